@@ -0,0 +1,190 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+	"maps"
+	"math"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"weather-app/internal/forecast"
+	"weather-app/internal/forecastlog"
+	"weather-app/internal/provider"
+	"weather-app/internal/render"
+)
+
+// archiveDelay is how many days the Open-Meteo archive lags behind today.
+// More recent days have no recorded values yet.
+const archiveDelay = 5
+
+// leadError is the mean absolute error of the forecasts made a number of
+// days ahead, in °C and mm.
+type leadError struct {
+	LeadDays      int      `json:"lead_days"`
+	Forecasts     int      `json:"forecasts"`
+	TempMax       float64  `json:"temp_max_mae"`
+	TempMin       float64  `json:"temp_min_mae"`
+	Precipitation *float64 `json:"precipitation_mae,omitempty"`
+
+	precipCount int
+}
+
+// meanAbsoluteErrors compares logged forecast days with the recorded day
+// actual returns for them, and averages the errors per lead day.
+func meanAbsoluteErrors(days []forecastlog.Day, actual func(forecastlog.Day) (forecast.Day, bool)) []leadError {
+	var errs []leadError
+	byLead := map[int]*leadError{}
+	for _, day := range days {
+		got, ok := actual(day)
+		if !ok {
+			continue
+		}
+		e, ok := byLead[day.LeadDays]
+		if !ok {
+			e = &leadError{LeadDays: day.LeadDays}
+			byLead[day.LeadDays] = e
+		}
+		e.Forecasts++
+		e.TempMax += math.Abs(day.TempMax - got.TempMax)
+		e.TempMin += math.Abs(day.TempMin - got.TempMin)
+		if day.Precipitation != nil && got.Precipitation != nil {
+			if e.Precipitation == nil {
+				e.Precipitation = new(float64)
+			}
+			*e.Precipitation += math.Abs(*day.Precipitation - *got.Precipitation)
+			e.precipCount++
+		}
+	}
+
+	leads := slices.Sorted(maps.Keys(byLead))
+	for _, lead := range leads {
+		e := byLead[lead]
+		e.TempMax = math.Round(e.TempMax/float64(e.Forecasts)*10) / 10
+		e.TempMin = math.Round(e.TempMin/float64(e.Forecasts)*10) / 10
+		if e.Precipitation != nil {
+			*e.Precipitation = math.Round(*e.Precipitation/float64(e.precipCount)*10) / 10
+		}
+		errs = append(errs, *e)
+	}
+	return errs
+}
+
+func accuracyTable(errs []leadError) error {
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "Lead days\tForecasts\tHigh\tLow\tPrecip\t")
+	for _, e := range errs {
+		precip := "-"
+		if e.Precipitation != nil {
+			precip = fmt.Sprintf("%.1f mm", *e.Precipitation)
+		}
+		fmt.Fprintf(tw, "%d\t%d\t%.1f °C\t%.1f °C\t%s\t\n", e.LeadDays, e.Forecasts, e.TempMax, e.TempMin, precip)
+	}
+	return tw.Flush()
+}
+
+func runAccuracy(ctx context.Context, args []string) {
+	fs := flag.NewFlagSet("accuracy", flag.ExitOnError)
+	var client clientFlags
+	client.register(fs)
+	path := fs.String("db", "", "SQLite database written by -log-db - *Mandatory")
+	city := fs.String("city", "", "Only score forecasts for this city - Optional")
+	format := fs.String("o", "table", "Output format: table or json - Optional")
+
+	fs.Usage = func() {
+		fmt.Println("Score the forecasts saved with -log-db against the weather recorded since.")
+		fmt.Println("Reports the mean absolute error of the high, low and precipitation for each")
+		fmt.Println("number of days the forecast was made ahead. Recorded values come from the")
+		fmt.Println("Open-Meteo archive, which lags a few days behind.")
+		fmt.Println("Usage:")
+		fmt.Println("  weather-app accuracy -db PATH [flags]")
+		fmt.Println()
+		fmt.Println("Optional Flags:")
+		fmt.Println("  -city           Only score forecasts for this city")
+		fmt.Println("  -provider       Only score forecasts from this provider")
+		fmt.Println("  -o              Output format: table or json (default table)")
+		printClientUsage()
+	}
+
+	fs.Parse(args)
+	if *path == "" {
+		fs.Usage()
+		os.Exit(exitUsage)
+	}
+	if *format != "table" && *format != "json" {
+		fatalUsage("accuracy can only be shown as table or json")
+	}
+	if _, err := os.Stat(*path); err != nil {
+		fatal(err)
+	}
+	providerSet := false
+	fs.Visit(func(f *flag.Flag) { providerSet = providerSet || f.Name == "provider" })
+
+	db, err := forecastlog.Open(*path)
+	if err != nil {
+		fatal(err)
+	}
+	defer db.Close()
+
+	cutoff := time.Now().AddDate(0, 0, -archiveDelay).Format("2006-01-02")
+	logged, err := db.DaysBefore(ctx, cutoff)
+	if err != nil {
+		fatal(err)
+	}
+	var days []forecastlog.Day
+	for _, day := range logged {
+		if *city != "" && !strings.EqualFold(day.Location.Name, *city) {
+			continue
+		}
+		if providerSet && day.Provider != client.provider {
+			continue
+		}
+		days = append(days, day)
+	}
+	if len(days) == 0 {
+		fatal(fmt.Sprintf("No logged forecasts before %s to score", cutoff))
+	}
+
+	if err := client.setup(slog.LevelWarn); err != nil {
+		fatal(err)
+	}
+	p := &provider.OpenMeteo{Client: client.newClient()}
+
+	// Fetch the recorded weather once per location, for all its dates.
+	type place struct{ lat, lon float64 }
+	recorded := map[place]map[string]forecast.Day{}
+	for i := 0; i < len(days); {
+		loc := days[i].Location
+		key := place{loc.Latitude, loc.Longitude}
+		start, end := days[i].Date, days[i].Date
+		for ; i < len(days) && days[i].Location.Latitude == key.lat && days[i].Location.Longitude == key.lon; i++ {
+			end = max(end, days[i].Date)
+		}
+		f, err := p.Archive(ctx, loc, provider.Options{Precipitation: true}, start, end)
+		if err != nil {
+			fatal(fmt.Errorf("%s: %w", loc.Name, err))
+		}
+		recorded[key] = map[string]forecast.Day{}
+		for _, day := range f.Days {
+			recorded[key][day.Date.String()] = day
+		}
+	}
+
+	errs := meanAbsoluteErrors(days, func(day forecastlog.Day) (forecast.Day, bool) {
+		got, ok := recorded[place{day.Location.Latitude, day.Location.Longitude}][day.Date]
+		return got, ok
+	})
+	if *format == "json" {
+		err = render.JSON(os.Stdout, errs)
+	} else {
+		err = accuracyTable(errs)
+	}
+	if err != nil {
+		fatal(err)
+	}
+}
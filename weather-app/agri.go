@@ -0,0 +1,115 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"weather-app/internal/forecast"
+	"weather-app/internal/provider"
+	"weather-app/internal/units"
+)
+
+// defaultGDDBase is the usual base temperature of growing degree days, for
+// maize and many vegetables: 10 °C, or 50 °F.
+const defaultGDDBase = 10.0
+
+// fetchAgri returns the agricultural forecast in the units of opts, with
+// growing degree days above base, a temperature in those units.
+func fetchAgri(ctx context.Context, p provider.Provider, place forecast.Location, opts forecastOptions, base float64) (forecast.Forecast, error) {
+	agri, ok := p.(provider.AgriProvider)
+	if !ok {
+		return forecast.Forecast{}, fmt.Errorf("%s does not forecast evapotranspiration or soil conditions, try -provider open-meteo", p.Name())
+	}
+	f, err := opts.convert(agri.AgriForecast(ctx, place, opts.Days, opts.PastDays))
+	if err != nil {
+		return f, err
+	}
+	forecast.GrowingDegreeDays(f.Agri, base)
+	return f, nil
+}
+
+func runAgri(ctx context.Context, args []string) {
+	fs := flag.NewFlagSet("agri", flag.ExitOnError)
+	var loc locationFlags
+	var client clientFlags
+	loc.register(fs)
+	client.register(fs)
+	var opts forecastOptions
+	fs.BoolVar(&opts.Fahrenheit, "f", false, "Use fahrenheit - Optional")
+	fs.StringVar(&opts.Units, "units", string(units.Metric), "Unit system: "+strings.Join(units.Systems, ", ")+" - Optional")
+	fs.IntVar(&opts.Days, "days", defaultDays, "Number of forecast days (1-16) - Optional")
+	fs.IntVar(&opts.PastDays, "past-days", 0, "Also show this many recent days, counted in the GDD total (0-92) - Optional")
+	base := fs.Float64("base", defaultGDDBase, "Base temperature of growing degree days, in the temperature unit (default 10 °C or 50 °F) - Optional")
+	var out outputFlags
+	out.register(fs)
+
+	fs.Usage = func() {
+		fmt.Println("Growing degree days, reference evapotranspiration (FAO-56 ET0) and soil")
+		fmt.Println("temperature and moisture per day, for gardeners and farmers.")
+		fmt.Println("Usage:")
+		fmt.Println("  weather-app agri [flags]")
+		fmt.Println()
+		printLocationUsage()
+		fmt.Println()
+		fmt.Println("Optional Flags:")
+		fmt.Println("  -base           Base temperature of growing degree days, in the temperature unit")
+		fmt.Println("                  (default 10 °C, or 50 °F with -f or -units imperial)")
+		fmt.Println("  -days           Number of forecast days (default 7, max 16)")
+		fmt.Println("  -past-days      Also show this many days before today (max 92); the GDD total")
+		fmt.Println("                  runs from the first day shown")
+		fmt.Println("  -units          Unit system: metric, imperial or si (default metric)")
+		fmt.Println("  -f              Use fahrenheit whatever the unit system")
+		printOutputUsage()
+		printClientUsage()
+		fmt.Println()
+		fmt.Println("  Soil temperature is the daily mean at 6 cm, soil moisture the mean water")
+		fmt.Println("  content at 3-9 cm as a percentage of the soil's volume.")
+	}
+
+	parseLocation(fs, &loc, args)
+
+	if err := out.validate(); err != nil {
+		fatalUsage(err)
+	}
+	client.language = out.lang
+	switch out.format {
+	case "table", "json", "yaml", "csv":
+	default:
+		fatalUsage("agricultural forecasts can only be shown as table, json, yaml or csv")
+	}
+	if err := opts.validate(); err != nil {
+		fatalUsage(err)
+	}
+	baseSet := false
+	fs.Visit(func(f *flag.Flag) { baseSet = baseSet || f.Name == "base" })
+	unit := opts.units().Temperature
+	if !baseSet {
+		*base = units.Temperature(defaultGDDBase, units.Celsius, unit)
+	}
+
+	if err := client.setup(slog.LevelWarn); err != nil {
+		fatal(err)
+	}
+
+	p, err := client.newProvider()
+	if err != nil {
+		fatal(err)
+	}
+
+	place, err := loc.resolve(ctx, p)
+	if err != nil {
+		fatal(err)
+	}
+
+	f, err := fetchAgri(ctx, p, place, opts, *base)
+	if err != nil {
+		fatal(err)
+	}
+
+	if err := out.render(f); err != nil {
+		fatal(err)
+	}
+}
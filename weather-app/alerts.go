@@ -0,0 +1,81 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+
+	"weather-app/internal/forecast"
+	"weather-app/internal/provider"
+)
+
+func fetchAlerts(ctx context.Context, p provider.Provider, place forecast.Location) ([]forecast.Alert, error) {
+	alerts, ok := p.(provider.AlertProvider)
+	if !ok {
+		return nil, fmt.Errorf("%s does not publish weather alerts, try -provider nws", p.Name())
+	}
+	return alerts.Alerts(ctx, place)
+}
+
+func runAlerts(ctx context.Context, args []string) {
+	fs := flag.NewFlagSet("alerts", flag.ExitOnError)
+	var loc locationFlags
+	var client clientFlags
+	loc.register(fs)
+	client.register(fs)
+	var out outputFlags
+	out.register(fs)
+
+	fs.Usage = func() {
+		fmt.Println("Active severe weather warnings, watches and advisories for a city.")
+		fmt.Println("Only providers that publish alerts are supported, e.g. -provider nws.")
+		fmt.Println("Usage:")
+		fmt.Println("  weather-app alerts [flags]")
+		fmt.Println()
+		printLocationUsage()
+		fmt.Println()
+		fmt.Println("Optional Flags:")
+		printOutputUsage()
+		printClientUsage()
+	}
+
+	parseLocation(fs, &loc, args)
+
+	if err := out.validate(); err != nil {
+		fatalUsage(err)
+	}
+	client.language = out.lang
+	switch out.format {
+	case "table", "json", "yaml", "markdown", "html":
+	default:
+		fatalUsage("alerts can only be shown as table, json, yaml, markdown or html")
+	}
+
+	if err := client.setup(slog.LevelWarn); err != nil {
+		fatal(err)
+	}
+
+	p, err := client.newProvider()
+	if err != nil {
+		fatal(err)
+	}
+
+	place, err := loc.resolve(ctx, p)
+	if err != nil {
+		fatal(err)
+	}
+
+	alerts, err := fetchAlerts(ctx, p, place)
+	if err != nil {
+		fatal(err)
+	}
+
+	if len(alerts) == 0 && out.format == "table" {
+		fmt.Println("No active alerts")
+		return
+	}
+	if err := out.render(forecast.Forecast{Location: place, Alerts: alerts}); err != nil {
+		fatal(err)
+	}
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// readBatch reads the city,country pairs of a -batch file, one per line.
+// Blank lines, lines starting with # and a city,country header are
+// skipped.
+func readBatch(r io.Reader, name string) (cities, countries []string, err error) {
+	cr := csv.NewReader(r)
+	cr.Comment = '#'
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+	for {
+		record, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("Error reading %s: %w", name, err)
+		}
+		line, _ := cr.FieldPos(0)
+		if len(record) != 2 || strings.TrimSpace(record[0]) == "" || strings.TrimSpace(record[1]) == "" {
+			return nil, nil, fmt.Errorf("%s line %d: expected city,country, e.g. \"The Hague,Netherlands\"", name, line)
+		}
+		city, country := strings.TrimSpace(record[0]), strings.TrimSpace(record[1])
+		if len(cities) == 0 && strings.EqualFold(city, "city") && strings.EqualFold(country, "country") {
+			continue
+		}
+		cities = append(cities, city)
+		countries = append(countries, country)
+	}
+	if len(cities) == 0 {
+		return nil, nil, fmt.Errorf("No locations in %s", name)
+	}
+	return cities, countries, nil
+}
+
+// loadBatch replaces the cities and countries with those of the -batch
+// file, or of standard input for "-".
+func (l *locationFlags) loadBatch() error {
+	for _, name := range []string{"city", "country", "zip", "airport", "lat", "lon", "fav", "auto"} {
+		if l.isSet(name) {
+			return errors.New("-batch cannot be combined with -" + name)
+		}
+	}
+	var r io.Reader = os.Stdin
+	name := "standard input"
+	if l.batch != "-" {
+		f, err := os.Open(l.batch)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		r, name = f, l.batch
+	}
+	cities, countries, err := readBatch(r, name)
+	if err != nil {
+		return err
+	}
+	l.cities, l.countries = cities, countries
+	if !l.isSet("pick") {
+		// Asking about every ambiguous city of a long list is no use.
+		l.pick = 1
+	}
+	return nil
+}
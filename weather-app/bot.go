@@ -0,0 +1,175 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"html"
+	"log/slog"
+	"os"
+	"strings"
+	"time"
+
+	"weather-app/internal/forecast"
+	"weather-app/internal/httpclient"
+	"weather-app/internal/provider"
+	"weather-app/internal/telegram"
+	"weather-app/internal/units"
+	"weather-app/internal/wmo"
+	"weather-app/pkg/openmeteo"
+)
+
+// telegramTokenEnv holds the bot token when the config file does not.
+const telegramTokenEnv = "TELEGRAM_BOT_TOKEN"
+
+const botHelp = "Send /weather followed by a city and its country, e.g. /weather Paris, France"
+
+func runBot(ctx context.Context, args []string) {
+	if len(args) == 0 || args[0] != "telegram" {
+		fmt.Println("Usage:")
+		fmt.Println("  weather-app bot telegram [flags]   Answer /weather messages sent to a Telegram bot")
+		os.Exit(exitUsage)
+	}
+	fs := flag.NewFlagSet("bot telegram", flag.ExitOnError)
+	var client clientFlags
+	client.register(fs)
+	opts := forecastOptions{Precipitation: true}
+	fs.BoolVar(&opts.Fahrenheit, "f", false, "Use fahrenheit - Optional")
+	fs.StringVar(&opts.Units, "units", string(units.Metric), "Unit system: "+strings.Join(units.Systems, ", ")+" - Optional")
+	fs.IntVar(&opts.Days, "days", 3, "Number of forecast days (1-16) - Optional")
+	configPath := fs.String("config", "", "Path to the config file - Optional")
+
+	fs.Usage = func() {
+		fmt.Println("Run a Telegram bot that answers /weather CITY, COUNTRY with the forecast.")
+		fmt.Println("Usage:")
+		fmt.Println("  weather-app bot telegram [flags]")
+		fmt.Println()
+		fmt.Println("The bot token, from @BotFather, is read from $" + telegramTokenEnv + " or from")
+		fmt.Println("the config file:")
+		fmt.Println()
+		fmt.Println("  [telegram]")
+		fmt.Println("  token = \"123456:ABC...\"")
+		fmt.Println()
+		fmt.Println("Optional Flags:")
+		fmt.Println("  -days           Number of forecast days per answer (default 3, max 16)")
+		fmt.Println("  -units          Unit system: metric, imperial or si (default metric)")
+		fmt.Println("  -f              Use fahrenheit whatever the unit system")
+		fmt.Println("  -config         Path to the config file")
+		printClientUsage()
+	}
+
+	fs.Parse(args[1:])
+
+	cfg, err := loadConfig(*configPath)
+	if err != nil {
+		fatalUsage("Error reading config:", err)
+	}
+	token := os.Getenv(telegramTokenEnv)
+	if token == "" {
+		token = cfg.Telegram.Token
+	}
+	if token == "" {
+		fatal("No bot token: set $" + telegramTokenEnv + " or token in the [telegram] table of the config file")
+	}
+	if err := opts.validate(); err != nil {
+		fatalUsage(err)
+	}
+
+	if err := client.setup(slog.LevelInfo); err != nil {
+		fatal(err)
+	}
+
+	p, err := client.newProvider()
+	if err != nil {
+		fatal(err)
+	}
+
+	bot := &telegram.Bot{Token: token, Client: httpclient.New(telegram.ClientTimeout)}
+	slog.Info("bot started")
+	offset := 0
+	for ctx.Err() == nil {
+		updates, err := bot.Updates(ctx, offset)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			slog.Warn("polling telegram", "err", err)
+			select {
+			case <-ctx.Done():
+			case <-time.After(5 * time.Second):
+			}
+			continue
+		}
+		for _, u := range updates {
+			offset = u.UpdateID + 1
+			if u.Message == nil || u.Message.Text == "" {
+				continue
+			}
+			reply := botReply(ctx, p, opts, u.Message.Text)
+			if err := bot.SendHTML(ctx, u.Message.Chat.ID, reply); err != nil {
+				slog.Warn("replying", "chat", u.Message.Chat.ID, "err", err)
+			}
+		}
+	}
+}
+
+// botReply answers a message: the forecast for /weather CITY, COUNTRY and
+// the help otherwise.
+func botReply(ctx context.Context, p provider.Provider, opts forecastOptions, text string) string {
+	command, arg, _ := strings.Cut(strings.TrimSpace(text), " ")
+	// In groups commands are addressed as /weather@SomeBot.
+	command, _, _ = strings.Cut(command, "@")
+	if command != "/weather" {
+		return html.EscapeString(botHelp)
+	}
+	city, country, err := parsePlace(arg)
+	if err != nil {
+		return html.EscapeString(botHelp)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+	matches, err := p.Geocode(ctx, city, country)
+	if errors.Is(err, openmeteo.ErrCityNotFound) {
+		msg := fmt.Sprintf("Could not find %s in %s.", city, country)
+		var nf *openmeteo.NotFoundError
+		if errors.As(err, &nf) && len(nf.Suggestions) > 0 {
+			msg += " Did you mean " + strings.Join(nf.Suggestions, " or ") + "?"
+		}
+		return html.EscapeString(msg)
+	}
+	if err != nil {
+		slog.Warn("geocoding", "city", city, "country", country, "err", err)
+		return "The weather service cannot be reached, please try again later."
+	}
+	f, err := fetchDaily(ctx, p, matches[0].Location, opts)
+	if err != nil {
+		slog.Warn("fetching forecast", "city", city, "country", country, "err", err)
+		return "The weather service cannot be reached, please try again later."
+	}
+	return botForecast(f)
+}
+
+// botForecast formats f for Telegram, one line per day, e.g.
+// "⛅ Mon 3 Jun  21/12 °C  Partly cloudy, 40% rain".
+func botForecast(f forecast.Forecast) string {
+	var b strings.Builder
+	b.WriteString("<b>Weather in " + html.EscapeString(placeLabel(f.Location.Name, f.Location.Country)) + "</b>\n")
+	degrees := units.Degrees(f.Units.Temperature)
+	for _, day := range f.Days {
+		icon := ""
+		if day.WeatherCode != nil {
+			icon = wmo.Icon(*day.WeatherCode, "emoji") + " "
+		}
+		fmt.Fprintf(&b, "%s%s  %.0f/%.0f %s", icon, day.Date.Format("Mon 2 Jan"), day.TempMax, day.TempMin, degrees)
+		if day.Description != "" {
+			b.WriteString("  " + html.EscapeString(day.Description))
+		}
+		if day.PrecipChance != nil {
+			fmt.Fprintf(&b, ", %.0f%% rain", *day.PrecipChance)
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"math"
+	"time"
+
+	"weather-app/internal/forecast"
+	"weather-app/internal/units"
+)
+
+// The smallest moves -diff reports, so that rounding noise between two
+// runs of a model does not count as a change.
+const (
+	changeDegrees = 1.0
+	changeChance  = 10.0 // percentage points
+	changeMM      = 1.0
+	changeKMH     = 5.0
+)
+
+// markChanges sets the Changes of the days of f that the earlier forecast,
+// fetched at fetched, also had. Both must be in the same units.
+func markChanges(f *forecast.Forecast, earlier forecast.Forecast, fetched time.Time) {
+	before := map[string]forecast.Day{}
+	for _, day := range earlier.Days {
+		before[day.Date.String()] = day
+	}
+	precip := units.Length(changeMM, units.Millimetres, f.Units.Precipitation)
+	wind := units.Speed(changeKMH, units.KilometresPerHour, f.Units.WindSpeed)
+
+	for i := range f.Days {
+		day := &f.Days[i]
+		prev, ok := before[day.Date.String()]
+		if !ok || day.Observed {
+			continue
+		}
+		add := func(of string, from, to *float64, least float64) {
+			if from != nil && to != nil && math.Abs(*to-*from) >= least {
+				day.Changes = append(day.Changes, forecast.Change{Of: of, From: *from, To: *to})
+			}
+		}
+		add(forecast.ChangeHigh, &prev.TempMax, &day.TempMax, changeDegrees)
+		add(forecast.ChangeLow, &prev.TempMin, &day.TempMin, changeDegrees)
+		add(forecast.ChangePrecipChance, prev.PrecipChance, day.PrecipChance, changeChance)
+		add(forecast.ChangePrecipitation, prev.Precipitation, day.Precipitation, precip)
+		add(forecast.ChangeWind, prev.WindSpeedMax, day.WindSpeedMax, wind)
+	}
+	f.ChangedSince = &fetched
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+
+	"weather-app/internal/chart"
+	"weather-app/internal/units"
+)
+
+func runChart(ctx context.Context, args []string) {
+	fs := flag.NewFlagSet("chart", flag.ExitOnError)
+	var loc locationFlags
+	var client clientFlags
+	loc.register(fs)
+	client.register(fs)
+	opts := forecastOptions{Precipitation: true}
+	fs.IntVar(&opts.Days, "days", defaultDays, "Number of forecast days (1-16) - Optional")
+	fs.BoolVar(&opts.Fahrenheit, "f", false, "Use fahrenheit - Optional")
+	fs.StringVar(&opts.Units, "units", string(units.Metric), "Unit system: "+strings.Join(units.Systems, ", ")+" - Optional")
+	path := fs.String("out", "", "Image file to write, ending in .png or .svg - *Mandatory")
+
+	fs.Usage = func() {
+		fmt.Println("Draw the daily highs, lows and precipitation as a PNG or SVG image.")
+		fmt.Println("Usage:")
+		fmt.Println("  weather-app chart -out FILE [flags]")
+		fmt.Println()
+		printLocationUsage()
+		fmt.Println("  -out            Image file to write; .png or .svg picks the format")
+		fmt.Println()
+		fmt.Println("Optional Flags:")
+		fmt.Printf("  -days           Number of forecast days (default %d, max 16)\n", defaultDays)
+		fmt.Println("  -units          Unit system: metric, imperial or si (default metric)")
+		fmt.Println("  -f              Use fahrenheit whatever the unit system")
+		printClientUsage()
+	}
+
+	parseLocation(fs, &loc, args)
+
+	if *path == "" {
+		fs.Usage()
+		os.Exit(exitUsage)
+	}
+	format, err := chart.FormatOf(*path)
+	if err != nil {
+		fatalUsage(err)
+	}
+	if err := opts.validate(); err != nil {
+		fatalUsage(err)
+	}
+
+	if err := client.setup(slog.LevelWarn); err != nil {
+		fatal(err)
+	}
+
+	p, err := client.newProvider()
+	if err != nil {
+		fatal(err)
+	}
+
+	place, err := loc.resolve(ctx, p)
+	if err != nil {
+		fatal(err)
+	}
+
+	f, err := fetchDaily(ctx, p, place, opts)
+	if err != nil {
+		fatal(err)
+	}
+
+	out, err := os.Create(*path)
+	if err != nil {
+		fatal(err)
+	}
+	if err := chart.Write(out, format, f); err != nil {
+		out.Close()
+		fatal(err)
+	}
+	if err := out.Close(); err != nil {
+		fatal(err)
+	}
+}
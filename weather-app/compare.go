@@ -0,0 +1,201 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"time"
+
+	"weather-app/internal/forecast"
+	"weather-app/internal/pool"
+	"weather-app/internal/provider"
+	"weather-app/internal/render"
+	"weather-app/internal/units"
+)
+
+// Several cities are fetched by maxParallel workers, so a long -batch list
+// does not open hundreds of connections. Each city gets requestTimeout,
+// which covers its retries and waits for the rate limit.
+const (
+	maxParallel    = 8
+	requestTimeout = time.Minute
+)
+
+// failures is the error of a set of requests of which some failed. The
+// requests that succeeded can still be shown.
+type failures struct {
+	what  string
+	total int
+	errs  []error
+}
+
+func (f *failures) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d of %d %s failed:", len(f.errs), f.total, f.what)
+	for _, err := range f.errs {
+		b.WriteString("\n  " + err.Error())
+	}
+	return b.String()
+}
+
+// dailyRequest is one of several daily forecasts fetched side by side;
+// label names it in errors.
+type dailyRequest struct {
+	label string
+	place forecast.Location
+	opts  forecastOptions
+}
+
+// fetchDailyForecasts requests the same daily forecast for every place
+// concurrently.
+func fetchDailyForecasts(ctx context.Context, p provider.Provider, places []forecast.Location, opts forecastOptions) ([]forecast.Forecast, error) {
+	requests := make([]dailyRequest, len(places))
+	for i, place := range places {
+		requests[i] = dailyRequest{label: place.Name, place: place, opts: opts}
+	}
+	return fetchConcurrently(ctx, p, requests)
+}
+
+// fetchModelForecasts requests the daily forecast for place from each of
+// the models in opts concurrently.
+func fetchModelForecasts(ctx context.Context, p provider.Provider, place forecast.Location, opts forecastOptions) ([]forecast.Forecast, error) {
+	var requests []dailyRequest
+	for _, o := range opts.byModel() {
+		requests = append(requests, dailyRequest{label: o.Model, place: place, opts: o})
+	}
+	return fetchConcurrently(ctx, p, requests)
+}
+
+// fetchConcurrently returns the forecasts that could be fetched, in the
+// order of requests, and a *failures listing the others.
+func fetchConcurrently(ctx context.Context, p provider.Provider, requests []dailyRequest) ([]forecast.Forecast, error) {
+	results := pool.Run(ctx, maxParallel, requestTimeout, requests, func(ctx context.Context, req dailyRequest) (forecast.Forecast, error) {
+		return fetchDaily(ctx, p, req.place, req.opts)
+	})
+
+	var forecasts []forecast.Forecast
+	failed := &failures{what: "forecasts", total: len(requests)}
+	for i, r := range results {
+		if r.Err != nil {
+			failed.errs = append(failed.errs, fmt.Errorf("%s: %w", requests[i].label, r.Err))
+			continue
+		}
+		forecasts = append(forecasts, r.Value)
+	}
+	if len(failed.errs) > 0 {
+		return forecasts, failed
+	}
+	return forecasts, nil
+}
+
+// parsePlace splits a "city,country" argument of compare. The country is
+// after the last comma, so a city may contain one.
+func parsePlace(arg string) (city, country string, err error) {
+	i := strings.LastIndex(arg, ",")
+	if i < 0 {
+		return "", "", fmt.Errorf("Expected city,country, e.g. \"Paris,France\", got %q", arg)
+	}
+	city, country = strings.TrimSpace(arg[:i]), strings.TrimSpace(arg[i+1:])
+	if city == "" || country == "" {
+		return "", "", fmt.Errorf("Expected city,country, e.g. \"Paris,France\", got %q", arg)
+	}
+	return city, country, nil
+}
+
+func runCompare(ctx context.Context, args []string) {
+	fs := flag.NewFlagSet("compare", flag.ExitOnError)
+	var client clientFlags
+	client.register(fs)
+	var opts forecastOptions
+	fs.BoolVar(&opts.Fahrenheit, "f", false, "Use fahrenheit - Optional")
+	fs.StringVar(&opts.Units, "units", string(units.Metric), "Unit system: "+strings.Join(units.Systems, ", ")+" - Optional")
+	fs.IntVar(&opts.Days, "days", defaultDays, "Number of forecast days (1-16) - Optional")
+	pick := fs.Int("pick", 0, "Pick the Nth matching city instead of asking - Optional")
+	var out outputFlags
+	out.register(fs)
+
+	fs.Usage = func() {
+		fmt.Println("Day by day difference in temperature and precipitation between two places,")
+		fmt.Println("e.g. to choose where to spend the weekend.")
+		fmt.Println("Usage:")
+		fmt.Println("  weather-app compare CITY,COUNTRY CITY,COUNTRY [flags]")
+		fmt.Println()
+		fmt.Println("Differences are the second place's values minus the first's.")
+		fmt.Println()
+		fmt.Println("Optional Flags:")
+		fmt.Println("  -days           Number of forecast days (default 7, max 16)")
+		fmt.Println("  -units          Unit system: metric, imperial or si (default metric)")
+		fmt.Println("  -f              Use fahrenheit whatever the unit system")
+		fmt.Println("  -pick           Pick the Nth matching city instead of asking")
+		printOutputUsage()
+		printClientUsage()
+	}
+
+	// The places may come before or after the flags.
+	var placeArgs []string
+	for len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		placeArgs, args = append(placeArgs, args[0]), args[1:]
+	}
+	fs.Parse(args)
+	placeArgs = append(placeArgs, fs.Args()...)
+	if len(placeArgs) != 2 {
+		fs.Usage()
+		os.Exit(exitUsage)
+	}
+	loc := locationFlags{fs: fs, pick: *pick}
+	for _, arg := range placeArgs {
+		city, country, err := parsePlace(arg)
+		if err != nil {
+			fatalUsage(err)
+		}
+		loc.cities = append(loc.cities, city)
+		loc.countries = append(loc.countries, country)
+	}
+
+	if err := out.validate(); err != nil {
+		fatalUsage(err)
+	}
+	client.language = out.lang
+	switch out.format {
+	case "table", "json", "yaml":
+	default:
+		fatalUsage("A comparison can only be shown as table, json or yaml")
+	}
+	opts.Precipitation = true
+	if err := opts.validate(); err != nil {
+		fatalUsage(err)
+	}
+
+	if err := client.setup(slog.LevelWarn); err != nil {
+		fatal(err)
+	}
+
+	p, err := client.newProvider()
+	if err != nil {
+		fatal(err)
+	}
+
+	places, err := loc.resolveAll(ctx, p)
+	if err != nil {
+		fatal(err)
+	}
+	forecasts, err := fetchDailyForecasts(ctx, p, places, opts)
+	if err != nil {
+		fatal(err)
+	}
+
+	diff := forecast.Diff(forecasts[0], forecasts[1])
+	if len(diff.Days) == 0 {
+		fatal(fmt.Sprintf("The forecasts for %s and %s have no day in common", places[0].Name, places[1].Name))
+	}
+	err = out.write(func(w io.Writer) error {
+		return render.Difference(w, out.format, diff, out.renderOptions(w))
+	})
+	if err != nil {
+		fatal(err)
+	}
+}
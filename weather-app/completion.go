@@ -0,0 +1,287 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"weather-app/internal/activity"
+	"weather-app/internal/completion"
+	"weather-app/internal/favorites"
+	"weather-app/internal/geoip"
+	"weather-app/internal/i18n"
+	"weather-app/internal/provider"
+	"weather-app/internal/render"
+	"weather-app/internal/reverse"
+	"weather-app/internal/units"
+	"weather-app/internal/webhook"
+)
+
+// flagValues are the values offered when completing a flag's argument.
+var flagValues = map[string][]string{
+	"o":                render.Formats,
+	"units":            units.Systems,
+	"wind-unit":        {"kmh", "ms", "mph", "kn"},
+	"icons":            {"emoji", "ascii"},
+	"log-format":       {"text", "json"},
+	"format":           webhook.Formats,
+	"provider":         provider.Names(),
+	"auto-provider":    geoip.Providers(),
+	"reverse-provider": reverse.Providers(),
+	"score":            activity.Names(),
+	"model":            {"best_match", "gfs", "icon", "ecmwf"},
+	"lang":             i18n.Languages,
+	"locale":           i18n.Locales(),
+}
+
+// commandFlags lists the flags a command registers. The shared flag groups
+// are registered on a scratch FlagSet, so completion follows them as they
+// change; extra holds the flags only that command defines.
+func commandFlags(groups []func(*flag.FlagSet), extra map[string]string) []completion.Flag {
+	fs := flag.NewFlagSet("completion", flag.ContinueOnError)
+	for _, register := range groups {
+		register(fs)
+	}
+	for name, usage := range extra {
+		if fs.Lookup(name) == nil {
+			fs.String(name, "", usage)
+		}
+	}
+
+	var flags []completion.Flag
+	fs.VisitAll(func(f *flag.Flag) {
+		flags = append(flags, completion.Flag{
+			Name:    f.Name,
+			Usage:   f.Usage,
+			Values:  flagValues[f.Name],
+			Dynamic: f.Name == "fav",
+		})
+	})
+	sort.Slice(flags, func(i, j int) bool { return flags[i].Name < flags[j].Name })
+	return flags
+}
+
+var (
+	locationGroup = func(fs *flag.FlagSet) {
+		new(locationFlags).register(fs)
+		fs.String("config", "", "Path to the config file - Optional")
+	}
+	clientGroup  = func(fs *flag.FlagSet) { new(clientFlags).register(fs) }
+	outputGroup  = func(fs *flag.FlagSet) { new(outputFlags).register(fs) }
+	optionsGroup = func(fs *flag.FlagSet) { new(forecastOptions).register(fs) }
+)
+
+// unitFlags are the unit flags of the commands that don't take every
+// forecast option.
+var unitFlags = map[string]string{
+	"f":     "Use fahrenheit - Optional",
+	"units": "Unit system: " + strings.Join(units.Systems, ", ") + " - Optional",
+}
+
+func with(flags map[string]string, extra map[string]string) map[string]string {
+	merged := map[string]string{}
+	for name, usage := range flags {
+		merged[name] = usage
+	}
+	for name, usage := range extra {
+		merged[name] = usage
+	}
+	return merged
+}
+
+// completionSpec describes every command of weather-app for the completion
+// scripts.
+func completionSpec() completion.Spec {
+	all := []func(*flag.FlagSet){locationGroup, clientGroup, optionsGroup, outputGroup}
+	return completion.Spec{
+		Program:        "weather-app",
+		DynamicCommand: "completion favorites",
+		Commands: []completion.Command{
+			{Flags: commandFlags(all, map[string]string{
+				"hourly":         "Show hourly forecast - Optional",
+				"hours":          "Number of hours to show in hourly mode (1-384) - Optional",
+				"watch":          "Keep running and redraw the forecast periodically - Optional",
+				"interval":       "Refresh interval for -watch - Optional",
+				"tui":            "Open an interactive dashboard - Optional",
+				"alerts":         "Show active weather alerts above the forecast - Optional",
+				"post-webhook":   "Post the forecast to this Slack or Discord webhook URL - Optional",
+				"format":         "Webhook payload format for -post-webhook - Optional",
+				"from":           "First day to show: today, tomorrow, a weekday or YYYY-MM-DD - Optional",
+				"to":             "Last day to show: today, tomorrow, a weekday or YYYY-MM-DD - Optional",
+				"log-db":         "Append every fetched forecast to this SQLite database - Optional",
+				"mqtt":           "Publish the forecast as JSON to this MQTT broker - Optional",
+				"mqtt-topic":     "Topic prefix for -mqtt - Optional",
+				"mqtt-discovery": "Announce Home Assistant sensors for the -mqtt topics - Optional",
+				"brief":          "Print today's forecast on a single line, for status bars - Optional",
+				"brief-format":   "Go template of the -brief line - Optional",
+				"warn-exit":      "Exit with status 10 when a day crosses -warn-below or -warn-above - Optional",
+			})},
+			{Name: "now", Usage: "Current conditions", Flags: commandFlags(
+				[]func(*flag.FlagSet){locationGroup, clientGroup, outputGroup},
+				with(unitFlags, map[string]string{
+					"wind-unit": "Wind speed unit: kmh, ms, mph or kn, overriding -units - Optional",
+					"tz":        "Show times in this timezone, e.g. Europe/Amsterdam, or local - Optional",
+				}),
+			)},
+			{Name: "compare", Usage: "Day by day difference between two places", Flags: commandFlags(
+				[]func(*flag.FlagSet){clientGroup, outputGroup},
+				with(unitFlags, map[string]string{
+					"days": "Number of forecast days (1-16) - Optional",
+					"pick": "Pick the Nth matching city instead of asking - Optional",
+				}),
+			)},
+			{Name: "history", Usage: "Past weather from the archive", Flags: commandFlags(
+				[]func(*flag.FlagSet){locationGroup, clientGroup, outputGroup},
+				with(unitFlags, map[string]string{
+					"start": "First day to show, YYYY-MM-DD - *Mandatory",
+					"end":   "Last day to show, YYYY-MM-DD - *Mandatory",
+				}),
+			)},
+			{Name: "serve", Usage: "Serve forecasts as JSON over HTTP", Flags: commandFlags(
+				[]func(*flag.FlagSet){clientGroup},
+				map[string]string{
+					"addr": "Address to listen on - Optional",
+					"grpc": "Also serve the gRPC WeatherService on this address, e.g. :9090 - Optional",
+				},
+			)},
+			{Name: "export", Usage: "Export forecasts as Prometheus metrics", Flags: commandFlags(
+				[]func(*flag.FlagSet){locationGroup, clientGroup},
+				map[string]string{
+					"addr":     "Address to listen on - Optional",
+					"interval": "How often forecasts are refreshed - Optional",
+					"days":     "Number of forecast days (1-16) - Optional",
+				},
+			)},
+			{Name: "alerts", Usage: "Active severe weather alerts", Flags: commandFlags(
+				[]func(*flag.FlagSet){locationGroup, clientGroup, outputGroup}, nil,
+			)},
+			{Name: "marine", Usage: "Wave and swell forecast", Flags: commandFlags(
+				[]func(*flag.FlagSet){locationGroup, clientGroup, outputGroup},
+				map[string]string{"days": "Number of forecast days (1-8) - Optional"},
+			)},
+			{Name: "pollen", Usage: "Pollen counts and allergy levels", Flags: commandFlags(
+				[]func(*flag.FlagSet){locationGroup, clientGroup, outputGroup},
+				map[string]string{"days": "Number of forecast days (1-4) - Optional"},
+			)},
+			{Name: "agri", Usage: "Growing degree days, evapotranspiration and soil conditions", Flags: commandFlags(
+				[]func(*flag.FlagSet){locationGroup, clientGroup, outputGroup},
+				with(unitFlags, map[string]string{
+					"days":      "Number of forecast days (1-16) - Optional",
+					"past-days": "Also show this many recent days, counted in the GDD total (0-92) - Optional",
+					"base":      "Base temperature of growing degree days - Optional",
+				}),
+			)},
+			{Name: "wind-rose", Usage: "How often the wind blows from each direction", Flags: commandFlags(
+				[]func(*flag.FlagSet){locationGroup, clientGroup, outputGroup},
+				map[string]string{
+					"days":      "Number of forecast days (1-16) - Optional",
+					"units":     "Unit system: " + strings.Join(units.Systems, ", ") + " - Optional",
+					"wind-unit": "Wind speed unit: kmh, ms, mph or kn, overriding -units - Optional",
+				},
+			)},
+			{Name: "geocode", Usage: "Every place matching a name", Flags: commandFlags(
+				[]func(*flag.FlagSet){clientGroup},
+				map[string]string{
+					"country": "Only show places in this country, by name or ISO code - Optional",
+					"count":   "Number of results to search (1-100) - Optional",
+					"lang":    "Language of the place names, e.g. de - Optional",
+					"o":       "Output format: table or json - Optional",
+				},
+			)},
+			{Name: "chart", Usage: "Highs, lows and precipitation as an image", Flags: commandFlags(
+				[]func(*flag.FlagSet){locationGroup, clientGroup},
+				with(unitFlags, map[string]string{
+					"days": "Number of forecast days (1-16) - Optional",
+					"out":  "Image file to write, ending in .png or .svg - *Mandatory",
+				}),
+			)},
+			{Name: "notify", Usage: "Today's forecast as a desktop notification", Flags: commandFlags(
+				[]func(*flag.FlagSet){locationGroup, clientGroup},
+				with(unitFlags, map[string]string{"print": "Print the notification instead of sending it - Optional"}),
+			)},
+			{
+				Name:  "bot",
+				Usage: "Answer /weather messages sent to a chat bot",
+				Flags: commandFlags(
+					[]func(*flag.FlagSet){clientGroup},
+					with(unitFlags, map[string]string{
+						"days":   "Number of forecast days (1-16) - Optional",
+						"config": "Path to the config file - Optional",
+					}),
+				),
+				Subcommands: []string{"telegram"},
+			},
+			{Name: "install-timer", Usage: "Run notify every day at a chosen time", Flags: commandFlags(
+				[]func(*flag.FlagSet){locationGroup, clientGroup},
+				with(unitFlags, map[string]string{
+					"at":    "Time of day to notify, HH:MM - Optional",
+					"print": "Print the files and commands instead of installing them - Optional",
+				}),
+			)},
+			{
+				Name:  "favorites",
+				Usage: "Manage saved locations",
+				Flags: commandFlags(
+					[]func(*flag.FlagSet){locationGroup, clientGroup},
+					map[string]string{"default": "Use this favorite when no location is given - Optional"},
+				),
+				Subcommands: []string{"add", "list", "remove", "default"},
+				DynamicArgs: []string{"remove", "default"},
+			},
+			{Name: "accuracy", Usage: "Forecast error per lead day", Flags: commandFlags(
+				[]func(*flag.FlagSet){clientGroup},
+				map[string]string{
+					"db":   "SQLite database written by -log-db - *Mandatory",
+					"city": "Only score forecasts for this city - Optional",
+					"o":    "Output format: table or json - Optional",
+				},
+			)},
+			{
+				Name:        "db",
+				Usage:       "Query forecasts saved with -log-db",
+				Flags:       commandFlags(nil, map[string]string{"db": "SQLite database written by -log-db - *Mandatory"}),
+				Subcommands: []string{"query"},
+			},
+			{Name: "update", Usage: "Install the latest release from GitHub", Flags: commandFlags(nil, map[string]string{
+				"check": "Only report whether a newer release exists - Optional",
+				"force": "Install the latest release even if it is not newer - Optional",
+			})},
+			{Name: "version", Usage: "Version, commit and build date", Flags: commandFlags(nil, map[string]string{
+				"json": "Print the build info as JSON - Optional",
+			})},
+			{Name: "completion", Usage: "Shell completion script", Subcommands: completion.Shells},
+		},
+	}
+}
+
+func runCompletion(args []string) {
+	if len(args) != 1 {
+		fmt.Println("Print a shell completion script.")
+		fmt.Println("Usage:")
+		fmt.Println("  weather-app completion bash|zsh|fish")
+		fmt.Println()
+		fmt.Println("  bash:  source <(weather-app completion bash)")
+		fmt.Println("  zsh:   source <(weather-app completion zsh)")
+		fmt.Println("  fish:  weather-app completion fish | source")
+		os.Exit(exitUsage)
+	}
+
+	// The scripts run "weather-app completion favorites" to complete saved
+	// location names.
+	if args[0] == "favorites" {
+		store, err := favorites.LoadDefault()
+		if err != nil {
+			fatal(err)
+		}
+		for _, name := range store.Names() {
+			fmt.Println(name)
+		}
+		return
+	}
+
+	if err := completion.Write(os.Stdout, args[0], completionSpec()); err != nil {
+		fatal(err)
+	}
+}
@@ -0,0 +1,180 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"strings"
+	"time"
+
+	"weather-app/internal/forecast"
+)
+
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday, "sunday": time.Sunday,
+	"mon": time.Monday, "monday": time.Monday,
+	"tue": time.Tuesday, "tuesday": time.Tuesday,
+	"wed": time.Wednesday, "wednesday": time.Wednesday,
+	"thu": time.Thursday, "thursday": time.Thursday,
+	"fri": time.Friday, "friday": time.Friday,
+	"sat": time.Saturday, "saturday": time.Saturday,
+}
+
+// dateFilter limits a forecast to the days from -from to -to. Days are
+// given as today, tomorrow, weekend, a weekday or YYYY-MM-DD and resolved
+// in the forecast location's timezone, so "tomorrow" in Tokyo is Tokyo's
+// tomorrow whatever the local clock says.
+type dateFilter struct {
+	from string
+	to   string
+}
+
+func (d *dateFilter) register(fs *flag.FlagSet) {
+	fs.StringVar(&d.from, "from", "", "First day to show: today, tomorrow, a weekday or YYYY-MM-DD - Optional")
+	fs.StringVar(&d.to, "to", "", "Last day to show: today, tomorrow, a weekday or YYYY-MM-DD - Optional")
+}
+
+// setWord handles a positional day such as "tomorrow" or "weekend", which
+// selects just that day or weekend.
+func (d *dateFilter) setWord(word string) error {
+	if d.from != "" || d.to != "" {
+		return fmt.Errorf("%q cannot be combined with -from or -to", word)
+	}
+	word = strings.ToLower(word)
+	if word == "weekend" {
+		d.from, d.to = "weekend", "sun"
+		return nil
+	}
+	if _, err := resolveDay(word, time.Now()); err != nil {
+		return err
+	}
+	d.from, d.to = word, word
+	return nil
+}
+
+// isDayWord reports whether word is a day setWord accepts, rather than a
+// place.
+func isDayWord(word string) bool {
+	if strings.EqualFold(word, "weekend") {
+		return true
+	}
+	_, err := resolveDay(word, time.Now())
+	return err == nil
+}
+
+func (d dateFilter) active() bool {
+	return d.from != "" || d.to != ""
+}
+
+// resolveDay turns a day into a date on or after the day of after.
+func resolveDay(s string, after time.Time) (time.Time, error) {
+	day := time.Date(after.Year(), after.Month(), after.Day(), 0, 0, 0, 0, after.Location())
+	s = strings.ToLower(s)
+	switch s {
+	case "today":
+		return day, nil
+	case "tomorrow":
+		return day.AddDate(0, 0, 1), nil
+	case "weekend":
+		// On a Sunday the weekend is already under way.
+		if day.Weekday() == time.Sunday {
+			return day, nil
+		}
+		s = "sat"
+	}
+	if wd, ok := weekdays[s]; ok {
+		return day.AddDate(0, 0, (int(wd)-int(day.Weekday())+7)%7), nil
+	}
+	t, err := time.ParseInLocation("2006-01-02", s, after.Location())
+	if err != nil {
+		return time.Time{}, fmt.Errorf("Unknown day %q, expected today, tomorrow, weekend, a weekday or YYYY-MM-DD", s)
+	}
+	return t, nil
+}
+
+// bounds resolves the filter to the first and last date to show, as
+// YYYY-MM-DD. An empty last date means no limit.
+func (d dateFilter) bounds(now time.Time) (string, string, error) {
+	from := now
+	if d.from != "" {
+		var err error
+		if from, err = resolveDay(d.from, now); err != nil {
+			return "", "", err
+		}
+	}
+	if d.to == "" {
+		return from.Format("2006-01-02"), "", nil
+	}
+	// A weekday ends the range on or after its start, -from sat -to sun
+	// being one weekend; other days are counted from today.
+	base := now
+	if _, ok := weekdays[strings.ToLower(d.to)]; ok {
+		base = from
+	}
+	to, err := resolveDay(d.to, base)
+	if err != nil {
+		return "", "", err
+	}
+	if to.Before(from) {
+		return "", "", errors.New("-to cannot be before -from")
+	}
+	return from.Format("2006-01-02"), to.Format("2006-01-02"), nil
+}
+
+// daysNeeded is roughly how many forecast days cover the filter, measured
+// on the local clock; the location's timezone can shift it by a day.
+func (d dateFilter) daysNeeded(now time.Time) int {
+	first, last, err := d.bounds(now)
+	if err != nil {
+		return 0
+	}
+	if last == "" {
+		last = first
+	}
+	t, _ := time.ParseInLocation("2006-01-02", last, now.Location())
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	return int(t.Sub(today).Hours()/24) + 2
+}
+
+// apply keeps the days and hours of f within the filter, with today taken
+// in the location's timezone.
+func (d dateFilter) apply(f forecast.Forecast, now time.Time) (forecast.Forecast, error) {
+	if !d.active() {
+		return f, nil
+	}
+	zone, err := time.LoadLocation(f.Location.Timezone)
+	if err != nil {
+		zone = time.UTC
+	}
+	first, last, err := d.bounds(now.In(zone))
+	if err != nil {
+		return f, err
+	}
+	within := func(date string) bool {
+		return date >= first && (last == "" || date <= last)
+	}
+
+	var days []forecast.Day
+	for _, day := range f.Days {
+		if within(day.Date.String()) {
+			days = append(days, day)
+		}
+	}
+	var hours []forecast.Hour
+	for _, h := range f.Hours {
+		if within(h.Time.In(zone).Format("2006-01-02")) {
+			hours = append(hours, h)
+		}
+	}
+	if len(days) == 0 && len(hours) == 0 {
+		switch last {
+		case "":
+			return f, fmt.Errorf("No forecast from %s", first)
+		case first:
+			return f, fmt.Errorf("No forecast for %s", first)
+		}
+		return f, fmt.Errorf("No forecast between %s and %s", first, last)
+	}
+	f.Days, f.Hours = days, hours
+	return f, nil
+}
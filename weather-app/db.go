@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"weather-app/internal/forecastlog"
+)
+
+func dbUsage() {
+	fmt.Println("Query the forecasts saved with -log-db.")
+	fmt.Println("Usage:")
+	fmt.Println("  weather-app db query -db PATH SQL")
+	fmt.Println()
+	fmt.Println("Tables:")
+	fmt.Println("  fetches     One row per fetch: fetched_at, provider, location, country, latitude, longitude")
+	fmt.Println("  days        One row per forecast day: fetch_id, date, lead_days, temp_max, temp_min,")
+	fmt.Println("              precipitation, precipitation_probability_max, snowfall_sum, wind_speed_max,")
+	fmt.Println("              uv_index, weather_code (metric units)")
+	fmt.Println("  forecasts   days joined with their fetch")
+	fmt.Println()
+	fmt.Println("Example:")
+	fmt.Println(`  weather-app db query -db forecasts.sqlite "SELECT date, lead_days, temp_max FROM forecasts WHERE location = 'Paris' ORDER BY date, lead_days"`)
+}
+
+func runDB(ctx context.Context, args []string) {
+	if len(args) == 0 || args[0] != "query" {
+		dbUsage()
+		os.Exit(exitUsage)
+	}
+
+	fs := flag.NewFlagSet("db query", flag.ExitOnError)
+	path := fs.String("db", "", "SQLite database written by -log-db - *Mandatory")
+	fs.Usage = dbUsage
+	fs.Parse(args[1:])
+	if *path == "" || fs.NArg() == 0 {
+		dbUsage()
+		os.Exit(exitUsage)
+	}
+	if _, err := os.Stat(*path); err != nil {
+		fatal(err)
+	}
+
+	db, err := forecastlog.Open(*path)
+	if err != nil {
+		fatal(err)
+	}
+	defer db.Close()
+
+	columns, rows, err := db.Query(ctx, strings.Join(fs.Args(), " "))
+	if err != nil {
+		fatal(err)
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.Join(columns, "\t"))
+	for _, row := range rows {
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	if err := tw.Flush(); err != nil {
+		fatal(err)
+	}
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/mail"
+
+	"weather-app/internal/config"
+	"weather-app/internal/email"
+	"weather-app/internal/forecast"
+	"weather-app/internal/i18n"
+	"weather-app/internal/render"
+)
+
+// mailServer returns the [smtp] settings of the config file for -email.
+func mailServer(cfg config.Config) (email.Server, error) {
+	s := cfg.SMTP
+	if s.Host == "" || s.From == "" {
+		return email.Server{}, errors.New("-email needs the mail server: set host and from in the [smtp] table of the config file")
+	}
+	if _, err := mail.ParseAddress(s.From); err != nil {
+		return email.Server{}, errors.New("Invalid smtp from address in the config file: " + err.Error())
+	}
+	return email.Server{Host: s.Host, Port: s.Port, Username: s.Username, Password: s.Password, From: s.From}, nil
+}
+
+// mailForecast sends f to the recipients as the HTML report with the table
+// as its plain-text version. The subject sums up the first day.
+func mailForecast(ctx context.Context, server email.Server, to []*mail.Address, f forecast.Forecast, loc i18n.Locale) error {
+	var text, html bytes.Buffer
+	if err := render.Render(&text, "table", f, render.Options{Locale: loc}); err != nil {
+		return err
+	}
+	if err := render.HTML(&html, f, render.Options{Locale: loc}); err != nil {
+		return err
+	}
+	subject := "Weather in " + placeLabel(f.Location.Name, f.Location.Country)
+	if f.Location.Name == "" {
+		subject = "Weather forecast"
+	}
+	if len(f.Days) > 0 {
+		subject += ": " + todaySummary(f)
+	}
+	return email.Send(ctx, server, email.Message{To: to, Subject: subject, Text: text.String(), HTML: html.String()})
+}
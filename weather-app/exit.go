@@ -0,0 +1,105 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+
+	"weather-app/internal/render"
+	"weather-app/pkg/nws"
+	"weather-app/pkg/openmeteo"
+)
+
+// Exit statuses, for scripts to tell failures apart. The flag package
+// exits with exitUsage too.
+const (
+	exitOK       = 0
+	exitError    = 1  // anything else, and some of several cities failing
+	exitUsage    = 2  // invalid or missing flags and arguments
+	exitNotFound = 3  // no such city or postal code, or a location the provider does not cover
+	exitAPI      = 4  // the weather API rejected the request or kept failing
+	exitNetwork  = 5  // the weather API could not be reached
+	exitWarned   = 10 // -warn-exit: a day crosses -warn-below or -warn-above
+)
+
+// exitCodes name the exit statuses in the -o json error envelope.
+var exitCodes = map[int]string{
+	exitError:    "error",
+	exitUsage:    "usage",
+	exitNotFound: "not_found",
+	exitAPI:      "api",
+	exitNetwork:  "network",
+}
+
+// jsonErrors is set once -o json is parsed, from when on errors are
+// printed as an errorEnvelope instead of text.
+var jsonErrors bool
+
+type errorEnvelope struct {
+	Error struct {
+		Code    string `json:"code"`
+		Status  int    `json:"exit_status"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// exitStatus tells why err happened.
+func exitStatus(err error) int {
+	var netErr net.Error
+	var apiErr *openmeteo.APIError
+	var nwsErr *nws.APIError
+	switch {
+	case errors.Is(err, openmeteo.ErrCityNotFound), errors.Is(err, nws.ErrUnsupportedLocation):
+		return exitNotFound
+	// Failed connections are retried, so they end as ErrAPIUnavailable
+	// around the network error.
+	case errors.As(err, &netErr), errors.Is(err, context.DeadlineExceeded):
+		return exitNetwork
+	case errors.As(err, &apiErr), errors.As(err, &nwsErr), errors.Is(err, openmeteo.ErrAPIUnavailable), errors.Is(err, openmeteo.ErrSchemaChanged):
+		return exitAPI
+	}
+	return exitError
+}
+
+// fatal prints v and exits, with the exitStatus of v when it is an error.
+func fatal(v ...any) {
+	status := exitError
+	if len(v) == 1 {
+		if err, ok := v[0].(error); ok {
+			status = exitStatus(err)
+		}
+	}
+	exit(status, v...)
+}
+
+// fatalUsage prints v and exits with exitUsage, for invalid flags.
+func fatalUsage(v ...any) {
+	exit(exitUsage, v...)
+}
+
+// failure prints err like fatal but returns its exit status, for a run
+// that has deferred work to do before it exits.
+func failure(err error) int {
+	status := exitStatus(err)
+	report(status, err)
+	return status
+}
+
+func exit(status int, v ...any) {
+	report(status, v...)
+	os.Exit(status)
+}
+
+func report(status int, v ...any) {
+	msg := strings.TrimSuffix(fmt.Sprintln(v...), "\n")
+	if jsonErrors {
+		var e errorEnvelope
+		e.Error.Code, e.Error.Status, e.Error.Message = exitCodes[status], status, msg
+		render.JSON(os.Stdout, e)
+	} else {
+		fmt.Println(msg)
+	}
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"testing"
+
+	"weather-app/pkg/nws"
+	"weather-app/pkg/openmeteo"
+)
+
+func TestExitStatus(t *testing.T) {
+	tests := []struct {
+		err  error
+		want int
+	}{
+		{fmt.Errorf("Could not find a proper location match: %w", openmeteo.ErrCityNotFound), exitNotFound},
+		{nws.ErrUnsupportedLocation, exitNotFound},
+		{&openmeteo.APIError{StatusCode: http.StatusBadRequest}, exitAPI},
+		{&openmeteo.APIError{StatusCode: http.StatusServiceUnavailable}, exitAPI},
+		{fmt.Errorf("%w: %w", openmeteo.ErrAPIUnavailable, &net.DNSError{Err: "no such host"}), exitNetwork},
+		{context.DeadlineExceeded, exitNetwork},
+		{&openmeteo.SchemaError{Field: "daily.temperature_2m_max", Problem: "is missing"}, exitAPI},
+		{errors.New("disk full"), exitError},
+	}
+	for _, tt := range tests {
+		if got := exitStatus(tt.err); got != tt.want {
+			t.Errorf("exitStatus(%v) = %d, want %d", tt.err, got, tt.want)
+		}
+	}
+}
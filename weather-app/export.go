@@ -0,0 +1,226 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"weather-app/internal/forecast"
+	"weather-app/internal/metrics"
+	"weather-app/internal/provider"
+)
+
+// dayGauge exports one daily value; ok is false when the value is missing.
+type dayGauge struct {
+	name  string
+	help  string
+	value func(forecast.Day) (v float64, ok bool)
+}
+
+func optionalGauge(v *float64) (float64, bool) {
+	if v == nil {
+		return 0, false
+	}
+	return *v, true
+}
+
+// dayGauges are exported in Prometheus base units, so the exporter always
+// requests Celsius and m/s.
+var dayGauges = []dayGauge{
+	{"weather_temperature_max_celsius", "Forecast maximum temperature.", func(d forecast.Day) (float64, bool) { return d.TempMax, true }},
+	{"weather_temperature_min_celsius", "Forecast minimum temperature.", func(d forecast.Day) (float64, bool) { return d.TempMin, true }},
+	{"weather_precipitation_millimeters", "Forecast precipitation sum.", func(d forecast.Day) (float64, bool) { return optionalGauge(d.Precipitation) }},
+	{"weather_precipitation_probability_percent", "Forecast maximum precipitation probability.", func(d forecast.Day) (float64, bool) { return optionalGauge(d.PrecipChance) }},
+	{"weather_precipitation_hours", "Forecast hours with precipitation.", func(d forecast.Day) (float64, bool) { return optionalGauge(d.PrecipHours) }},
+	{"weather_uv_index_max", "Forecast maximum UV index.", func(d forecast.Day) (float64, bool) { return optionalGauge(d.UVIndex) }},
+	{"weather_wind_speed_max_meters_per_second", "Forecast maximum wind speed.", func(d forecast.Day) (float64, bool) { return optionalGauge(d.WindSpeedMax) }},
+	{"weather_wind_gusts_max_meters_per_second", "Forecast maximum wind gusts.", func(d forecast.Day) (float64, bool) { return optionalGauge(d.WindGustsMax) }},
+	{"weather_code", "Forecast WMO weather code.", func(d forecast.Day) (float64, bool) {
+		if d.WeatherCode == nil {
+			return 0, false
+		}
+		return float64(*d.WeatherCode), true
+	}},
+}
+
+// forecastFamilies turns forecasts into gauges labelled by place and by the
+// number of days ahead.
+func forecastFamilies(forecasts []forecast.Forecast) []metrics.Family {
+	families := make([]metrics.Family, len(dayGauges))
+	for i, g := range dayGauges {
+		families[i] = metrics.Family{Name: g.name, Help: g.help, Type: "gauge"}
+		for _, f := range forecasts {
+			for ahead, day := range f.Days {
+				v, ok := g.value(day)
+				if !ok {
+					continue
+				}
+				families[i].Samples = append(families[i].Samples, metrics.Sample{
+					Labels: []metrics.Label{
+						{Name: "city", Value: f.Location.Name},
+						{Name: "country", Value: f.Location.Country},
+						{Name: "day", Value: strconv.Itoa(ahead)},
+					},
+					Value: v,
+				})
+			}
+		}
+	}
+	return families
+}
+
+// exporter keeps the latest forecasts for a fixed set of places and serves
+// them as Prometheus metrics.
+type exporter struct {
+	provider provider.Provider
+	places   []forecast.Location
+	opts     forecastOptions
+
+	mu          sync.Mutex
+	families    []metrics.Family
+	lastRefresh time.Time
+	failures    int
+}
+
+func (e *exporter) refresh(ctx context.Context) error {
+	forecasts, err := fetchDailyForecasts(ctx, e.provider, e.places, e.opts)
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if err != nil {
+		e.failures++
+		return err
+	}
+	e.families = forecastFamilies(forecasts)
+	e.lastRefresh = time.Now()
+	return nil
+}
+
+// run refreshes the forecasts every interval until ctx is done. Failed
+// refreshes are logged and keep the previous values.
+func (e *exporter) run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := e.refresh(ctx); err != nil && ctx.Err() == nil {
+				slog.Error("refresh failed", "error", err)
+			}
+		}
+	}
+}
+
+func (e *exporter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	e.mu.Lock()
+	families := append([]metrics.Family(nil), e.families...)
+	families = append(families,
+		metrics.Family{
+			Name:    "weather_refresh_failures_total",
+			Help:    "Forecast refreshes that failed.",
+			Type:    "counter",
+			Samples: []metrics.Sample{{Value: float64(e.failures)}},
+		},
+	)
+	if !e.lastRefresh.IsZero() {
+		families = append(families, metrics.Family{
+			Name:    "weather_last_refresh_timestamp_seconds",
+			Help:    "Unix time of the last successful forecast refresh.",
+			Type:    "gauge",
+			Samples: []metrics.Sample{{Value: float64(e.lastRefresh.Unix())}},
+		})
+	}
+	e.mu.Unlock()
+
+	var buf bytes.Buffer
+	if err := metrics.Write(&buf, families); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", metrics.ContentType)
+	w.Write(buf.Bytes())
+}
+
+func runExport(ctx context.Context, args []string) {
+	fs := flag.NewFlagSet("export", flag.ExitOnError)
+	var loc locationFlags
+	var client clientFlags
+	loc.register(fs)
+	client.register(fs)
+	addr := fs.String("addr", ":9110", "Address to listen on - Optional")
+	interval := fs.Duration("interval", 30*time.Minute, "How often forecasts are refreshed - Optional")
+	days := fs.Int("days", defaultDays, "Number of forecast days (1-16) - Optional")
+
+	fs.Usage = func() {
+		fmt.Println("Export forecasts as Prometheus metrics.")
+		fmt.Println("Usage:")
+		fmt.Println("  weather-app export [flags]")
+		fmt.Println()
+		printLocationUsage()
+		fmt.Println()
+		fmt.Println("  Metrics are served on /metrics, labelled by city, country and days ahead.")
+		fmt.Println()
+		fmt.Println("Optional Flags:")
+		fmt.Println("  -addr           Address to listen on (default :9110)")
+		fmt.Println("  -interval       How often forecasts are refreshed (default 30m)")
+		fmt.Println("  -days           Number of forecast days (default 7, max 16)")
+		printClientUsage()
+	}
+
+	parseLocation(fs, &loc, args)
+
+	if *interval <= 0 {
+		fatalUsage("-interval must be positive")
+	}
+	opts := forecastOptions{Precipitation: true, UVIndex: true, Wind: true, WindUnit: "ms", Days: *days}
+	if err := opts.validate(); err != nil {
+		fatalUsage(err)
+	}
+
+	if err := client.setup(slog.LevelInfo); err != nil {
+		fatal(err)
+	}
+
+	p, err := client.newProvider()
+	if err != nil {
+		fatal(err)
+	}
+	places, err := loc.resolveAll(ctx, p)
+	if err != nil {
+		fatal(err)
+	}
+
+	e := &exporter{provider: p, places: places, opts: opts}
+	if err := e.refresh(ctx); err != nil {
+		fatal(err)
+	}
+	go e.run(ctx, *interval)
+
+	mux := http.NewServeMux()
+	mux.Handle("GET /metrics", e)
+	srv := &http.Server{
+		Addr:              *addr,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		srv.Shutdown(shutdownCtx)
+	}()
+
+	slog.Info("exporting metrics", "locations", len(places), "addr", *addr, "path", "/metrics")
+	if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		fatal(err)
+	}
+}
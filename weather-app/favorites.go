@@ -0,0 +1,130 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+
+	"weather-app/internal/favorites"
+)
+
+func favoritesUsage() {
+	fmt.Println("Manage saved favorite locations.")
+	fmt.Println("Usage:")
+	fmt.Println("  weather-app favorites add NAME [-default] -city ... -country ...  (or -lat/-lon)")
+	fmt.Println("  weather-app favorites list")
+	fmt.Println("  weather-app favorites remove NAME")
+	fmt.Println("  weather-app favorites default NAME")
+	fmt.Println()
+	fmt.Println("Use a favorite with: weather-app -fav NAME")
+}
+
+// splitName separates a leading positional NAME from the flags that follow.
+func splitName(args []string) (string, []string) {
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		return args[0], args[1:]
+	}
+	return "", args
+}
+
+func runFavorites(ctx context.Context, args []string) {
+	if len(args) == 0 {
+		favoritesUsage()
+		os.Exit(exitUsage)
+	}
+
+	store, err := favorites.LoadDefault()
+	if err != nil {
+		fatal(err)
+	}
+
+	switch args[0] {
+	case "add":
+		name, rest := splitName(args[1:])
+		fs := flag.NewFlagSet("favorites add", flag.ExitOnError)
+		var loc locationFlags
+		var client clientFlags
+		loc.register(fs)
+		client.register(fs)
+		makeDefault := fs.Bool("default", false, "Use this favorite when no location is given - Optional")
+		fs.Usage = func() {
+			favoritesUsage()
+			fmt.Println()
+			printLocationUsage()
+		}
+		parseLocation(fs, &loc, rest)
+		if name == "" {
+			name = fs.Arg(0)
+		}
+		if name == "" {
+			fs.Usage()
+			os.Exit(exitUsage)
+		}
+
+		if err := client.setup(slog.LevelWarn); err != nil {
+			fatal(err)
+		}
+
+		p, err := client.newProvider()
+		if err != nil {
+			fatal(err)
+		}
+		place, err := loc.resolve(ctx, p)
+		if err != nil {
+			fatal(err)
+		}
+		store.Add(name, place)
+		if *makeDefault {
+			store.SetDefault(name)
+		}
+		if err := store.Save(); err != nil {
+			fatal(err)
+		}
+		fmt.Printf("Saved %s: %s (%.4f, %.4f)\n", name, placeLabel(place.Name, place.Country), place.Latitude, place.Longitude)
+
+	case "list":
+		for _, name := range store.Names() {
+			place := store.Locations[name]
+			marker := " "
+			if name == store.Default {
+				marker = "*"
+			}
+			fmt.Printf("%s %-12s %s (%.4f, %.4f)\n", marker, name, placeLabel(place.Name, place.Country), place.Latitude, place.Longitude)
+		}
+
+	case "remove", "default":
+		if len(args) != 2 {
+			favoritesUsage()
+			os.Exit(exitUsage)
+		}
+		if args[0] == "remove" {
+			err = store.Remove(args[1])
+		} else {
+			err = store.SetDefault(args[1])
+		}
+		if err != nil {
+			fatal(err)
+		}
+		if err := store.Save(); err != nil {
+			fatal(err)
+		}
+
+	default:
+		favoritesUsage()
+		os.Exit(exitUsage)
+	}
+}
+
+func placeLabel(name, country string) string {
+	switch {
+	case name == "":
+		return "-"
+	case country == "":
+		return name
+	default:
+		return name + ", " + country
+	}
+}
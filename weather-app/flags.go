@@ -0,0 +1,888 @@
+package main
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"weather-app/internal/airports"
+	"weather-app/internal/cache"
+	"weather-app/internal/config"
+	"weather-app/internal/favorites"
+	"weather-app/internal/forecast"
+	"weather-app/internal/gazetteer"
+	"weather-app/internal/geoip"
+	"weather-app/internal/httpclient"
+	"weather-app/internal/i18n"
+	"weather-app/internal/pool"
+	"weather-app/internal/provider"
+	"weather-app/internal/render"
+	"weather-app/internal/reverse"
+	"weather-app/pkg/openmeteo"
+)
+
+var errNoLocation = errors.New("no location given")
+
+// stringList is a flag that can be repeated or given a comma-separated list.
+type stringList []string
+
+func (s *stringList) String() string {
+	return strings.Join(*s, ",")
+}
+
+func (s *stringList) Set(value string) error {
+	for _, part := range strings.Split(value, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			*s = append(*s, part)
+		}
+	}
+	return nil
+}
+
+// locationFlags are the flags shared by every command that needs a place.
+type locationFlags struct {
+	fs        *flag.FlagSet
+	cities    stringList
+	countries stringList
+	zip       string
+	airports  stringList
+	lat       float64
+	lon       float64
+	pick      int
+	// strict fails when no city matches the country, instead of falling
+	// back to matches in other countries.
+	strict    bool
+	favorite  string
+	batch     string
+	auto      bool
+	provider  string
+	reverse   bool
+	reverseBy string
+	// region narrows the matches of the city, when a place argument such
+	// as "Springfield, Illinois, United States" names one.
+	region string
+	// locator is set by validate when the location comes from -auto.
+	locator geoip.Locator
+	// reverser is set by validate when -reverse names -lat and -lon.
+	reverser reverse.Geocoder
+}
+
+func (l *locationFlags) register(fs *flag.FlagSet) {
+	l.fs = fs
+	fs.Var(&l.cities, "city", "Name of the city (e.g., 'The Hague'), repeatable - *Mandatory")
+	fs.Var(&l.countries, "country", "Country of the city (e.g., 'Netherlands'), one for all cities or one per city - *Mandatory")
+	fs.StringVar(&l.zip, "zip", "", "Postal code (e.g., '2511CV'), used instead of -city together with -country")
+	fs.Var(&l.airports, "airport", "IATA or ICAO airport code (e.g., 'AMS'), used instead of -city/-country, repeatable - Optional")
+	fs.Float64Var(&l.lat, "lat", 0, "Latitude, used instead of -city/-country together with -lon")
+	fs.Float64Var(&l.lon, "lon", 0, "Longitude, used instead of -city/-country together with -lat")
+	fs.IntVar(&l.pick, "pick", 0, "Pick the Nth matching city instead of asking - Optional")
+	fs.BoolVar(&l.strict, "strict-country", false, "Fail when no city matches -country instead of offering cities elsewhere - Optional")
+	fs.StringVar(&l.favorite, "fav", "", "Use a saved favorite location - Optional")
+	fs.StringVar(&l.batch, "batch", "", "Read city,country lines from this file, or - for stdin - Optional")
+	fs.BoolVar(&l.auto, "auto", false, "Detect the location from the public IP address when no city is given - Optional")
+	fs.StringVar(&l.provider, "auto-provider", geoip.DefaultProvider, "IP geolocation service for -auto: "+strings.Join(geoip.Providers(), ", ")+" - Optional")
+	fs.BoolVar(&l.reverse, "reverse", false, "Name the place nearest to -lat and -lon - Optional")
+	fs.StringVar(&l.reverseBy, "reverse-provider", reverse.DefaultProvider, "Reverse geocoding service for -reverse: "+strings.Join(reverse.Providers(), ", ")+" - Optional")
+}
+
+func (l *locationFlags) isSet(name string) bool {
+	set := false
+	l.fs.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			set = true
+		}
+	})
+	return set
+}
+
+// setPlace sets the city and country, and the region if any, from a place
+// given as an argument, e.g. "Paris, France".
+func (l *locationFlags) setPlace(place string) error {
+	if l.isSet("city") || l.isSet("zip") || l.isSet("airport") {
+		return fmt.Errorf("The place %q cannot be combined with -city, -zip or -airport", place)
+	}
+	city, region, country, err := splitPlace(place)
+	if err != nil {
+		return err
+	}
+	if country != "" && l.isSet("country") {
+		return fmt.Errorf("The place %q names its country already, leave out -country", place)
+	}
+	l.fs.Set("city", city)
+	if country != "" {
+		l.fs.Set("country", country)
+	}
+	l.region = region
+	return nil
+}
+
+func (l *locationFlags) useCoordinates() bool {
+	return l.isSet("lat") || l.isSet("lon")
+}
+
+// validate checks the location flags after parsing. Without any location
+// flags the IP address is used with -auto, or else the default favorite;
+// errNoLocation is returned when there is none either.
+func (l *locationFlags) validate() error {
+	if l.batch != "" {
+		return l.loadBatch()
+	}
+	if l.favorite != "" {
+		return nil
+	}
+	if l.auto && !l.useCoordinates() && len(l.cities) == 0 && len(l.airports) == 0 && l.zip == "" {
+		if l.locator != nil {
+			return nil
+		}
+		locator, err := geoip.New(l.provider, httpclient.New(geoip.DefaultTimeout))
+		if err != nil {
+			return err
+		}
+		l.locator = locator
+		return nil
+	}
+	if l.reverse && !l.useCoordinates() {
+		return errors.New("-reverse needs -lat and -lon")
+	}
+	if l.useCoordinates() {
+		if !l.isSet("lat") || !l.isSet("lon") {
+			return errors.New("Both -lat and -lon are required when querying by coordinates")
+		}
+		if l.reverse && l.reverser == nil {
+			reverser, err := reverse.New(l.reverseBy, httpclient.New(reverse.DefaultTimeout))
+			if err != nil {
+				return err
+			}
+			l.reverser = reverser
+		}
+		return validateCoordinates(l.lat, l.lon)
+	}
+	if len(l.airports) > 0 {
+		if len(l.cities) > 0 || l.zip != "" {
+			return errors.New("-airport cannot be combined with -city or -zip")
+		}
+		for _, code := range l.airports {
+			if _, ok := airports.Lookup(code); !ok {
+				return fmt.Errorf("Unknown airport code %q: only major airports are known, try -city or -lat and -lon", code)
+			}
+		}
+		return nil
+	}
+	if l.zip != "" {
+		if len(l.cities) > 0 {
+			return errors.New("-zip cannot be combined with -city")
+		}
+		if len(l.countries) != 1 {
+			return errors.New("-zip needs a single -country, e.g. -zip 2511CV -country NL")
+		}
+		return nil
+	}
+	if len(l.cities) == 0 && len(l.countries) == 0 {
+		if store, err := favorites.LoadDefault(); err == nil && store.Default != "" {
+			l.favorite = store.Default
+			return nil
+		}
+	}
+	if len(l.cities) == 0 || len(l.countries) == 0 {
+		return errNoLocation
+	}
+	if len(l.countries) != 1 && len(l.countries) != len(l.cities) {
+		return fmt.Errorf("Got %d cities and %d countries: give one -country for all cities or one per city", len(l.cities), len(l.countries))
+	}
+	return nil
+}
+
+func (l *locationFlags) multiple() bool {
+	return l.favorite == "" && !l.useCoordinates() && (len(l.cities) > 1 || len(l.airports) > 1)
+}
+
+func (l *locationFlags) country(i int) string {
+	if len(l.countries) == 1 {
+		return l.countries[0]
+	}
+	return l.countries[i]
+}
+
+// resolve returns the single location the flags describe.
+func (l *locationFlags) resolve(ctx context.Context, geo provider.Geocoder) (forecast.Location, error) {
+	if l.multiple() {
+		return forecast.Location{}, errors.New("This command accepts a single -city")
+	}
+	places, err := l.resolveAll(ctx, geo)
+	if err != nil {
+		return forecast.Location{}, err
+	}
+	return places[0], nil
+}
+
+// resolveAll geocodes every requested city concurrently. When a city has
+// several matches the user is asked about them one at a time afterwards.
+// When some of several cities cannot be found, the others are returned
+// with a *failures naming them.
+func (l *locationFlags) resolveAll(ctx context.Context, geo provider.Geocoder) ([]forecast.Location, error) {
+	if l.favorite != "" {
+		store, err := favorites.LoadDefault()
+		if err != nil {
+			return nil, err
+		}
+		place, err := store.Get(l.favorite)
+		if err != nil {
+			return nil, err
+		}
+		if place.Name == "" {
+			place.Name = l.favorite
+		}
+		return []forecast.Location{place}, nil
+	}
+
+	if l.locator != nil {
+		place, err := l.locator.Locate(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return []forecast.Location{place}, nil
+	}
+
+	if l.useCoordinates() {
+		place := forecast.Location{Latitude: l.lat, Longitude: l.lon}
+		if len(l.cities) > 0 {
+			place.Name = l.cities[0]
+		}
+		if len(l.countries) > 0 {
+			place.Country = l.countries[0]
+		}
+		if l.reverser != nil && place.Name == "" {
+			// The forecast does not depend on the name, so a failed
+			// lookup leaves the coordinates to stand for the place.
+			named, err := l.reverser.Reverse(ctx, l.lat, l.lon)
+			if err != nil {
+				slog.Warn("naming the coordinates", "err", err)
+			} else {
+				place.Name, place.Country = named.Name, named.Country
+			}
+		}
+		return []forecast.Location{place}, nil
+	}
+
+	if len(l.airports) > 0 {
+		places := make([]forecast.Location, len(l.airports))
+		for i, code := range l.airports {
+			airport, _ := airports.Lookup(code)
+			places[i] = airport.Location()
+		}
+		return places, nil
+	}
+
+	if l.zip != "" {
+		postcodes, ok := geo.(provider.PostcodeGeocoder)
+		if !ok {
+			return nil, errors.New("Postal codes cannot be looked up with this provider")
+		}
+		matches, err := postcodes.GeocodePostcode(ctx, l.zip, l.countries[0])
+		if err != nil {
+			return nil, err
+		}
+		result, err := chooseCity(matches, l.pick)
+		if err != nil {
+			return nil, err
+		}
+		return []forecast.Location{result.Location}, nil
+	}
+
+	jobs := make([]int, len(l.cities))
+	for i := range jobs {
+		jobs[i] = i
+	}
+	results := pool.Run(ctx, maxParallel, requestTimeout, jobs, func(ctx context.Context, i int) ([]provider.Place, error) {
+		return geo.Geocode(ctx, l.cities[i], l.country(i))
+	})
+	for i := range results {
+		if elsewhere := provider.Elsewhere(results[i].Err); len(elsewhere) > 0 && !l.strict {
+			slog.Warn("no such city in the country, showing matches from other countries (-strict-country to fail instead)", "city", l.cities[i], "country", l.country(i))
+			results[i].Value, results[i].Err = elsewhere, nil
+		}
+	}
+	if len(results) == 1 && results[0].Err != nil {
+		return nil, results[0].Err
+	}
+
+	var places []forecast.Location
+	failed := &failures{what: "cities", total: len(l.cities)}
+	for i, r := range results {
+		if errors.Is(r.Err, openmeteo.ErrCityNotFound) {
+			// The message names the city already.
+			failed.errs = append(failed.errs, r.Err)
+			continue
+		}
+		if r.Err != nil {
+			failed.errs = append(failed.errs, fmt.Errorf("%s: %w", l.cities[i], r.Err))
+			continue
+		}
+		matches := r.Value
+		if l.region != "" {
+			if matches = inRegion(matches, l.region); len(matches) == 0 {
+				return nil, fmt.Errorf("Could not find %s in %s, %s: %w", l.cities[i], l.region, l.country(i), openmeteo.ErrCityNotFound)
+			}
+		}
+		result, err := chooseCity(matches, l.pick)
+		if err != nil {
+			return nil, err
+		}
+		places = append(places, result.Location)
+	}
+	if len(failed.errs) > 0 {
+		return places, failed
+	}
+	return places, nil
+}
+
+func printLocationUsage() {
+	fmt.Println("Mandatory Flags:")
+	fmt.Println("  -city           Name of the city (e.g., 'The Hague')")
+	fmt.Println("  -country        Country of the city, by name or ISO code (e.g., 'Netherlands', 'NL' or 'NLD')")
+	fmt.Println()
+	fmt.Println("  Repeat -city (or pass a comma-separated list) to compare several cities,")
+	fmt.Println("  with either one -country for all of them or one per city.")
+	fmt.Println()
+	fmt.Println("  or, to look up a postal code instead of a city:")
+	fmt.Println("  -zip            Postal code (e.g., '2511CV'), with -country as a name or code (e.g., 'NL')")
+	fmt.Println()
+	fmt.Println("  or, for the weather at an airport (repeat or comma-separate to compare):")
+	fmt.Println("  -airport        IATA or ICAO code of a major airport (e.g., 'AMS' or 'EHAM')")
+	fmt.Println()
+	fmt.Println("  or, to skip the city lookup:")
+	fmt.Println("  -lat            Latitude (-90 to 90)")
+	fmt.Println("  -lon            Longitude (-180 to 180)")
+	fmt.Println("  -reverse        Name the place nearest to -lat and -lon in the output")
+	fmt.Println("  -reverse-provider")
+	fmt.Println("                  Service for -reverse: " + strings.Join(reverse.Providers(), ", ") + " (default " + reverse.DefaultProvider + ");")
+	fmt.Println("                  offline picks the nearest of the major cities built in")
+	fmt.Println()
+	fmt.Println("  or, for every city of a list:")
+	fmt.Println("  -batch          File of city,country lines, or - to read them from stdin")
+	fmt.Println()
+	fmt.Println("  -pick           Pick the Nth city when several match, instead of asking")
+	fmt.Println("  -strict-country Fail when no city matches -country, instead of offering")
+	fmt.Println("                  the cities of that name in other countries")
+	fmt.Println("  -fav            Use a saved favorite location (see 'favorites')")
+	fmt.Println("  -auto           Detect the location from your public IP address")
+	fmt.Println("  -auto-provider  IP geolocation service for -auto: " + strings.Join(geoip.Providers(), ", ") + " (default " + geoip.DefaultProvider + ")")
+	fmt.Println()
+	fmt.Println("  Without any location flags the default favorite is used.")
+}
+
+// apiKeyEnv holds the Open-Meteo API key when -api-key and the config file
+// do not, to keep it out of the shell history.
+const apiKeyEnv = "OPEN_METEO_API_KEY"
+
+// clientFlags choose the weather provider and configure how the Open-Meteo
+// client talks to the API.
+type clientFlags struct {
+	provider  string
+	noCache   bool
+	cacheTTL  time.Duration
+	timeout   time.Duration
+	retries   int
+	retryWait time.Duration
+	maxRPS    float64
+	// noGazetteer always asks the geocoding API, even for well-known cities.
+	noGazetteer bool
+	verbose     bool
+	veryVerbose bool
+	logFormat   string
+	apiKey      string
+	// proxy, caCerts and insecure configure the shared transport in setup,
+	// once the command line and the config file are both read.
+	proxy    string
+	caCerts  stringList
+	insecure bool
+	// language of place names from the geocoding API, set from -lang by the
+	// commands that have it.
+	language string
+	// logger is set by setupLogging.
+	logger *slog.Logger
+}
+
+func (c *clientFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&c.provider, "provider", provider.DefaultName, "Weather provider: "+strings.Join(provider.Names(), ", ")+" - Optional")
+	fs.BoolVar(&c.noCache, "no-cache", false, "Always fetch a fresh forecast - Optional")
+	fs.DurationVar(&c.cacheTTL, "cache-ttl", cache.DefaultTTL, "How long cached forecasts stay valid - Optional")
+	fs.DurationVar(&c.timeout, "timeout", openmeteo.DefaultTimeout, "Timeout for each API request - Optional")
+	fs.IntVar(&c.retries, "retries", openmeteo.DefaultRetries, "Retries for failed API requests - Optional")
+	fs.DurationVar(&c.retryWait, "retry-wait", openmeteo.DefaultRetryWait, "Wait before the first retry, doubled on each attempt - Optional")
+	fs.Float64Var(&c.maxRPS, "max-rps", openmeteo.DefaultMaxRPS, "Most API requests a second, 0 for no limit - Optional")
+	fs.BoolVar(&c.noGazetteer, "no-gazetteer", false, "Look up every city online instead of using the built-in list - Optional")
+	fs.BoolVar(&c.verbose, "v", false, "Log requests, latencies and retries to stderr - Optional")
+	fs.BoolVar(&c.veryVerbose, "vv", false, "Also log cache and gazetteer hits - Optional")
+	fs.StringVar(&c.logFormat, "log-format", "text", "Log format: text or json - Optional")
+	fs.StringVar(&c.apiKey, "api-key", "", "Open-Meteo API key of a commercial plan, else $"+apiKeyEnv+" - Optional")
+	fs.StringVar(&c.proxy, "proxy", "", "Send requests through this proxy URL, overriding $HTTPS_PROXY - Optional")
+	fs.Var(&c.caCerts, "ca-cert", "Also trust the certificates in this PEM file, repeatable - Optional")
+	fs.BoolVar(&c.insecure, "insecure", false, "Do not verify TLS certificates - Optional")
+}
+
+// setup installs the logger with setupLogging and configures the shared
+// transport, for every client the command builds afterwards.
+func (c *clientFlags) setup(level slog.Level) error {
+	if err := c.setupLogging(level); err != nil {
+		return err
+	}
+	return httpclient.Configure(httpclient.Settings{Proxy: c.proxy, CACerts: c.caCerts, Insecure: c.insecure})
+}
+
+// setupLogging installs the logger for -v, -vv and -log-format as the slog
+// default. level is what the command logs without -v; each step of
+// verbosity lowers it down to debug.
+func (c *clientFlags) setupLogging(level slog.Level) error {
+	switch {
+	case c.veryVerbose:
+		level -= 8
+	case c.verbose:
+		level -= 4
+	}
+	level = max(level, slog.LevelDebug)
+
+	opts := &slog.HandlerOptions{Level: level}
+	var handler slog.Handler
+	switch c.logFormat {
+	case "text":
+		handler = slog.NewTextHandler(os.Stderr, opts)
+	case "json":
+		handler = slog.NewJSONHandler(os.Stderr, opts)
+	default:
+		return fmt.Errorf("Unknown log format %q, expected text or json", c.logFormat)
+	}
+	c.logger = slog.New(handler)
+	slog.SetDefault(c.logger)
+	return nil
+}
+
+func (c *clientFlags) newClient() *openmeteo.Client {
+	opts := []openmeteo.Option{
+		openmeteo.WithHTTPClient(httpclient.New(c.timeout)),
+		openmeteo.WithRetries(c.retries, c.retryWait),
+		openmeteo.WithRateLimit(c.maxRPS),
+	}
+	if !c.noCache && c.cacheTTL > 0 {
+		if dir, err := cache.DefaultDir(); err == nil {
+			opts = append(opts,
+				openmeteo.WithCache(cache.New(dir, c.cacheTTL)),
+				openmeteo.WithGeocodingCache(cache.New(dir, cache.GeocodingTTL)),
+			)
+		}
+	}
+	if !c.noGazetteer {
+		opts = append(opts, openmeteo.WithGazetteer(gazetteer.Gazetteer{}))
+	}
+	if c.logger != nil {
+		opts = append(opts, openmeteo.WithLogger(c.logger))
+	}
+	if c.language != "" {
+		opts = append(opts, openmeteo.WithLanguage(c.language))
+	}
+	if key := cmp.Or(c.apiKey, os.Getenv(apiKeyEnv)); key != "" {
+		opts = append(opts, openmeteo.WithAPIKey(key))
+	}
+	return openmeteo.NewClient(opts...)
+}
+
+// newProvider returns the provider chosen with -provider.
+func (c *clientFlags) newProvider() (provider.Provider, error) {
+	return provider.New(c.provider, c.newClient(), c.logger)
+}
+
+func printClientUsage() {
+	fmt.Println("  -provider       Weather provider: " + strings.Join(provider.Names(), ", ") + " (default " + provider.DefaultName + ")")
+	fmt.Println("  -no-cache       Always fetch a fresh forecast")
+	fmt.Println("  -cache-ttl      How long cached forecasts stay valid (default 30m)")
+	fmt.Println("  -timeout        Timeout for each API request (default 10s)")
+	fmt.Println("  -retries        Retries for failed API requests (default 2)")
+	fmt.Println("  -retry-wait     Wait before the first retry, doubled each attempt (default 500ms)")
+	fmt.Println("  -max-rps        Most API requests a second, 0 for no limit; Retry-After is always honored")
+	fmt.Println("                  (default 5)")
+	fmt.Println("  -no-gazetteer   Look up every city online instead of using the built-in list")
+	fmt.Println("  -api-key        Open-Meteo API key of a commercial plan, sent to its customer-api")
+	fmt.Println("                  endpoints (default $" + apiKeyEnv + ")")
+	fmt.Println("  -proxy          Send requests through this http, https or socks5 proxy URL; by default")
+	fmt.Println("                  $HTTPS_PROXY, $HTTP_PROXY and $NO_PROXY are honored")
+	fmt.Println("  -ca-cert        Also trust the certificates in this PEM file, e.g. of a TLS-intercepting proxy;")
+	fmt.Println("                  repeatable")
+	fmt.Println("  -insecure       Do not verify TLS certificates; prefer -ca-cert")
+	fmt.Println("  -v, -vv         Log requests, latencies and retries (-vv adds cache hits) to stderr")
+	fmt.Println("  -log-format     Log format: text or json (default text)")
+	fmt.Println("  -config         Path to the config file (default ~/.config/weather-app/config.toml)")
+}
+
+// configFlagValues maps config entries onto the flag names they provide a
+// default for.
+func configFlagValues(cfg config.Config) map[string]string {
+	values := map[string]string{}
+	if cfg.City != "" {
+		values["city"] = cfg.City
+	}
+	if cfg.Country != "" {
+		values["country"] = cfg.Country
+	}
+	if cfg.Units != "" {
+		values["units"] = cfg.Units
+	}
+	if cfg.Output != "" {
+		values["o"] = cfg.Output
+	}
+	if cfg.Icons != "" {
+		values["icons"] = cfg.Icons
+	}
+	if cfg.Precipitation {
+		values["p"] = "true"
+	}
+	if cfg.UVIndex {
+		values["uv"] = "true"
+	}
+	if cfg.UVAdvice {
+		values["uv-advice"] = "true"
+	}
+	if cfg.Sunrise {
+		values["sunrise"] = "true"
+	}
+	if cfg.Sunset {
+		values["sunset"] = "true"
+	}
+	if cfg.Daylight {
+		values["daylight"] = "true"
+	}
+	if cfg.Feels {
+		values["feels"] = "true"
+	}
+	if cfg.Snow {
+		values["snow"] = "true"
+	}
+	if cfg.Humidity {
+		values["humidity"] = "true"
+	}
+	if cfg.Pressure {
+		values["pressure"] = "true"
+	}
+	if cfg.Clouds {
+		values["clouds"] = "true"
+	}
+	if cfg.Ensemble {
+		values["ensemble"] = "true"
+	}
+	if cfg.Wind {
+		values["wind"] = "true"
+	}
+	if cfg.WindUnit != "" {
+		values["wind-unit"] = cfg.WindUnit
+	}
+	if cfg.Model != "" {
+		values["model"] = cfg.Model
+	}
+	if cfg.Lang != "" {
+		values["lang"] = cfg.Lang
+	}
+	if cfg.Locale != "" {
+		values["locale"] = cfg.Locale
+	}
+	if cfg.Days != 0 {
+		values["days"] = strconv.Itoa(cfg.Days)
+	}
+	if cfg.Timezone != "" {
+		values["tz"] = cfg.Timezone
+	}
+	if cfg.BriefFormat != "" {
+		values["brief-format"] = cfg.BriefFormat
+	}
+	if cfg.APIKey != "" {
+		values["api-key"] = cfg.APIKey
+	}
+	if cfg.Proxy != "" {
+		values["proxy"] = cfg.Proxy
+	}
+	if cfg.CACert != "" {
+		values["ca-cert"] = cfg.CACert
+	}
+	if cfg.Insecure {
+		values["insecure"] = "true"
+	}
+	if cfg.WarnBelow != nil {
+		values["warn-below"] = strconv.FormatFloat(*cfg.WarnBelow, 'f', -1, 64)
+	}
+	if cfg.WarnAbove != nil {
+		values["warn-above"] = strconv.FormatFloat(*cfg.WarnAbove, 'f', -1, 64)
+	}
+	return values
+}
+
+// applyConfig sets every flag that was not given on the command line from
+// the config file.
+func applyConfig(fs *flag.FlagSet, cfg config.Config) error {
+	set := map[string]bool{}
+	fs.Visit(func(f *flag.Flag) {
+		set[f.Name] = true
+	})
+	values := configFlagValues(cfg)
+	if set["zip"] || set["airport"] {
+		// A postal code or airport replaces the configured city; postal
+		// codes keep its country.
+		delete(values, "city")
+	}
+	if set["batch"] || set["auto"] || set["fav"] || set["lat"] || set["lon"] {
+		// The location comes from elsewhere; a configured city would
+		// take its place, or name the coordinates.
+		delete(values, "city")
+		delete(values, "country")
+	}
+	for name, value := range values {
+		if set[name] || fs.Lookup(name) == nil {
+			continue
+		}
+		if err := fs.Set(name, value); err != nil {
+			return fmt.Errorf("config value for %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+func loadConfig(path string) (config.Config, error) {
+	if path != "" {
+		return config.Load(path)
+	}
+	return config.LoadDefault()
+}
+
+// outputFlags choose the output format and styling.
+type outputFlags struct {
+	format  string
+	path    string
+	noColor bool
+	icons   string
+	spark   bool
+	graph   bool
+	lang    string
+	locale  string
+	// renderCmd is an external program that renders the forecast instead
+	// of -o; validate registers it as the commandFormat.
+	renderCmd string
+	// template and templateFile are a Go template executed for each day
+	// instead of -o; validate registers it as the templateFormat.
+	template     string
+	templateFile string
+	// uvAdvice is set from forecastOptions.UVAdvice by the commands that
+	// have it.
+	uvAdvice bool
+	// loc is resolved from -locale, LC_ALL or LC_TIME, and -lang by validate.
+	loc i18n.Locale
+	// clear replaces the terminal's contents with each output, once it is
+	// fully rendered; -watch sets it when writing to a terminal.
+	clear bool
+}
+
+func (o *outputFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&o.format, "o", "table", "Output format: "+strings.Join(render.Formats, ", ")+" - Optional")
+	fs.StringVar(&o.path, "out", "", "Write output to this file instead of stdout - Optional")
+	fs.BoolVar(&o.noColor, "no-color", false, "Disable colored output - Optional")
+	fs.StringVar(&o.icons, "icons", "", "Show weather icons: emoji or ascii - Optional")
+	fs.BoolVar(&o.spark, "spark", false, "Use sparkline blocks instead of star bars - Optional")
+	fs.BoolVar(&o.graph, "graph", false, "Draw a chart of daily highs and lows - Optional")
+	fs.StringVar(&o.lang, "lang", "", "Language of descriptions, day names and dates: "+strings.Join(i18n.Languages, ", ")+" - Optional")
+	fs.StringVar(&o.locale, "locale", "", "Format dates, times and numbers for this locale, e.g. en_US or de_DE - Optional")
+	fs.StringVar(&o.renderCmd, "render-cmd", "", "Pipe the forecast as JSON to this command and print its output instead - Optional")
+	fs.StringVar(&o.template, "template", "", "Go template executed for each day instead of -o, e.g. '{{.Date}} {{.TempMax}}°{{.Unit}}' - Optional")
+	fs.StringVar(&o.templateFile, "template-file", "", "Read the -template from this file - Optional")
+}
+
+// commandFormat and templateFormat are the output formats of -render-cmd
+// and -template.
+const (
+	commandFormat  = "render-cmd"
+	templateFormat = "template"
+)
+
+func (o *outputFlags) validate() error {
+	jsonErrors = o.format == "json"
+	if o.template != "" && o.templateFile != "" {
+		return errors.New("-template and -template-file cannot be combined")
+	}
+	if (o.template != "" || o.templateFile != "") && o.renderCmd != "" {
+		return errors.New("-template cannot be combined with -render-cmd")
+	}
+	if o.templateFile != "" {
+		data, err := os.ReadFile(o.templateFile)
+		if err != nil {
+			return err
+		}
+		o.template = string(data)
+	}
+	if o.template != "" {
+		t, err := render.ParseTemplate(templateFormat, o.template)
+		if err != nil {
+			return fmt.Errorf("Invalid -template: %w", err)
+		}
+		if !render.Supported(templateFormat) {
+			render.Register(templateFormat, t)
+		}
+		o.format = templateFormat
+	}
+	if o.renderCmd != "" {
+		cmd, err := render.ParseCommand(o.renderCmd)
+		if err != nil {
+			return err
+		}
+		if !render.Supported(commandFormat) {
+			render.Register(commandFormat, cmd)
+		}
+		o.format = commandFormat
+	}
+	if !render.Supported(o.format) {
+		formats := append(slices.Clone(render.Formats), render.Registered()...)
+		return fmt.Errorf("Unknown output format %q, expected one of %s", o.format, strings.Join(formats, ", "))
+	}
+	switch o.icons {
+	case "", "emoji", "ascii":
+	default:
+		return fmt.Errorf("Unknown icon style %q, expected emoji or ascii", o.icons)
+	}
+	lang, err := i18n.Parse(o.lang)
+	if err != nil {
+		return err
+	}
+	o.loc = i18n.FromEnv()
+	if o.locale != "" {
+		if o.loc, err = i18n.ParseLocale(o.locale); err != nil {
+			return err
+		}
+	}
+	// Day names follow -lang when it is given, else the locale's language.
+	if o.lang != "" {
+		o.loc.Lang = lang
+	}
+	o.lang = lang
+	return nil
+}
+
+// writer opens the output destination. The returned close function must be
+// called once writing is done.
+func (o *outputFlags) writer() (io.Writer, func() error, error) {
+	if o.path == "" {
+		return os.Stdout, func() error { return nil }, nil
+	}
+	f, err := os.Create(o.path)
+	if err != nil {
+		return nil, nil, err
+	}
+	return f, f.Close, nil
+}
+
+func (o *outputFlags) renderOptions(w io.Writer) render.Options {
+	if o.clear {
+		// w buffers what is shown on stdout.
+		w = os.Stdout
+	}
+	return render.Options{
+		Color:    !o.noColor && render.ColorSupported(w),
+		Icons:    o.icons,
+		Spark:    o.spark,
+		Graph:    o.graph,
+		Locale:   o.loc,
+		UVAdvice: o.uvAdvice,
+	}
+}
+
+// render writes f to the configured destination.
+func (o *outputFlags) render(f forecast.Forecast) error {
+	return o.write(func(w io.Writer) error {
+		return render.Render(w, o.format, i18n.Translate(f, o.lang), o.renderOptions(w))
+	})
+}
+
+func (o *outputFlags) renderComparison(forecasts []forecast.Forecast) error {
+	translated := make([]forecast.Forecast, len(forecasts))
+	for i, f := range forecasts {
+		translated[i] = i18n.Translate(f, o.lang)
+	}
+	return o.write(func(w io.Writer) error {
+		return render.Comparison(w, o.format, translated, o.renderOptions(w))
+	})
+}
+
+func (o *outputFlags) write(fn func(io.Writer) error) error {
+	if o.clear {
+		// A failed render leaves the previous output on the screen.
+		var buf bytes.Buffer
+		if err := fn(&buf); err != nil {
+			return err
+		}
+		_, err := fmt.Print(clearScreen + buf.String())
+		return err
+	}
+	w, closeOut, err := o.writer()
+	if err != nil {
+		return err
+	}
+	if err := fn(w); err != nil {
+		closeOut()
+		return err
+	}
+	return closeOut()
+}
+
+func printOutputUsage() {
+	fmt.Println("  -o              Output format: " + strings.Join(render.Formats, ", ") + " (default table)")
+	fmt.Println("  -out            Write output to this file instead of stdout")
+	fmt.Println("  -no-color       Disable colored output (also honors NO_COLOR)")
+	fmt.Println("  -icons          Show weather icons: emoji or ascii")
+	fmt.Println("  -spark          Use sparkline blocks instead of star bars")
+	fmt.Println("  -graph          Draw a chart of daily highs and lows instead of the table")
+	fmt.Println("  -lang           Language of descriptions, day names and dates: " + strings.Join(i18n.Languages, ", ") + " (default en)")
+	fmt.Println("  -locale         Format dates, times and numbers for this locale, e.g. en_US or de_DE (default $LC_TIME)")
+	fmt.Println("  -render-cmd     Pipe the forecast as JSON to this command, split at spaces, and print what it")
+	fmt.Println("                  writes instead of an -o format, e.g. -render-cmd \"jq -r .days[0].temp_max\"")
+	fmt.Println("  -template       Go template printed for each day (or hour) instead of an -o format, over")
+	fmt.Println("                  .Date, .TempMax, .TempMin, .Description, .PrecipChance and the other day")
+	fmt.Println("                  fields, .Unit and .Location, e.g. '{{.Date}} {{.TempMax}}°{{.Unit}}'")
+	fmt.Println("  -template-file  Read the -template from this file")
+}
+
+// parseLocation parses args, fills in defaults from the config file and
+// validates the location flags, printing usage and exiting when they are
+// missing or invalid.
+func parseLocation(fs *flag.FlagSet, loc *locationFlags, args []string) config.Config {
+	configPath := fs.String("config", "", "Path to the config file - Optional")
+	fs.Parse(args)
+	return configureLocation(fs, loc, *configPath)
+}
+
+// configureLocation is parseLocation after the flags are parsed, for
+// commands that look at them first. It returns the config file, for the
+// settings that have no flag.
+func configureLocation(fs *flag.FlagSet, loc *locationFlags, configPath string) config.Config {
+	cfg, err := loadConfig(configPath)
+	if err != nil {
+		fatalUsage("Error reading config:", err)
+	}
+	if err := applyConfig(fs, cfg); err != nil {
+		fatalUsage(err)
+	}
+	if o := fs.Lookup("o"); o != nil && o.Value.String() == "json" {
+		jsonErrors = true
+	}
+
+	if err := loc.validate(); err != nil {
+		if errors.Is(err, errNoLocation) {
+			fs.Usage()
+			os.Exit(exitUsage)
+		}
+		fatalUsage(err)
+	}
+	return cfg
+}
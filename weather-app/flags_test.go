@@ -0,0 +1,150 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"io"
+	"os"
+	"slices"
+	"testing"
+
+	"weather-app/internal/config"
+)
+
+// parseLocationFlags parses args and applies cfg as parseLocation does,
+// without validating.
+func parseLocationFlags(t *testing.T, args []string, cfg config.Config) *locationFlags {
+	t.Helper()
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	var loc locationFlags
+	loc.register(fs)
+	if err := fs.Parse(args); err != nil {
+		t.Fatal(err)
+	}
+	if err := applyConfig(fs, cfg); err != nil {
+		t.Fatal(err)
+	}
+	return &loc
+}
+
+func TestConfigLocationReplaced(t *testing.T) {
+	cfg := config.Config{City: "Paris", Country: "France"}
+	tests := []struct {
+		name        string
+		args        []string
+		wantCities  []string
+		wantCountry []string
+	}{
+		{"config only", nil, []string{"Paris"}, []string{"France"}},
+		{"-city", []string{"-city", "Lyon"}, []string{"Lyon"}, []string{"France"}},
+		{"-auto", []string{"-auto"}, nil, nil},
+		{"-fav", []string{"-fav", "home"}, nil, nil},
+		{"-zip", []string{"-zip", "75001"}, nil, []string{"France"}},
+		{"-lat and -lon", []string{"-lat", "52.08", "-lon", "4.3"}, nil, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loc := parseLocationFlags(t, tt.args, cfg)
+			if !slices.Equal([]string(loc.cities), tt.wantCities) || !slices.Equal([]string(loc.countries), tt.wantCountry) {
+				t.Errorf("cities %q in %q, want %q in %q", loc.cities, loc.countries, tt.wantCities, tt.wantCountry)
+			}
+		})
+	}
+}
+
+func TestClearedOutputKeptOnError(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	defer func() { os.Stdout = stdout }()
+
+	out := outputFlags{clear: true}
+	write := func(text string, err error) error {
+		return out.write(func(w io.Writer) error {
+			io.WriteString(w, text)
+			return err
+		})
+	}
+	if err := write("first\n", nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := write("half", errors.New("refresh failed")); err == nil {
+		t.Fatal("no error from a failed render")
+	}
+	w.Close()
+	got, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := clearScreen + "first\n"; string(got) != want {
+		t.Errorf("printed %q, want %q", got, want)
+	}
+}
+
+func TestClientFlagsCollectCACerts(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		cfg  config.Config
+		want []string
+	}{
+		{"config only", nil, config.Config{CACert: "corp.pem"}, []string{"corp.pem"}},
+		{"repeated", []string{"-ca-cert", "a.pem", "-ca-cert", "b.pem"}, config.Config{CACert: "corp.pem"}, []string{"a.pem", "b.pem"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := flag.NewFlagSet("test", flag.ContinueOnError)
+			fs.SetOutput(io.Discard)
+			var client clientFlags
+			client.register(fs)
+			if err := fs.Parse(tt.args); err != nil {
+				t.Fatal(err)
+			}
+			if err := applyConfig(fs, tt.cfg); err != nil {
+				t.Fatal(err)
+			}
+			if !slices.Equal([]string(client.caCerts), tt.want) {
+				t.Errorf("-ca-cert %q, want %q", client.caCerts, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyConfigMerge(t *testing.T) {
+	zero := 0.0
+	cfg := config.Config{Units: "imperial", Precipitation: true, UVIndex: true, Days: 10, WindUnit: "kn", WarnBelow: &zero}
+	tests := []struct {
+		name string
+		args []string
+		want forecastOptions
+	}{
+		{"config only", nil, forecastOptions{Units: "imperial", Precipitation: true, UVIndex: true, Days: 10, WindUnit: "kn", WarnBelow: &zero}},
+		{"flags win", []string{"-units", "si", "-days", "3", "-uv=false", "-warn-below", "-5"}, forecastOptions{Units: "si", Precipitation: true, UVIndex: false, Days: 3, WindUnit: "kn", WarnBelow: new(float64)}},
+	}
+	*tests[1].want.WarnBelow = -5
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := flag.NewFlagSet("test", flag.ContinueOnError)
+			fs.SetOutput(io.Discard)
+			var opts forecastOptions
+			opts.register(fs)
+			if err := fs.Parse(tt.args); err != nil {
+				t.Fatal(err)
+			}
+			if err := applyConfig(fs, cfg); err != nil {
+				t.Fatal(err)
+			}
+			if opts.Units != tt.want.Units || opts.Precipitation != tt.want.Precipitation || opts.UVIndex != tt.want.UVIndex ||
+				opts.Days != tt.want.Days || opts.WindUnit != tt.want.WindUnit {
+				t.Errorf("options = %+v, want %+v", opts, tt.want)
+			}
+			if opts.WarnBelow == nil || *opts.WarnBelow != *tt.want.WarnBelow {
+				t.Errorf("-warn-below = %v, want %g", opts.WarnBelow, *tt.want.WarnBelow)
+			}
+		})
+	}
+}
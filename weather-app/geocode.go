@@ -0,0 +1,110 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"weather-app/internal/render"
+	"weather-app/pkg/openmeteo"
+)
+
+// maxGeocodeResults is the most results the geocoding API returns.
+const maxGeocodeResults = 100
+
+func geocodeTable(w io.Writer, results []openmeteo.GeocodingResult) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "Name\tRegion\tCountry\tLatitude\tLongitude\tPopulation\tTimezone")
+	for _, r := range results {
+		region := r.Admin1
+		if region == "" {
+			region = "-"
+		}
+		population := "-"
+		if r.Population > 0 {
+			population = strconv.Itoa(r.Population)
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%.4f\t%.4f\t%s\t%s\n", r.Name, region, r.Country, r.Latitude, r.Longitude, population, r.Timezone)
+	}
+	return tw.Flush()
+}
+
+func runGeocode(ctx context.Context, args []string) {
+	fs := flag.NewFlagSet("geocode", flag.ExitOnError)
+	var client clientFlags
+	client.register(fs)
+	country := fs.String("country", "", "Only show places in this country, by name or ISO code - Optional")
+	count := fs.Int("count", 10, "Number of results to search (1-100) - Optional")
+	fs.StringVar(&client.language, "lang", "", "Language of the place names, e.g. de - Optional")
+	format := fs.String("o", "table", "Output format: table or json - Optional")
+
+	fs.Usage = func() {
+		fmt.Println("Search the Open-Meteo geocoding API and list every matching place, to find")
+		fmt.Println("the -city and -country, or the -lat and -lon, of a forecast.")
+		fmt.Println("Usage:")
+		fmt.Println("  weather-app geocode [flags] NAME")
+		fmt.Println()
+		fmt.Println("Optional Flags:")
+		fmt.Println("  -country        Only show places in this country, by name or ISO code (e.g. NL)")
+		fmt.Println("  -count          Number of results to search (default 10, max 100)")
+		fmt.Println("  -lang           Language of the place names, e.g. de (default en)")
+		fmt.Println("  -o              Output format: table or json (default table)")
+		printClientUsage()
+	}
+
+	// The name may come before the flags too.
+	name, args := splitName(args)
+	fs.Parse(args)
+	jsonErrors = *format == "json"
+	if name == "" {
+		name = strings.Join(fs.Args(), " ")
+	} else if fs.NArg() > 0 {
+		fatalUsage(fmt.Errorf("Unexpected argument %q", fs.Arg(0)))
+	}
+	if name == "" {
+		fs.Usage()
+		os.Exit(exitUsage)
+	}
+	if *format != "table" && *format != "json" {
+		fatalUsage("geocode can only be shown as table or json")
+	}
+	if *count < 1 || *count > maxGeocodeResults {
+		fatalUsage(fmt.Sprintf("-count must be between 1 and %d", maxGeocodeResults))
+	}
+
+	if err := client.setup(slog.LevelWarn); err != nil {
+		fatal(err)
+	}
+
+	results, err := client.newClient().Search(ctx, openmeteo.GeocodingRequest{Name: name, Count: *count})
+	if err != nil {
+		fatal(err)
+	}
+	if *country != "" {
+		var matches []openmeteo.GeocodingResult
+		for _, r := range results {
+			if r.InCountry(*country) {
+				matches = append(matches, r)
+			}
+		}
+		results = matches
+	}
+	if len(results) == 0 {
+		fatal(fmt.Errorf("No place called %s: %w", name, openmeteo.ErrCityNotFound))
+	}
+
+	if *format == "json" {
+		err = render.JSON(os.Stdout, results)
+	} else {
+		err = geocodeTable(os.Stdout, results)
+	}
+	if err != nil {
+		fatal(err)
+	}
+}
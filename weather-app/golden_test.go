@@ -0,0 +1,360 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"weather-app/internal/activity"
+	"weather-app/internal/chart"
+	"weather-app/internal/clothing"
+	"weather-app/internal/forecast"
+	"weather-app/internal/forecastlog"
+	"weather-app/internal/i18n"
+	"weather-app/internal/provider"
+	"weather-app/internal/render"
+	"weather-app/pkg/nws"
+	"weather-app/pkg/openmeteo"
+	"weather-app/pkg/openmeteo/openmeteotest"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+// fakeTransport serves the testdata files by endpoint.
+func fakeTransport(t *testing.T, files map[string]string) *openmeteotest.Transport {
+	t.Helper()
+	tr := openmeteotest.NewTransport()
+	for endpoint, file := range files {
+		if err := tr.HandleFile(endpoint, filepath.Join("testdata", file)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return tr
+}
+
+func checkGolden(t *testing.T, name string, got []byte) {
+	t.Helper()
+	path := filepath.Join("testdata", name+".golden")
+	if *update {
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("%s output differs from %s\ngot:\n%s\nwant:\n%s", name, path, got, want)
+	}
+}
+
+var (
+	hague        = forecast.Location{Name: "The Hague", Country: "Netherlands", Latitude: 52.08, Longitude: 4.3}
+	paris        = forecast.Location{Name: "Paris", Country: "France", Latitude: 48.86, Longitude: 2.34}
+	hoboken      = forecast.Location{Name: "Hoboken", Country: "United States", Latitude: 40.744, Longitude: -74.0324}
+	scheveningen = forecast.Location{Name: "Scheveningen", Country: "Netherlands", Latitude: 52.11, Longitude: 4.26}
+)
+
+// forecastFile serves file as the Open-Meteo forecast.
+func forecastFile(file string) map[string]string {
+	return map[string]string{openmeteo.DefaultForecastURL: file}
+}
+
+type fetchFunc func(context.Context, provider.Provider) (forecast.Forecast, error)
+
+func daily(opts forecastOptions) fetchFunc {
+	return func(ctx context.Context, p provider.Provider) (forecast.Forecast, error) {
+		return fetchDaily(ctx, p, hague, opts)
+	}
+}
+
+func hourly(opts forecastOptions, hours int) fetchFunc {
+	return func(ctx context.Context, p provider.Provider) (forecast.Forecast, error) {
+		return fetchHourly(ctx, p, hague, opts, hours)
+	}
+}
+
+// written returns what write writes, failing t on an error.
+func written(t *testing.T, write func(io.Writer) error) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := write(&buf); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// queryContains checks that the first request's query parameter key
+// mentions want.
+func queryContains(key, want string) func(*testing.T, []*http.Request) {
+	return func(t *testing.T, requests []*http.Request) {
+		if q := requests[0].URL.Query().Get(key); !strings.Contains(q, want) {
+			t.Errorf("%s = %q, want %s", key, q, want)
+		}
+	}
+}
+
+func TestGolden(t *testing.T) {
+	us, err := i18n.ParseLocale("en_US")
+	if err != nil {
+		t.Fatal(err)
+	}
+	german, err := i18n.ParseLocale("de_DE")
+	if err != nil {
+		t.Fatal(err)
+	}
+	allDaily := forecastOptions{Precipitation: true, UVIndex: true, Sunrise: true, Sunset: true, Daylight: true, Wind: true, Feels: true, Snow: true, Humidity: true, Pressure: true, WindUnit: "kmh"}
+	pastDays := allDaily
+	pastDays.PastDays = 2
+	imperial := allDaily
+	imperial.Units, imperial.WindUnit = "imperial", ""
+	below, above := 11.0, 20.0
+	beach := activity.Profiles["beach"]
+	nwsDaily := map[string]string{
+		nws.DefaultBaseURL + "/points/40.7440,-74.0324":       "nws_points.json",
+		nws.DefaultBaseURL + "/gridpoints/OKX/33,35/forecast": "nws_forecast.json",
+	}
+
+	// Each test writes testdata/NAME.golden. The fake serves files, or
+	// daily.json as the forecast when nil. The forecast from fetch is
+	// rendered as format, unless output writes something else.
+	tests := []struct {
+		name   string
+		files  map[string]string
+		nws    bool
+		fetch  fetchFunc
+		format string
+		opts   render.Options
+		output func(*testing.T, provider.Provider) []byte
+		// check, if set, inspects the requests made.
+		check func(*testing.T, []*http.Request)
+	}{
+		{name: "daily_table", fetch: daily(allDaily), format: "table"},
+		{name: "daily_ragged", files: forecastFile("daily_ragged.json"), fetch: daily(allDaily), format: "table"},
+		{name: "daily_ragged_json", files: forecastFile("daily_ragged.json"), fetch: daily(allDaily), format: "json"},
+		{name: "daily_past_days", fetch: daily(pastDays), format: "table"},
+		{name: "daily_uv_advice", fetch: daily(forecastOptions{UVIndex: true}), format: "table", opts: render.Options{UVAdvice: true}},
+		{name: "daily_warnings", fetch: daily(forecastOptions{WarnBelow: &below, WarnAbove: &above}), format: "table"},
+		{name: "daily_spark", fetch: daily(allDaily), format: "table", opts: render.Options{Spark: true}},
+		{name: "daily_icons", fetch: daily(allDaily), format: "table", opts: render.Options{Icons: "ascii"}},
+		{name: "daily_graph", fetch: daily(allDaily), format: "table", opts: render.Options{Graph: true}},
+		{name: "daily_de", fetch: func(ctx context.Context, p provider.Provider) (forecast.Forecast, error) {
+			f, err := fetchDaily(ctx, p, hague, allDaily)
+			return i18n.Translate(f, "de"), err
+		}, format: "table", opts: render.Options{Locale: i18n.Locale{Lang: "de"}}},
+		{name: "daily_de_DE", fetch: daily(allDaily), format: "table", opts: render.Options{Locale: german}},
+		{name: "daily_imperial", fetch: daily(imperial), format: "table"},
+		{name: "daily_json", fetch: daily(allDaily), format: "json"},
+		{name: "daily_yaml", fetch: daily(allDaily), format: "yaml"},
+		{name: "daily_csv", fetch: daily(allDaily), format: "csv"},
+		{name: "daily_markdown", fetch: daily(allDaily), format: "markdown"},
+		{name: "daily_html", fetch: daily(allDaily), format: "html"},
+		{name: "daily_waybar", fetch: daily(allDaily), format: "waybar"},
+		{name: "daily_i3status", fetch: daily(allDaily), format: "i3status"},
+		{name: "daily_ics", fetch: daily(allDaily), format: "ics", opts: render.Options{Timestamp: time.Date(2024, 6, 3, 6, 0, 0, 0, time.UTC)}},
+		{name: "daily_score", fetch: daily(forecastOptions{Precipitation: true, UVIndex: true, Wind: true, Activity: "beach", Score: &beach}), format: "table"},
+		{name: "daily_what_to_wear", fetch: daily(forecastOptions{Feels: true, Precipitation: true, UVIndex: true, Wind: true, WhatToWear: true, Wear: clothing.Rules}), format: "table"},
+		{name: "daily_summary", fetch: func(ctx context.Context, p provider.Provider) (forecast.Forecast, error) {
+			f, err := fetchDaily(ctx, p, hague, forecastOptions{Precipitation: true})
+			summarize(&f)
+			return f, err
+		}, format: "table"},
+		{name: "daily_summary_json", fetch: func(ctx context.Context, p provider.Provider) (forecast.Forecast, error) {
+			f, err := fetchDaily(ctx, p, hague, forecastOptions{Precipitation: true, Units: "imperial"})
+			summarize(&f)
+			return f, err
+		}, format: "json"},
+		{name: "daily_clouds", files: forecastFile("clouds.json"), fetch: daily(forecastOptions{Clouds: true, Days: 2}), format: "table"},
+		{name: "daily_brief", output: func(t *testing.T, p provider.Provider) []byte {
+			f, err := fetchDaily(context.Background(), p, hague, forecastOptions{Precipitation: true, UVIndex: true})
+			if err != nil {
+				t.Fatal(err)
+			}
+			return written(t, func(w io.Writer) error {
+				for _, text := range []string{"", "{{.Icon}} {{.High}}{{.Degrees}} {{upper .Description}}"} {
+					tmpl, err := render.ParseBrief(text)
+					if err != nil {
+						return err
+					}
+					if err := render.Brief(w, tmpl, f); err != nil {
+						return err
+					}
+				}
+				return nil
+			})
+		}},
+		{name: "daily_diff", output: func(t *testing.T, p provider.Provider) []byte {
+			ctx := context.Background()
+			db, err := forecastlog.Open(filepath.Join(t.TempDir(), "forecasts.sqlite"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer db.Close()
+			opts := forecastOptions{Precipitation: true, Wind: true, Log: db, Diff: true}
+			if _, err := fetchDaily(ctx, p, hague, opts); err != nil {
+				t.Fatal(err)
+			}
+			// Make the logged forecast differ from the one fetched next.
+			_, _, err = db.Query(ctx, `UPDATE fetches SET fetched_at = '2024-06-02T06:00:00Z'`)
+			if err == nil {
+				_, _, err = db.Query(ctx, `UPDATE days SET temp_max = temp_max - 3, precipitation_probability_max = 60, wind_speed_max = wind_speed_max + 0.5 WHERE date = '2024-06-04'`)
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			f, err := fetchDaily(ctx, p, hague, opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			return written(t, func(w io.Writer) error { return render.Render(w, "table", f, render.Options{}) })
+		}},
+		{name: "hourly_table", files: forecastFile("hourly.json"), fetch: hourly(forecastOptions{Snow: true, Humidity: true, Pressure: true}, 6), format: "table"},
+		{name: "hourly_markdown", files: forecastFile("hourly.json"), fetch: hourly(forecastOptions{Snow: true, Humidity: true, Pressure: true}, 6), format: "markdown"},
+		{name: "hourly_precipitation", files: forecastFile("hourly_precipitation.json"), fetch: hourly(forecastOptions{Precipitation: true}, 8), format: "table"},
+		{name: "hourly_clouds_imperial", files: forecastFile("clouds.json"), fetch: hourly(forecastOptions{Clouds: true, Units: "imperial"}, 48), format: "table"},
+		{name: "hourly_en_US", files: forecastFile("hourly.json"), fetch: hourly(forecastOptions{Snow: true}, 6), format: "table", opts: render.Options{Locale: us}},
+		{name: "hourly_tz", files: forecastFile("hourly.json"), fetch: hourly(forecastOptions{Timezone: "America/New_York"}, 6), format: "table"},
+		{name: "current_table", files: forecastFile("current.json"), fetch: func(ctx context.Context, p provider.Provider) (forecast.Forecast, error) {
+			return fetchCurrent(ctx, p, hague, forecastOptions{})
+		}, format: "table"},
+		{name: "chart_svg", output: func(t *testing.T, p provider.Provider) []byte {
+			f, err := fetchDaily(context.Background(), p, hague, forecastOptions{Precipitation: true})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := chart.Write(io.Discard, "png", f); err != nil {
+				t.Fatal(err)
+			}
+			return written(t, func(w io.Writer) error { return chart.Write(w, "svg", f) })
+		}},
+		{name: "ensemble_table", files: map[string]string{openmeteo.DefaultForecastURL: "daily.json", openmeteo.DefaultEnsembleURL: "ensemble.json"}, fetch: daily(forecastOptions{Ensemble: true}), format: "table"},
+		{name: "ensemble_csv", files: map[string]string{openmeteo.DefaultForecastURL: "daily.json", openmeteo.DefaultEnsembleURL: "ensemble.json"}, fetch: daily(forecastOptions{Ensemble: true}), format: "csv"},
+		{name: "models_table", output: func(t *testing.T, p provider.Provider) []byte {
+			opts := forecastOptions{Model: "gfs, icon"}
+			if err := opts.validate(); err != nil {
+				t.Fatal(err)
+			}
+			forecasts, err := fetchModelForecasts(context.Background(), p, hague, opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			return written(t, func(w io.Writer) error { return render.Comparison(w, "table", forecasts, render.Options{}) })
+		}, check: func(t *testing.T, requests []*http.Request) {
+			var models []string
+			for _, r := range requests {
+				models = append(models, r.URL.Query().Get("models"))
+			}
+			slices.Sort(models)
+			if !slices.Equal(models, []string{"gfs_seamless", "icon_seamless"}) {
+				t.Errorf("models = %q, want gfs_seamless and icon_seamless", models)
+			}
+		}},
+		{name: "difference_table", files: map[string]string{}, output: func(t *testing.T, _ provider.Provider) []byte {
+			return written(t, func(w io.Writer) error { return render.Difference(w, "table", hagueParisDiff(t), render.Options{}) })
+		}},
+		{name: "difference_json", files: map[string]string{}, output: func(t *testing.T, _ provider.Provider) []byte {
+			return written(t, func(w io.Writer) error { return render.Difference(w, "json", hagueParisDiff(t), render.Options{}) })
+		}},
+		{name: "marine_table", files: map[string]string{openmeteo.DefaultMarineURL: "marine.json"}, fetch: func(ctx context.Context, p provider.Provider) (forecast.Forecast, error) {
+			return fetchMarine(ctx, p, scheveningen, 5)
+		}, format: "table", check: queryContains("daily", "swell_wave_period_max")},
+		{name: "marine_csv", files: map[string]string{openmeteo.DefaultMarineURL: "marine.json"}, fetch: func(ctx context.Context, p provider.Provider) (forecast.Forecast, error) {
+			return fetchMarine(ctx, p, scheveningen, 5)
+		}, format: "csv"},
+		{name: "pollen_table", files: map[string]string{openmeteo.DefaultAirQualityURL: "pollen.json"}, fetch: func(ctx context.Context, p provider.Provider) (forecast.Forecast, error) {
+			return fetchPollen(ctx, p, hague, 3)
+		}, format: "table", check: queryContains("hourly", "birch_pollen")},
+		{name: "pollen_csv", files: map[string]string{openmeteo.DefaultAirQualityURL: "pollen.json"}, fetch: func(ctx context.Context, p provider.Provider) (forecast.Forecast, error) {
+			return fetchPollen(ctx, p, hague, 3)
+		}, format: "csv"},
+		{name: "agri_table", files: forecastFile("agri.json"), fetch: func(ctx context.Context, p provider.Provider) (forecast.Forecast, error) {
+			return fetchAgri(ctx, p, hague, forecastOptions{Units: "metric", Days: 2, PastDays: 1}, defaultGDDBase)
+		}, format: "table", check: queryContains("daily", "et0_fao_evapotranspiration")},
+		{name: "agri_csv", files: forecastFile("agri.json"), fetch: func(ctx context.Context, p provider.Provider) (forecast.Forecast, error) {
+			return fetchAgri(ctx, p, hague, forecastOptions{Units: "metric", Days: 2, PastDays: 1}, defaultGDDBase)
+		}, format: "csv"},
+		{name: "wind_rose_table", files: forecastFile("hourly_precipitation.json"), output: func(t *testing.T, p provider.Provider) []byte {
+			rose, err := fetchWindRose(context.Background(), p, hague, forecastOptions{Units: "metric", Days: 1})
+			if err != nil {
+				t.Fatal(err)
+			}
+			return written(t, func(w io.Writer) error { return render.WindRose(w, "table", rose, render.Options{}) })
+		}},
+		{name: "geocode_table", files: map[string]string{openmeteo.DefaultGeocodingURL: "geocoding.json"}, output: func(t *testing.T, p provider.Provider) []byte {
+			results, err := p.(*provider.OpenMeteo).Client.Search(context.Background(), openmeteo.GeocodingRequest{Name: "The Hague"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			return written(t, func(w io.Writer) error { return geocodeTable(w, results) })
+		}},
+		{name: "nws_daily_table", files: nwsDaily, nws: true, fetch: func(ctx context.Context, p provider.Provider) (forecast.Forecast, error) {
+			return fetchDaily(ctx, p, hoboken, forecastOptions{Precipitation: true, Wind: true, Units: "imperial", Days: 7})
+		}, format: "table"},
+		{name: "nws_alerts_table", files: map[string]string{nws.DefaultBaseURL + "/alerts/active": "nws_alerts.json"}, nws: true, fetch: func(ctx context.Context, p provider.Provider) (forecast.Forecast, error) {
+			alerts, err := fetchAlerts(ctx, p, hoboken)
+			return forecast.Forecast{Location: hoboken, Alerts: alerts}, err
+		}, format: "table", check: func(t *testing.T, requests []*http.Request) {
+			if q := requests[0].URL.Query().Get("point"); q != "40.7440,-74.0324" {
+				t.Errorf("point = %q, want 40.7440,-74.0324", q)
+			}
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			files := tt.files
+			if files == nil {
+				files = forecastFile("daily.json")
+			}
+			tr := fakeTransport(t, files)
+			var p provider.Provider = &provider.OpenMeteo{Client: openmeteo.NewClient(openmeteo.WithHTTPClient(tr.Client()))}
+			if tt.nws {
+				p = &provider.NWS{Client: nws.NewClient(nws.WithHTTPClient(tr.Client()))}
+			}
+
+			var got []byte
+			if tt.output != nil {
+				got = tt.output(t, p)
+			} else {
+				f, err := tt.fetch(context.Background(), p)
+				if err != nil {
+					t.Fatal(err)
+				}
+				got = written(t, func(w io.Writer) error { return render.Render(w, tt.format, f, tt.opts) })
+			}
+			if tt.check != nil {
+				tt.check(t, tr.Requests())
+			}
+			checkGolden(t, tt.name, got)
+		})
+	}
+}
+
+// hagueParisDiff compares the forecasts of The Hague and Paris.
+func hagueParisDiff(t *testing.T) forecast.Difference {
+	t.Helper()
+	var forecasts []forecast.Forecast
+	for _, place := range []struct {
+		file     string
+		location forecast.Location
+	}{{"daily.json", hague}, {"daily_paris.json", paris}} {
+		tr := fakeTransport(t, forecastFile(place.file))
+		p := &provider.OpenMeteo{Client: openmeteo.NewClient(openmeteo.WithHTTPClient(tr.Client()))}
+		f, err := fetchDaily(context.Background(), p, place.location, forecastOptions{Precipitation: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		forecasts = append(forecasts, f)
+	}
+	return forecast.Diff(forecasts[0], forecasts[1])
+}
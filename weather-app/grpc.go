@@ -0,0 +1,267 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"weather-app/internal/forecast"
+	"weather-app/internal/i18n"
+	"weather-app/internal/provider"
+	"weather-app/pkg/nws"
+	"weather-app/pkg/openmeteo"
+	"weather-app/pkg/weatherpb"
+)
+
+// grpcServer serves the WeatherService of weather.proto from the same
+// provider as the HTTP endpoints.
+type grpcServer struct {
+	weatherpb.UnimplementedWeatherServiceServer
+	provider provider.Provider
+}
+
+func invalidArgument(err error) error {
+	return status.Error(codes.InvalidArgument, err.Error())
+}
+
+// grpcError maps provider errors to status codes, as writeError does to
+// HTTP statuses.
+func grpcError(err error) error {
+	code := codes.Unknown
+	switch {
+	case errors.Is(err, openmeteo.ErrCityNotFound), errors.Is(err, nws.ErrUnsupportedLocation):
+		code = codes.NotFound
+	case errors.Is(err, openmeteo.ErrAPIUnavailable):
+		code = codes.Unavailable
+	case errors.Is(err, openmeteo.ErrSchemaChanged):
+		code = codes.Internal
+	case errors.Is(err, context.DeadlineExceeded):
+		code = codes.DeadlineExceeded
+	case errors.Is(err, context.Canceled):
+		code = codes.Canceled
+	}
+	return status.Error(code, err.Error())
+}
+
+// place resolves either the coordinates or the city and country of p.
+// Ambiguous city names resolve to the first match.
+func (s *grpcServer) place(ctx context.Context, p *weatherpb.Place) (forecast.Location, error) {
+	if c := p.GetCoordinates(); c != nil {
+		if err := validateCoordinates(c.Latitude, c.Longitude); err != nil {
+			return forecast.Location{}, invalidArgument(err)
+		}
+		return forecast.Location{Latitude: c.Latitude, Longitude: c.Longitude}, nil
+	}
+	if p.GetCity() == "" || p.GetCountry() == "" {
+		return forecast.Location{}, status.Error(codes.InvalidArgument, "either city and country or coordinates are required")
+	}
+	matches, err := s.provider.Geocode(ctx, p.City, p.Country)
+	if err != nil {
+		return forecast.Location{}, grpcError(err)
+	}
+	return matches[0].Location, nil
+}
+
+func unitOptions(u *weatherpb.Units) forecastOptions {
+	return forecastOptions{Units: u.GetSystem(), Fahrenheit: u.GetFahrenheit(), WindUnit: u.GetWind()}
+}
+
+func (s *grpcServer) GetForecast(ctx context.Context, req *weatherpb.GetForecastRequest) (*weatherpb.Forecast, error) {
+	opts := unitOptions(req.Units)
+	opts.Precipitation = req.Precipitation
+	opts.UVIndex = req.UvIndex
+	opts.Sunrise = req.Sunrise
+	opts.Sunset = req.Sunset
+	opts.Daylight = req.Daylight
+	opts.Wind = req.Wind
+	opts.Feels = req.Feels
+	opts.Snow = req.Snow
+	opts.Humidity = req.Humidity
+	opts.Pressure = req.Pressure
+	opts.Ensemble = req.Ensemble
+	opts.Model = req.Model
+	opts.Days = int(req.Days)
+	if opts.Days == 0 {
+		opts.Days = defaultDays
+	}
+	if err := opts.validate(); err != nil {
+		return nil, invalidArgument(err)
+	}
+	if len(opts.Models) > 1 {
+		return nil, status.Error(codes.InvalidArgument, "only one model can be requested")
+	}
+	if req.Hours < 0 || req.Hours > 384 {
+		return nil, status.Error(codes.InvalidArgument, "hours must be between 1 and 384")
+	}
+	lang, err := i18n.Parse(req.Lang)
+	if err != nil {
+		return nil, invalidArgument(err)
+	}
+
+	place, err := s.place(ctx, req.Place)
+	if err != nil {
+		return nil, err
+	}
+	var f forecast.Forecast
+	if req.Hours > 0 {
+		f, err = fetchHourly(ctx, s.provider, place, opts, int(req.Hours))
+	} else {
+		f, err = fetchDaily(ctx, s.provider, place, opts)
+	}
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return forecastProto(i18n.Translate(f, lang)), nil
+}
+
+func (s *grpcServer) GetCurrent(ctx context.Context, req *weatherpb.GetCurrentRequest) (*weatherpb.Forecast, error) {
+	opts := unitOptions(req.Units)
+	if err := opts.validate(); err != nil {
+		return nil, invalidArgument(err)
+	}
+	lang, err := i18n.Parse(req.Lang)
+	if err != nil {
+		return nil, invalidArgument(err)
+	}
+	place, err := s.place(ctx, req.Place)
+	if err != nil {
+		return nil, err
+	}
+	f, err := fetchCurrent(ctx, s.provider, place, opts)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return forecastProto(i18n.Translate(f, lang)), nil
+}
+
+func (s *grpcServer) ResolveCity(ctx context.Context, req *weatherpb.ResolveCityRequest) (*weatherpb.ResolveCityResponse, error) {
+	if req.City == "" || req.Country == "" {
+		return nil, status.Error(codes.InvalidArgument, "city and country are required")
+	}
+	matches, err := s.provider.Geocode(ctx, req.City, req.Country)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	resp := &weatherpb.ResolveCityResponse{}
+	for _, m := range matches {
+		resp.Places = append(resp.Places, &weatherpb.ResolvedPlace{
+			Location:   locationProto(m.Location),
+			Region:     m.Region,
+			Population: int64(m.Population),
+		})
+	}
+	return resp, nil
+}
+
+func locationProto(l forecast.Location) *weatherpb.Location {
+	return &weatherpb.Location{
+		Name:      l.Name,
+		Country:   l.Country,
+		Latitude:  l.Latitude,
+		Longitude: l.Longitude,
+		Timezone:  l.Timezone,
+	}
+}
+
+func timestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
+
+func weatherCode(code *int) *int32 {
+	if code == nil {
+		return nil
+	}
+	c := int32(*code)
+	return &c
+}
+
+func forecastProto(f forecast.Forecast) *weatherpb.Forecast {
+	pb := &weatherpb.Forecast{
+		Location: locationProto(f.Location),
+		Units: &weatherpb.ForecastUnits{
+			Temperature:   f.Units.Temperature,
+			Precipitation: f.Units.Precipitation,
+			WindSpeed:     f.Units.WindSpeed,
+			Snow:          f.Units.Snow,
+		},
+		Model: f.Model,
+	}
+	if c := f.Current; c != nil {
+		pb.Current = &weatherpb.Current{
+			Time:          timestamppb.New(c.Time),
+			Temperature:   c.Temperature,
+			WindSpeed:     c.WindSpeed,
+			WindDirection: c.WindDirection,
+			WeatherCode:   int32(c.WeatherCode),
+			Description:   c.Description,
+			IsDay:         c.IsDay,
+		}
+	}
+	for _, d := range f.Days {
+		pb.Days = append(pb.Days, &weatherpb.Day{
+			Date:                        d.Date.String(),
+			TempMax:                     d.TempMax,
+			TempMin:                     d.TempMin,
+			TempMaxP10:                  d.TempMaxP10,
+			TempMaxP90:                  d.TempMaxP90,
+			TempMinP10:                  d.TempMinP10,
+			TempMinP90:                  d.TempMinP90,
+			ApparentTemperatureMax:      d.FeelsMax,
+			ApparentTemperatureMin:      d.FeelsMin,
+			Precipitation:               d.Precipitation,
+			PrecipitationProbabilityMax: d.PrecipChance,
+			PrecipitationHours:          d.PrecipHours,
+			SnowfallSum:                 d.Snowfall,
+			RelativeHumidityMean:        d.HumidityMean,
+			RelativeHumidityMin:         d.HumidityMin,
+			RelativeHumidityMax:         d.HumidityMax,
+			DewPointMean:                d.DewPointMean,
+			DewPointMin:                 d.DewPointMin,
+			DewPointMax:                 d.DewPointMax,
+			SurfacePressureMean:         d.PressureMean,
+			PressureTrend:               d.PressureTrend,
+			UvIndex:                     d.UVIndex,
+			Sunrise:                     timestamp(d.Sunrise),
+			Sunset:                      timestamp(d.Sunset),
+			DaylightDuration:            d.Daylight,
+			SunshineDuration:            d.Sunshine,
+			WindSpeedMax:                d.WindSpeedMax,
+			WindGustsMax:                d.WindGustsMax,
+			WindDirectionDominant:       d.WindDirection,
+			WeatherCode:                 weatherCode(d.WeatherCode),
+			Description:                 d.Description,
+		})
+	}
+	for _, h := range f.Hours {
+		pb.Hours = append(pb.Hours, &weatherpb.Hour{
+			Time:                     timestamppb.New(h.Time),
+			Temperature:              h.Temperature,
+			PrecipitationProbability: h.PrecipProbability,
+			WindSpeed:                h.WindSpeed,
+			WindDirection:            h.WindDirection,
+			SnowDepth:                h.SnowDepth,
+			RelativeHumidity:         h.Humidity,
+			DewPoint:                 h.DewPoint,
+			SurfacePressure:          h.Pressure,
+		})
+	}
+	for _, a := range f.Alerts {
+		pb.Alerts = append(pb.Alerts, &weatherpb.Alert{
+			Event:       a.Event,
+			Severity:    a.Severity,
+			Headline:    a.Headline,
+			Description: a.Description,
+			Instruction: a.Instruction,
+			Start:       timestamppb.New(a.Start),
+			End:         timestamp(a.End),
+		})
+	}
+	return pb
+}
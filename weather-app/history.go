@@ -0,0 +1,97 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"weather-app/internal/provider"
+	"weather-app/internal/units"
+)
+
+func parseDateRange(start, end string) error {
+	if start == "" || end == "" {
+		return fmt.Errorf("Both -start and -end are required")
+	}
+	s, err := time.Parse("2006-01-02", start)
+	if err != nil {
+		return fmt.Errorf("Invalid -start date %q, expected YYYY-MM-DD", start)
+	}
+	e, err := time.Parse("2006-01-02", end)
+	if err != nil {
+		return fmt.Errorf("Invalid -end date %q, expected YYYY-MM-DD", end)
+	}
+	if e.Before(s) {
+		return fmt.Errorf("-end must not be before -start")
+	}
+	return nil
+}
+
+func runHistory(ctx context.Context, args []string) {
+	fs := flag.NewFlagSet("history", flag.ExitOnError)
+	var loc locationFlags
+	var client clientFlags
+	loc.register(fs)
+	client.register(fs)
+	start := fs.String("start", "", "First day to show, YYYY-MM-DD - *Mandatory")
+	end := fs.String("end", "", "Last day to show, YYYY-MM-DD - *Mandatory")
+	opts := forecastOptions{Precipitation: true}
+	fs.BoolVar(&opts.Fahrenheit, "f", false, "Use fahrenheit - Optional")
+	fs.StringVar(&opts.Units, "units", string(units.Metric), "Unit system: "+strings.Join(units.Systems, ", ")+" - Optional")
+	var out outputFlags
+	out.register(fs)
+
+	fs.Usage = func() {
+		fmt.Println("Past daily temperatures and precipitation for a city.")
+		fmt.Println("Usage:")
+		fmt.Println("  weather-app history -start YYYY-MM-DD -end YYYY-MM-DD [flags]")
+		fmt.Println()
+		printLocationUsage()
+		fmt.Println("  -start          First day to show (YYYY-MM-DD)")
+		fmt.Println("  -end            Last day to show (YYYY-MM-DD)")
+		fmt.Println()
+		fmt.Println("Optional Flags:")
+		fmt.Println("  -units          Unit system: metric, imperial or si (default metric)")
+		fmt.Println("  -f              Use fahrenheit whatever the unit system")
+		printOutputUsage()
+		printClientUsage()
+	}
+
+	parseLocation(fs, &loc, args)
+
+	if err := out.validate(); err != nil {
+		fatalUsage(err)
+	}
+	client.language = out.lang
+
+	if err := parseDateRange(*start, *end); err != nil {
+		fatalUsage(err)
+	}
+
+	// Only Open-Meteo keeps an archive of past weather.
+	if client.provider != provider.DefaultName {
+		fatalUsage("history is only available from " + provider.DefaultName)
+	}
+	if err := client.setup(slog.LevelWarn); err != nil {
+		fatal(err)
+	}
+
+	p := &provider.OpenMeteo{Client: client.newClient()}
+
+	place, err := loc.resolve(ctx, p)
+	if err != nil {
+		fatal(err)
+	}
+
+	f, err := opts.convert(p.Archive(ctx, place, opts.providerOptions(), *start, *end))
+	if err != nil {
+		fatal(err)
+	}
+
+	if err := out.render(f); err != nil {
+		fatal(err)
+	}
+}
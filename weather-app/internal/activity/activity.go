@@ -0,0 +1,150 @@
+// Package activity scores how suitable forecast days are for outdoor
+// activities, from 0 (stay in) to 10 (perfect).
+package activity
+
+import (
+	"math"
+	"sort"
+
+	"weather-app/internal/forecast"
+)
+
+// Profile is what makes a day good for an activity. Values are metric, as
+// the providers return them. Each factor scores between 0 and 1, and the
+// day's score is their weighted mean; a factor without a value for the day
+// is left out.
+type Profile struct {
+	// IdealLow and IdealHigh bound the best high temperature (the feels
+	// like high when known) in °C. The temperature scores 0 at Tolerance
+	// degrees outside them.
+	IdealLow  float64
+	IdealHigh float64
+	Tolerance float64
+	// MaxWind is the wind speed in km/h, and MaxPrecip the precipitation in
+	// mm, at which they score 0. The chance of precipitation counts too.
+	MaxWind   float64
+	MaxPrecip float64
+	// MaxUV is the highest UV index that does not count against the day;
+	// each point above it takes a third off.
+	MaxUV float64
+
+	TemperatureWeight   float64
+	PrecipitationWeight float64
+	WindWeight          float64
+	UVWeight            float64
+}
+
+// Profiles are the built-in activities.
+var Profiles = map[string]Profile{
+	"beach": {
+		IdealLow: 25, IdealHigh: 32, Tolerance: 8, MaxWind: 35, MaxPrecip: 2, MaxUV: 8,
+		TemperatureWeight: 4, PrecipitationWeight: 3, WindWeight: 2, UVWeight: 1,
+	},
+	"run": {
+		IdealLow: 8, IdealHigh: 18, Tolerance: 12, MaxWind: 40, MaxPrecip: 5, MaxUV: 9,
+		TemperatureWeight: 3, PrecipitationWeight: 3, WindWeight: 2, UVWeight: 1,
+	},
+	"bbq": {
+		IdealLow: 18, IdealHigh: 28, Tolerance: 10, MaxWind: 30, MaxPrecip: 1, MaxUV: 10,
+		TemperatureWeight: 3, PrecipitationWeight: 4, WindWeight: 2, UVWeight: 1,
+	},
+	// Snow is welcome when skiing, so precipitation counts for little.
+	"ski": {
+		IdealLow: -10, IdealHigh: 0, Tolerance: 10, MaxWind: 50, MaxPrecip: 15, MaxUV: 10,
+		TemperatureWeight: 3, PrecipitationWeight: 1, WindWeight: 3, UVWeight: 1,
+	},
+}
+
+// Default is the profile custom activities from the config file start from.
+var Default = Profiles["run"]
+
+// Names lists the built-in activities.
+func Names() []string {
+	names := make([]string, 0, len(Profiles))
+	for name := range Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Score returns the score of day for p, rounded to 0.1, or nil when the
+// day has no value any factor with a weight looks at.
+func Score(day forecast.Day, p Profile) *float64 {
+	var sum, weights float64
+	add := func(weight, score float64) {
+		if weight > 0 {
+			sum += weight * score
+			weights += weight
+		}
+	}
+
+	high := day.TempMax
+	if day.FeelsMax != nil {
+		high = *day.FeelsMax
+	}
+	add(p.TemperatureWeight, temperatureScore(high, p))
+
+	if precip, ok := precipitationScore(day, p); ok {
+		add(p.PrecipitationWeight, precip)
+	}
+	if day.WindSpeedMax != nil {
+		add(p.WindWeight, falling(*day.WindSpeedMax, p.MaxWind))
+	}
+	if day.UVIndex != nil {
+		add(p.UVWeight, clamp(1-(*day.UVIndex-p.MaxUV)/3))
+	}
+
+	if weights == 0 {
+		return nil
+	}
+	score := math.Round(100*sum/weights) / 10
+	return &score
+}
+
+// Mark sets the Score of every day.
+func Mark(days []forecast.Day, p Profile) {
+	for i := range days {
+		days[i].Score = Score(days[i], p)
+	}
+}
+
+func temperatureScore(t float64, p Profile) float64 {
+	var off float64
+	switch {
+	case t < p.IdealLow:
+		off = p.IdealLow - t
+	case t > p.IdealHigh:
+		off = t - p.IdealHigh
+	}
+	return falling(off, p.Tolerance)
+}
+
+// precipitationScore scores the worse of the chance and the amount of
+// precipitation, whichever the day has.
+func precipitationScore(day forecast.Day, p Profile) (float64, bool) {
+	score, ok := 1.0, false
+	if day.PrecipChance != nil {
+		score, ok = min(score, 1-*day.PrecipChance/100), true
+	}
+	if day.Precipitation != nil {
+		score, ok = min(score, falling(*day.Precipitation, p.MaxPrecip)), true
+	}
+	return clamp(score), ok
+}
+
+// falling scores v from 1 at 0 down to 0 at limit and beyond. Without a
+// limit anything above 0 scores 0.
+func falling(v, limit float64) float64 {
+	if limit <= 0 {
+		if v > 0 {
+			return 0
+		}
+		return 1
+	}
+	return clamp(1 - v/limit)
+}
+
+func clamp(v float64) float64 {
+	return max(0, min(v, 1))
+}
@@ -0,0 +1,80 @@
+// Package airports resolves IATA and ICAO codes from a small embedded list
+// of major airports.
+package airports
+
+import (
+	_ "embed"
+	"encoding/csv"
+	"strconv"
+	"strings"
+	"sync"
+
+	"weather-app/internal/forecast"
+)
+
+//go:embed airports.csv
+var airportsCSV string
+
+type Airport struct {
+	IATA      string
+	ICAO      string
+	Name      string
+	City      string
+	Country   string
+	Latitude  float64
+	Longitude float64
+}
+
+var (
+	loadOnce sync.Once
+	airports []Airport
+)
+
+// load parses the embedded list. It is part of the binary, so a malformed
+// file is a programming error.
+func load() {
+	records, err := csv.NewReader(strings.NewReader(airportsCSV)).ReadAll()
+	if err != nil {
+		panic("airports: " + err.Error())
+	}
+	for _, r := range records[1:] {
+		lat, err1 := strconv.ParseFloat(r[5], 64)
+		lon, err2 := strconv.ParseFloat(r[6], 64)
+		if err1 != nil || err2 != nil {
+			panic("airports: bad record for " + r[0])
+		}
+		airports = append(airports, Airport{
+			IATA:      r[0],
+			ICAO:      r[1],
+			Name:      r[2],
+			City:      r[3],
+			Country:   r[4],
+			Latitude:  lat,
+			Longitude: lon,
+		})
+	}
+}
+
+// Lookup finds an airport by its three-letter IATA or four-letter ICAO
+// code, ignoring case.
+func Lookup(code string) (Airport, bool) {
+	loadOnce.Do(load)
+	code = strings.ToUpper(strings.TrimSpace(code))
+	for _, a := range airports {
+		if a.IATA == code || a.ICAO == code {
+			return a, true
+		}
+	}
+	return Airport{}, false
+}
+
+// Location returns the airport as a forecast location, named after its
+// city and IATA code, e.g. "Amsterdam (AMS)".
+func (a Airport) Location() forecast.Location {
+	return forecast.Location{
+		Name:      a.City + " (" + a.IATA + ")",
+		Country:   a.Country,
+		Latitude:  a.Latitude,
+		Longitude: a.Longitude,
+	}
+}
@@ -0,0 +1,102 @@
+// Package cache stores API responses on disk for a limited time.
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"weather-app/pkg/openmeteo"
+)
+
+const DefaultTTL = 30 * time.Minute
+
+// GeocodingTTL is how long city lookups are kept. Coordinates of a place
+// rarely change, so this is much longer than the forecast TTL.
+const GeocodingTTL = 30 * 24 * time.Hour
+
+type FileCache struct {
+	dir string
+	ttl time.Duration
+}
+
+func New(dir string, ttl time.Duration) *FileCache {
+	return &FileCache{dir: dir, ttl: ttl}
+}
+
+// DefaultDir returns the per-user cache directory, e.g. ~/.cache/weather-app.
+func DefaultDir() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "weather-app"), nil
+}
+
+func (c *FileCache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:])+".json")
+}
+
+// Open returns the cached data for key if it is younger than the TTL.
+func (c *FileCache) Open(key string) (io.ReadCloser, bool) {
+	path := c.path(key)
+	info, err := os.Stat(path)
+	if err != nil || time.Since(info.ModTime()) > c.ttl {
+		return nil, false
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, false
+	}
+	return f, true
+}
+
+// Create starts an entry for key in a temporary file, which Commit renames
+// over the old entry.
+func (c *FileCache) Create(key string) (openmeteo.CacheEntry, error) {
+	if err := os.MkdirAll(c.dir, 0o755); err != nil {
+		return nil, err
+	}
+	tmp, err := os.CreateTemp(c.dir, "tmp-*")
+	if err != nil {
+		return nil, err
+	}
+	return &entry{file: tmp, path: c.path(key)}, nil
+}
+
+// entry is an entry being written. A failed write is kept for Commit to
+// report rather than returned, so that it does not fail the request the
+// response is streamed to.
+type entry struct {
+	file *os.File
+	path string
+	err  error
+}
+
+func (e *entry) Write(p []byte) (int, error) {
+	if e.err == nil {
+		_, e.err = e.file.Write(p)
+	}
+	return len(p), nil
+}
+
+func (e *entry) Commit() error {
+	err := e.file.Close()
+	if e.err != nil {
+		err = e.err
+	}
+	if err != nil {
+		os.Remove(e.file.Name())
+		return err
+	}
+	return os.Rename(e.file.Name(), e.path)
+}
+
+func (e *entry) Abort() {
+	e.file.Close()
+	os.Remove(e.file.Name())
+}
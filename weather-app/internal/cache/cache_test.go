@@ -0,0 +1,112 @@
+package cache
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func write(t *testing.T, c *FileCache, key, data string) {
+	t.Helper()
+	e, err := c.Create(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := io.WriteString(e, data); err != nil {
+		t.Fatal(err)
+	}
+	if err := e.Commit(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func read(c *FileCache, key string) (string, bool) {
+	r, ok := c.Open(key)
+	if !ok {
+		return "", false
+	}
+	defer r.Close()
+	data, err := io.ReadAll(r)
+	return string(data), err == nil
+}
+
+func TestTTL(t *testing.T) {
+	c := New(t.TempDir(), time.Hour)
+	write(t, c, "https://api.example/forecast?a=1", `{"a":1}`)
+
+	tests := []struct {
+		name string
+		age  time.Duration
+		want bool
+	}{
+		{"fresh", 0, true},
+		{"almost expired", 59 * time.Minute, true},
+		{"expired", 61 * time.Minute, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mtime := time.Now().Add(-tt.age)
+			if err := os.Chtimes(c.path("https://api.example/forecast?a=1"), mtime, mtime); err != nil {
+				t.Fatal(err)
+			}
+			data, ok := read(c, "https://api.example/forecast?a=1")
+			if ok != tt.want {
+				t.Fatalf("got hit %v, want %v", ok, tt.want)
+			}
+			if ok && data != `{"a":1}` {
+				t.Errorf("got %q", data)
+			}
+		})
+	}
+}
+
+func TestKeyHashing(t *testing.T) {
+	c := New(t.TempDir(), time.Hour)
+	a, b := c.path("https://api.example/forecast?a=1"), c.path("https://api.example/forecast?a=2")
+	if a == b {
+		t.Errorf("different keys share %s", a)
+	}
+	if a != c.path("https://api.example/forecast?a=1") {
+		t.Error("the same key hashed differently")
+	}
+	// Keys are URLs, which must not end up in file names.
+	if name := filepath.Base(a); len(name) != 64+len(".json") {
+		t.Errorf("got file name %q, want a sha256 hex digest", name)
+	}
+
+	write(t, c, "https://api.example/forecast?a=1", "one")
+	write(t, c, "https://api.example/forecast?a=2", "two")
+	if data, _ := read(c, "https://api.example/forecast?a=1"); data != "one" {
+		t.Errorf("got %q, want one", data)
+	}
+}
+
+func TestAbortKeepsOldEntry(t *testing.T) {
+	dir := t.TempDir()
+	c := New(dir, time.Hour)
+	write(t, c, "k", "old")
+
+	e, err := c.Create("k")
+	if err != nil {
+		t.Fatal(err)
+	}
+	io.WriteString(e, "partial")
+	e.Abort()
+
+	if data, _ := read(c, "k"); data != "old" {
+		t.Errorf("got %q, want the old entry", data)
+	}
+	files, _ := os.ReadDir(dir)
+	if len(files) != 1 {
+		t.Errorf("got %d files, want no temporary file left", len(files))
+	}
+}
+
+func TestMissingEntry(t *testing.T) {
+	c := New(filepath.Join(t.TempDir(), "not-yet"), time.Hour)
+	if _, ok := c.Open("k"); ok {
+		t.Error("got a hit from an empty cache")
+	}
+}
@@ -0,0 +1,170 @@
+// Package chart draws a daily forecast as an image: the highs and lows as
+// lines over bars of the precipitation, written as PNG or SVG.
+package chart
+
+import (
+	"fmt"
+	"image/color"
+	"io"
+	"math"
+	"path/filepath"
+	"strings"
+
+	"weather-app/internal/forecast"
+	"weather-app/internal/units"
+)
+
+// Formats lists the image formats charts are written in.
+var Formats = []string{"png", "svg"}
+
+// FormatOf picks the format of path from its extension.
+func FormatOf(path string) (string, error) {
+	ext := strings.ToLower(strings.TrimPrefix(filepath.Ext(path), "."))
+	for _, f := range Formats {
+		if ext == f {
+			return f, nil
+		}
+	}
+	return "", fmt.Errorf("Cannot tell the image format of %q, expected a file ending in .png or .svg", path)
+}
+
+// Image size and the margins around the plot, in pixels. The margins hold
+// the title, the axis labels and the legend.
+const (
+	width        = 800
+	height       = 400
+	marginLeft   = 60
+	marginRight  = 60
+	marginTop    = 48
+	marginBottom = 40
+)
+
+var (
+	white     = color.RGBA{0xff, 0xff, 0xff, 0xff}
+	ink       = color.RGBA{0x24, 0x29, 0x2f, 0xff}
+	grid      = color.RGBA{0xd0, 0xd7, 0xde, 0xff}
+	highColor = color.RGBA{0xcf, 0x22, 0x2e, 0xff}
+	lowColor  = color.RGBA{0x09, 0x69, 0xda, 0xff}
+	rainColor = color.RGBA{0x54, 0xae, 0xff, 0x80}
+)
+
+type anchor int
+
+const (
+	start anchor = iota
+	middle
+	end
+)
+
+// canvas is what a chart is drawn on. Coordinates are in pixels from the
+// top left; text is placed by its baseline.
+type canvas interface {
+	rect(x, y, w, h float64, c color.RGBA)
+	line(x1, y1, x2, y2, w float64, c color.RGBA)
+	text(x, y float64, s string, a anchor, c color.RGBA)
+}
+
+// Write draws f in format, one of Formats. f must have at least one day.
+func Write(w io.Writer, format string, f forecast.Forecast) error {
+	if len(f.Days) == 0 {
+		return fmt.Errorf("No forecast days to chart")
+	}
+	switch format {
+	case "png":
+		c := newPNG(width, height)
+		plot(c, f)
+		return c.encode(w)
+	case "svg":
+		c := newSVG(width, height)
+		plot(c, f)
+		return c.encode(w)
+	default:
+		return fmt.Errorf("unknown chart format %q", format)
+	}
+}
+
+// tempStep picks a gridline spacing giving at most six lines over the
+// span.
+func tempStep(span float64) float64 {
+	for _, step := range []float64{1, 2, 5, 10, 20, 50} {
+		if span/step <= 6 {
+			return step
+		}
+	}
+	return 100
+}
+
+// plot draws the precipitation bars on the right-hand scale, then the
+// temperature lines on the left-hand one over them.
+func plot(c canvas, f forecast.Forecast) {
+	c.rect(0, 0, width, height, white)
+
+	left, right := float64(marginLeft), float64(width-marginRight)
+	top, bottom := float64(marginTop), float64(height-marginBottom)
+
+	lo, hi := f.Days[0].TempMin, f.Days[0].TempMax
+	maxRain := 0.0
+	for _, day := range f.Days {
+		lo, hi = min(lo, day.TempMin), max(hi, day.TempMax)
+		if day.Precipitation != nil {
+			maxRain = max(maxRain, *day.Precipitation)
+		}
+	}
+	step := tempStep(hi - lo)
+	lo, hi = math.Floor(lo/step)*step, math.Ceil(hi/step)*step
+	if hi == lo {
+		hi = lo + step
+	}
+	rainTop := math.Max(math.Ceil(maxRain), 1)
+
+	slot := (right - left) / float64(len(f.Days))
+	x := func(i int) float64 { return left + slot*(float64(i)+0.5) }
+	y := func(t float64) float64 { return bottom - (t-lo)/(hi-lo)*(bottom-top) }
+
+	title := "Highs, lows and precipitation"
+	if f.Location.Name != "" {
+		title = f.Location.Name + ": highs, lows and precipitation"
+	}
+	c.text(left, 28, title, start, ink)
+	legend := []struct {
+		label string
+		c     color.RGBA
+	}{{"High", highColor}, {"Low", lowColor}, {"Precipitation", rainColor}}
+	lx := right
+	for i := len(legend) - 1; i >= 0; i-- {
+		c.text(lx, 28, legend[i].label, end, ink)
+		lx -= float64(len(legend[i].label))*7 + 6
+		c.rect(lx-12, 19, 12, 10, legend[i].c)
+		lx -= 24
+	}
+
+	degrees := units.Degrees(f.Units.Temperature)
+	for t := lo; t <= hi; t += step {
+		c.line(left, y(t), right, y(t), 1, grid)
+		c.text(left-8, y(t)+4, fmt.Sprintf("%.0f %s", t, degrees), end, ink)
+	}
+	c.text(right+8, top+4, fmt.Sprintf("%.0f %s", rainTop, f.Units.Precipitation), start, ink)
+	c.text(right+8, bottom+4, "0", start, ink)
+
+	for i, day := range f.Days {
+		if day.Precipitation != nil && *day.Precipitation > 0 {
+			h := *day.Precipitation / rainTop * (bottom - top)
+			c.rect(x(i)-slot*0.3, bottom-h, slot*0.6, h, rainColor)
+		}
+		label := day.Date.Format("Mon 2")
+		if len(f.Days) > 10 {
+			label = day.Date.Format("2")
+		}
+		c.text(x(i), bottom+20, label, middle, ink)
+	}
+	c.line(left, bottom, right, bottom, 1, ink)
+
+	for i := range f.Days {
+		if i > 0 {
+			c.line(x(i-1), y(f.Days[i-1].TempMax), x(i), y(f.Days[i].TempMax), 2.5, highColor)
+			c.line(x(i-1), y(f.Days[i-1].TempMin), x(i), y(f.Days[i].TempMin), 2.5, lowColor)
+		}
+		c.rect(x(i)-3, y(f.Days[i].TempMax)-3, 6, 6, highColor)
+		c.rect(x(i)-3, y(f.Days[i].TempMin)-3, 6, 6, lowColor)
+	}
+}
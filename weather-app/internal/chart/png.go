@@ -0,0 +1,71 @@
+package chart
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+	"math"
+	"strings"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+)
+
+type pngCanvas struct {
+	img *image.RGBA
+}
+
+func newPNG(w, h int) *pngCanvas {
+	return &pngCanvas{img: image.NewRGBA(image.Rect(0, 0, w, h))}
+}
+
+func (c *pngCanvas) rect(x, y, w, h float64, col color.RGBA) {
+	r := image.Rect(int(math.Round(x)), int(math.Round(y)), int(math.Round(x+w)), int(math.Round(y+h)))
+	draw.Draw(c.img, r, image.NewUniform(premultiplied(col)), image.Point{}, draw.Over)
+}
+
+// line stamps w-wide squares along the line every half pixel.
+func (c *pngCanvas) line(x1, y1, x2, y2, w float64, col color.RGBA) {
+	steps := int(math.Ceil(math.Hypot(x2-x1, y2-y1) * 2))
+	src := image.NewUniform(premultiplied(col))
+	half := w / 2
+	for i := 0; i <= steps; i++ {
+		t := 0.0
+		if steps > 0 {
+			t = float64(i) / float64(steps)
+		}
+		x, y := x1+(x2-x1)*t, y1+(y2-y1)*t
+		r := image.Rect(int(math.Round(x-half)), int(math.Round(y-half)), int(math.Round(x+half)), int(math.Round(y+half)))
+		draw.Draw(c.img, r, src, image.Point{}, draw.Src)
+	}
+}
+
+// text writes s in the built-in 7x13 font, which only has ASCII: the degree
+// sign is dropped, so "°C" reads "C".
+func (c *pngCanvas) text(x, y float64, s string, a anchor, col color.RGBA) {
+	s = strings.ReplaceAll(s, "°", "")
+	d := &font.Drawer{Dst: c.img, Src: image.NewUniform(col), Face: basicfont.Face7x13}
+	width := d.MeasureString(s).Round()
+	switch a {
+	case middle:
+		x -= float64(width) / 2
+	case end:
+		x -= float64(width)
+	}
+	d.Dot = fixed.P(int(math.Round(x)), int(math.Round(y)))
+	d.DrawString(s)
+}
+
+func (c *pngCanvas) encode(w io.Writer) error {
+	return png.Encode(w, c.img)
+}
+
+// premultiplied converts a straight-alpha color to the premultiplied form
+// image/color expects.
+func premultiplied(c color.RGBA) color.RGBA {
+	a := uint32(c.A)
+	return color.RGBA{uint8(uint32(c.R) * a / 0xff), uint8(uint32(c.G) * a / 0xff), uint8(uint32(c.B) * a / 0xff), c.A}
+}
@@ -0,0 +1,54 @@
+package chart
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"image/color"
+	"io"
+	"math"
+)
+
+type svgCanvas struct {
+	buf bytes.Buffer
+}
+
+func newSVG(w, h int) *svgCanvas {
+	c := &svgCanvas{}
+	fmt.Fprintf(&c.buf, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="Helvetica, Arial, sans-serif" font-size="12">`+"\n", w, h, w, h)
+	return c
+}
+
+// paint is the SVG fill or stroke of c with its opacity.
+func paint(attr string, c color.RGBA) string {
+	s := fmt.Sprintf(`%s="#%02x%02x%02x"`, attr, c.R, c.G, c.B)
+	if c.A != 0xff {
+		s += fmt.Sprintf(` %s-opacity="%.2f"`, attr, float64(c.A)/0xff)
+	}
+	return s
+}
+
+func (c *svgCanvas) rect(x, y, w, h float64, col color.RGBA) {
+	fmt.Fprintf(&c.buf, `<rect x="%g" y="%g" width="%g" height="%g" %s/>`+"\n", round(x), round(y), round(w), round(h), paint("fill", col))
+}
+
+func (c *svgCanvas) line(x1, y1, x2, y2, w float64, col color.RGBA) {
+	fmt.Fprintf(&c.buf, `<line x1="%g" y1="%g" x2="%g" y2="%g" stroke-width="%g" stroke-linecap="round" %s/>`+"\n", round(x1), round(y1), round(x2), round(y2), w, paint("stroke", col))
+}
+
+var textAnchors = map[anchor]string{start: "start", middle: "middle", end: "end"}
+
+func (c *svgCanvas) text(x, y float64, s string, a anchor, col color.RGBA) {
+	fmt.Fprintf(&c.buf, `<text x="%g" y="%g" text-anchor="%s" %s>%s</text>`+"\n", round(x), round(y), textAnchors[a], paint("fill", col), html.EscapeString(s))
+}
+
+func (c *svgCanvas) encode(w io.Writer) error {
+	c.buf.WriteString("</svg>\n")
+	_, err := c.buf.WriteTo(w)
+	return err
+}
+
+// round keeps coordinates to a tenth of a pixel.
+func round(v float64) float64 {
+	return math.Round(v*10) / 10
+}
@@ -0,0 +1,70 @@
+// Package clothing recommends what to wear on forecast days.
+package clothing
+
+import "weather-app/internal/forecast"
+
+// Rule recommends Item on days that meet all of its set conditions.
+// Temperatures are the feels like high in °C, or the high when that is
+// unknown; wind is the highest speed in km/h. Bounds below are exclusive,
+// bounds above inclusive, so rules can share a boundary.
+type Rule struct {
+	Item              string
+	FeelsBelow        *float64
+	FeelsAbove        *float64
+	PrecipChanceAbove *float64
+	WindAbove         *float64
+	UVAbove           *float64
+}
+
+// ptr returns a pointer to a bound of the built-in rules.
+func ptr(f float64) *float64 { return &f }
+
+// Rules are the built-in rules, warmest clothes first.
+var Rules = []Rule{
+	{Item: "winter coat", FeelsBelow: ptr(5)},
+	{Item: "hat and gloves", FeelsBelow: ptr(0)},
+	{Item: "warm jacket", FeelsAbove: ptr(5), FeelsBelow: ptr(12)},
+	{Item: "light jacket", FeelsAbove: ptr(12), FeelsBelow: ptr(18)},
+	{Item: "t-shirt", FeelsAbove: ptr(18)},
+	{Item: "shorts", FeelsAbove: ptr(24)},
+	{Item: "umbrella", PrecipChanceAbove: ptr(50)},
+	{Item: "windbreaker", WindAbove: ptr(35)},
+	{Item: "sunglasses", UVAbove: ptr(3)},
+	{Item: "sunscreen", UVAbove: ptr(6)},
+}
+
+// Recommend returns the items of the rules day meets, in the order of
+// rules. A rule with a condition the day has no value for is skipped.
+func Recommend(day forecast.Day, rules []Rule) []string {
+	feels := day.TempMax
+	if day.FeelsMax != nil {
+		feels = *day.FeelsMax
+	}
+	var items []string
+	for _, r := range rules {
+		if below(&feels, r.FeelsBelow) && above(&feels, r.FeelsAbove) &&
+			above(day.PrecipChance, r.PrecipChanceAbove) &&
+			above(day.WindSpeedMax, r.WindAbove) &&
+			above(day.UVIndex, r.UVAbove) {
+			items = append(items, r.Item)
+		}
+	}
+	return items
+}
+
+// Mark sets what to wear on every day.
+func Mark(days []forecast.Day, rules []Rule) {
+	for i := range days {
+		days[i].Wear = Recommend(days[i], rules)
+	}
+}
+
+// above reports whether value is at least bound, or there is no bound.
+func above(value, bound *float64) bool {
+	return bound == nil || (value != nil && *value >= *bound)
+}
+
+// below reports whether value is under bound, or there is no bound.
+func below(value, bound *float64) bool {
+	return bound == nil || (value != nil && *value < *bound)
+}
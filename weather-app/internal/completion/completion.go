@@ -0,0 +1,263 @@
+// Package completion writes bash, zsh and fish completion scripts from a
+// description of a program's commands and flags.
+package completion
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// Flag is a command-line flag. Completing its value offers Values, or the
+// output of the program's dynamic names command when Dynamic is set.
+type Flag struct {
+	Name    string
+	Usage   string
+	Values  []string
+	Dynamic bool
+}
+
+// Command is a subcommand with its flags. The command with an empty Name is
+// the one run without a subcommand. Subcommands are completed as the first
+// argument; those in DynamicArgs take a dynamic name as second argument.
+type Command struct {
+	Name        string
+	Usage       string
+	Flags       []Flag
+	Subcommands []string
+	DynamicArgs []string
+}
+
+// Spec describes a program. DynamicCommand is run, as arguments to Program,
+// to list the names offered for dynamic values, one per line.
+type Spec struct {
+	Program        string
+	Commands       []Command
+	DynamicCommand string
+}
+
+// Shells lists the supported shells.
+var Shells = []string{"bash", "zsh", "fish"}
+
+// Write writes the completion script for shell.
+func Write(w io.Writer, shell string, spec Spec) error {
+	switch shell {
+	case "bash":
+		return bash(w, spec)
+	case "zsh":
+		return zsh(w, spec)
+	case "fish":
+		return fish(w, spec)
+	}
+	return fmt.Errorf("Unknown shell %q, expected one of %s", shell, strings.Join(Shells, ", "))
+}
+
+// identifier turns the program name into a shell function name.
+func identifier(program string) string {
+	return "_" + strings.NewReplacer("-", "_", ".", "_").Replace(program)
+}
+
+func flagNames(c Command) string {
+	names := make([]string, len(c.Flags))
+	for i, f := range c.Flags {
+		names[i] = "-" + f.Name
+	}
+	return strings.Join(names, " ")
+}
+
+func commandNames(spec Spec) []string {
+	var names []string
+	for _, c := range spec.Commands {
+		if c.Name != "" {
+			names = append(names, c.Name)
+		}
+	}
+	return names
+}
+
+// valueFlags returns every flag with completable values, by name. Flags
+// shared between commands are expected to take the same values.
+func valueFlags(spec Spec) []Flag {
+	seen := map[string]Flag{}
+	for _, c := range spec.Commands {
+		for _, f := range c.Flags {
+			if len(f.Values) > 0 || f.Dynamic {
+				seen[f.Name] = f
+			}
+		}
+	}
+	flags := make([]Flag, 0, len(seen))
+	for _, f := range seen {
+		flags = append(flags, f)
+	}
+	sort.Slice(flags, func(i, j int) bool { return flags[i].Name < flags[j].Name })
+	return flags
+}
+
+func dynamic(spec Spec) string {
+	return spec.Program + " " + spec.DynamicCommand + " 2>/dev/null"
+}
+
+func bash(w io.Writer, spec Spec) error {
+	fn := identifier(spec.Program)
+	var b strings.Builder
+	fmt.Fprintf(&b, "# bash completion for %s\n", spec.Program)
+	fmt.Fprintf(&b, "# Load with: source <(%s completion bash)\n\n", spec.Program)
+	fmt.Fprintf(&b, "%s() {\n", fn)
+	b.WriteString("    local cur=\"${COMP_WORDS[COMP_CWORD]}\" prev=\"${COMP_WORDS[COMP_CWORD-1]}\"\n")
+	b.WriteString("    local cmd=\"${COMP_WORDS[1]}\" words=\"\"\n\n")
+
+	b.WriteString("    case \"$prev\" in\n")
+	for _, f := range valueFlags(spec) {
+		if f.Dynamic {
+			fmt.Fprintf(&b, "    -%s|--%[1]s) words=\"$(%s)\" ;;\n", f.Name, dynamic(spec))
+		} else {
+			fmt.Fprintf(&b, "    -%s|--%[1]s) words=%q ;;\n", f.Name, strings.Join(f.Values, " "))
+		}
+	}
+	b.WriteString("    esac\n")
+	b.WriteString("    if [[ -n \"$words\" ]]; then\n")
+	b.WriteString("        COMPREPLY=($(compgen -W \"$words\" -- \"$cur\"))\n")
+	b.WriteString("        return\n")
+	b.WriteString("    fi\n\n")
+
+	b.WriteString("    case \"$cmd\" in\n")
+	for _, c := range spec.Commands {
+		if c.Name == "" {
+			continue
+		}
+		fmt.Fprintf(&b, "    %s)\n", c.Name)
+		if len(c.Subcommands) > 0 {
+			fmt.Fprintf(&b, "        if [[ $COMP_CWORD -eq 2 ]]; then\n")
+			fmt.Fprintf(&b, "            words=%q\n", strings.Join(c.Subcommands, " "))
+			if len(c.DynamicArgs) > 0 {
+				fmt.Fprintf(&b, "        elif [[ $COMP_CWORD -eq 3 && \" %s \" == *\" ${COMP_WORDS[2]} \"* ]]; then\n", strings.Join(c.DynamicArgs, " "))
+				fmt.Fprintf(&b, "            words=\"$(%s)\"\n", dynamic(spec))
+			}
+			fmt.Fprintf(&b, "        else\n")
+			fmt.Fprintf(&b, "            words=%q\n", flagNames(c))
+			fmt.Fprintf(&b, "        fi\n")
+		} else {
+			fmt.Fprintf(&b, "        words=%q\n", flagNames(c))
+		}
+		b.WriteString("        ;;\n")
+	}
+	for _, c := range spec.Commands {
+		if c.Name != "" {
+			continue
+		}
+		b.WriteString("    *)\n")
+		fmt.Fprintf(&b, "        words=%q\n", flagNames(c))
+		fmt.Fprintf(&b, "        [[ $COMP_CWORD -eq 1 ]] && words=\"$words %s\"\n", strings.Join(commandNames(spec), " "))
+		b.WriteString("        ;;\n")
+	}
+	b.WriteString("    esac\n")
+	b.WriteString("    COMPREPLY=($(compgen -W \"$words\" -- \"$cur\"))\n")
+	b.WriteString("}\n\n")
+	fmt.Fprintf(&b, "complete -F %s %s\n", fn, spec.Program)
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func zsh(w io.Writer, spec Spec) error {
+	fn := identifier(spec.Program)
+	var b strings.Builder
+	fmt.Fprintf(&b, "#compdef %s\n", spec.Program)
+	fmt.Fprintf(&b, "# zsh completion for %s\n", spec.Program)
+	fmt.Fprintf(&b, "# Load with: source <(%s completion zsh)\n\n", spec.Program)
+	fmt.Fprintf(&b, "%s() {\n", fn)
+	b.WriteString("    local cmd=${words[2]}\n")
+	b.WriteString("    local -a candidates\n\n")
+
+	b.WriteString("    case ${words[CURRENT-1]} in\n")
+	for _, f := range valueFlags(spec) {
+		if f.Dynamic {
+			fmt.Fprintf(&b, "    -%s|--%[1]s) compadd -- ${(f)\"$(%s)\"}; return ;;\n", f.Name, dynamic(spec))
+		} else {
+			fmt.Fprintf(&b, "    -%s|--%[1]s) compadd -- %s; return ;;\n", f.Name, strings.Join(f.Values, " "))
+		}
+	}
+	b.WriteString("    esac\n\n")
+
+	b.WriteString("    case $cmd in\n")
+	for _, c := range spec.Commands {
+		if c.Name == "" {
+			continue
+		}
+		fmt.Fprintf(&b, "    %s)\n", c.Name)
+		if len(c.Subcommands) > 0 {
+			fmt.Fprintf(&b, "        if (( CURRENT == 3 )); then\n")
+			fmt.Fprintf(&b, "            compadd -- %s; return\n", strings.Join(c.Subcommands, " "))
+			if len(c.DynamicArgs) > 0 {
+				fmt.Fprintf(&b, "        elif (( CURRENT == 4 )) && [[ ${words[3]} == (%s) ]]; then\n", strings.Join(c.DynamicArgs, "|"))
+				fmt.Fprintf(&b, "            compadd -- ${(f)\"$(%s)\"}; return\n", dynamic(spec))
+			}
+			fmt.Fprintf(&b, "        fi\n")
+		}
+		fmt.Fprintf(&b, "        candidates=(%s)\n", flagNames(c))
+		b.WriteString("        ;;\n")
+	}
+	for _, c := range spec.Commands {
+		if c.Name != "" {
+			continue
+		}
+		b.WriteString("    *)\n")
+		fmt.Fprintf(&b, "        candidates=(%s)\n", flagNames(c))
+		fmt.Fprintf(&b, "        (( CURRENT == 2 )) && candidates+=(%s)\n", strings.Join(commandNames(spec), " "))
+		b.WriteString("        ;;\n")
+	}
+	b.WriteString("    esac\n")
+	b.WriteString("    compadd -- $candidates\n")
+	b.WriteString("}\n\n")
+	fmt.Fprintf(&b, "compdef %s %s\n", fn, spec.Program)
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// fishDescription shortens a flag usage to a fish description.
+func fishDescription(usage string) string {
+	usage = strings.TrimSuffix(usage, " - Optional")
+	usage = strings.TrimSuffix(usage, " - *Mandatory")
+	return strings.ReplaceAll(usage, "'", `\'`)
+}
+
+func fish(w io.Writer, spec Spec) error {
+	p := spec.Program
+	var b strings.Builder
+	fmt.Fprintf(&b, "# fish completion for %s\n", p)
+	fmt.Fprintf(&b, "# Load with: %s completion fish | source\n\n", p)
+	fmt.Fprintf(&b, "complete -c %s -f\n", p)
+
+	names := strings.Join(commandNames(spec), " ")
+	for _, c := range spec.Commands {
+		if c.Name != "" {
+			fmt.Fprintf(&b, "complete -c %s -n '__fish_use_subcommand' -a %s -d '%s'\n", p, c.Name, fishDescription(c.Usage))
+		}
+	}
+	for _, c := range spec.Commands {
+		cond := fmt.Sprintf("__fish_seen_subcommand_from %s", c.Name)
+		if c.Name == "" {
+			cond = fmt.Sprintf("not __fish_seen_subcommand_from %s", names)
+		}
+		b.WriteString("\n")
+		for _, sub := range c.Subcommands {
+			fmt.Fprintf(&b, "complete -c %s -n '%s; and not __fish_seen_subcommand_from %s' -a %s\n", p, cond, strings.Join(c.Subcommands, " "), sub)
+		}
+		for _, sub := range c.DynamicArgs {
+			fmt.Fprintf(&b, "complete -c %s -n '%s; and __fish_seen_subcommand_from %s' -a '(%s)'\n", p, cond, sub, dynamic(spec))
+		}
+		for _, f := range c.Flags {
+			line := fmt.Sprintf("complete -c %s -n '%s' -o %s -d '%s'", p, cond, f.Name, fishDescription(f.Usage))
+			switch {
+			case f.Dynamic:
+				line += fmt.Sprintf(" -x -a '(%s)'", dynamic(spec))
+			case len(f.Values) > 0:
+				line += fmt.Sprintf(" -x -a '%s'", strings.Join(f.Values, " "))
+			}
+			b.WriteString(line + "\n")
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
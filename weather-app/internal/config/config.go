@@ -0,0 +1,136 @@
+// Package config loads user defaults from a TOML file.
+package config
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/BurntSushi/toml"
+)
+
+// Config holds defaults for command-line flags. Zero values mean "not set".
+type Config struct {
+	City    string `toml:"city"`
+	Country string `toml:"country"`
+	Units   string `toml:"units"`
+	Output  string `toml:"output"`
+	Icons   string `toml:"icons"`
+
+	Precipitation bool `toml:"precipitation"`
+	UVIndex       bool `toml:"uv"`
+	UVAdvice      bool `toml:"uv_advice"`
+	Sunrise       bool `toml:"sunrise"`
+	Sunset        bool `toml:"sunset"`
+	Daylight      bool `toml:"daylight"`
+	Feels         bool `toml:"feels"`
+	Snow          bool `toml:"snow"`
+	Humidity      bool `toml:"humidity"`
+	Pressure      bool `toml:"pressure"`
+	Clouds        bool `toml:"clouds"`
+	Ensemble      bool `toml:"ensemble"`
+	Wind          bool `toml:"wind"`
+
+	WindUnit string `toml:"wind_unit"`
+	Days     int    `toml:"days"`
+	Timezone string `toml:"tz"`
+	Model    string `toml:"model"`
+	Lang     string `toml:"lang"`
+	Locale   string `toml:"locale"`
+
+	BriefFormat string `toml:"brief_format"`
+
+	APIKey   string `toml:"api_key"`
+	Proxy    string `toml:"proxy"`
+	CACert   string `toml:"ca_cert"`
+	Insecure bool   `toml:"insecure"`
+
+	// Thresholds are pointers, as 0 is a meaningful temperature.
+	WarnBelow *float64 `toml:"warn_below"`
+	WarnAbove *float64 `toml:"warn_above"`
+
+	SMTP     SMTP     `toml:"smtp"`
+	Telegram Telegram `toml:"telegram"`
+
+	// Score holds the [score.NAME] tables, which change or add the
+	// activities of -score.
+	Score map[string]ScoreProfile `toml:"score"`
+
+	// Wear holds the [[wear]] tables, which replace the built-in rules of
+	// -what-to-wear.
+	Wear []WearRule `toml:"wear"`
+}
+
+// WearRule is a [[wear]] table: wear item on days that meet every
+// condition set.
+type WearRule struct {
+	Item              string   `toml:"item"`
+	FeelsBelow        *float64 `toml:"feels_below"`
+	FeelsAbove        *float64 `toml:"feels_above"`
+	PrecipChanceAbove *float64 `toml:"precip_chance_above"`
+	WindAbove         *float64 `toml:"wind_above"`
+	UVAbove           *float64 `toml:"uv_above"`
+}
+
+// ScoreProfile is a [score.NAME] table. Unset values keep those of the
+// built-in activity of that name.
+type ScoreProfile struct {
+	IdealLow            *float64 `toml:"ideal_low"`
+	IdealHigh           *float64 `toml:"ideal_high"`
+	Tolerance           *float64 `toml:"tolerance"`
+	MaxWind             *float64 `toml:"max_wind"`
+	MaxPrecip           *float64 `toml:"max_precip"`
+	MaxUV               *float64 `toml:"max_uv"`
+	TemperatureWeight   *float64 `toml:"temperature_weight"`
+	PrecipitationWeight *float64 `toml:"precipitation_weight"`
+	WindWeight          *float64 `toml:"wind_weight"`
+	UVWeight            *float64 `toml:"uv_weight"`
+}
+
+// SMTP is the [smtp] table: the mail server -email sends through.
+type SMTP struct {
+	Host     string `toml:"host"`
+	Port     int    `toml:"port"`
+	Username string `toml:"username"`
+	Password string `toml:"password"`
+	From     string `toml:"from"`
+}
+
+// Telegram is the [telegram] table for bot telegram.
+type Telegram struct {
+	Token string `toml:"token"`
+}
+
+// DefaultPath returns ~/.config/weather-app/config.toml or the platform
+// equivalent.
+func DefaultPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "weather-app", "config.toml"), nil
+}
+
+// Load reads the config file at path.
+func Load(path string) (Config, error) {
+	var cfg Config
+	if _, err := toml.DecodeFile(path, &cfg); err != nil {
+		return Config{}, err
+	}
+	return cfg, nil
+}
+
+// LoadDefault reads the config file at DefaultPath. A missing file yields an
+// empty Config.
+func LoadDefault() (Config, error) {
+	path, err := DefaultPath()
+	if err != nil {
+		return Config{}, nil
+	}
+	cfg, err := Load(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return Config{}, nil
+	}
+	return cfg, err
+}
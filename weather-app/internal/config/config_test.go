@@ -0,0 +1,114 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.toml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoad(t *testing.T) {
+	cfg, err := Load(writeConfig(t, `
+city = "The Hague"
+country = "Netherlands"
+units = "imperial"
+uv = true
+days = 10
+warn_below = 0
+ca_cert = "/etc/ssl/corp-root.pem"
+
+[smtp]
+host = "smtp.example.com"
+port = 587
+
+[score.run]
+ideal_high = 15
+uv_weight = 0
+
+[[wear]]
+item = "rain coat"
+precip_chance_above = 40
+
+[[wear]]
+item = "fleece"
+feels_below = 14
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.City != "The Hague" || cfg.Country != "Netherlands" || cfg.Units != "imperial" || !cfg.UVIndex || cfg.Days != 10 {
+		t.Errorf("Load() = %+v", cfg)
+	}
+	if cfg.CACert != "/etc/ssl/corp-root.pem" || cfg.SMTP.Host != "smtp.example.com" || cfg.SMTP.Port != 587 {
+		t.Errorf("Load() = %+v", cfg)
+	}
+	// A threshold of 0 is set, one left out is not.
+	if cfg.WarnBelow == nil || *cfg.WarnBelow != 0 || cfg.WarnAbove != nil {
+		t.Errorf("thresholds = %v, %v, want 0 and unset", cfg.WarnBelow, cfg.WarnAbove)
+	}
+	run, ok := cfg.Score["run"]
+	if !ok || run.IdealHigh == nil || *run.IdealHigh != 15 || run.UVWeight == nil || *run.UVWeight != 0 || run.IdealLow != nil {
+		t.Errorf("[score.run] = %+v", run)
+	}
+	if len(cfg.Wear) != 2 || cfg.Wear[0].Item != "rain coat" || *cfg.Wear[0].PrecipChanceAbove != 40 || cfg.Wear[0].FeelsBelow != nil || *cfg.Wear[1].FeelsBelow != 14 {
+		t.Errorf("[[wear]] = %+v", cfg.Wear)
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+	}{
+		{"missing", filepath.Join(t.TempDir(), "missing.toml")},
+		{"not TOML", writeConfig(t, "city = The Hague")},
+		{"wrong type", writeConfig(t, `days = "seven"`)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if cfg, err := Load(tt.path); err == nil {
+				t.Errorf("Load() = %+v, want an error", cfg)
+			}
+		})
+	}
+}
+
+func TestLoadDefault(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	t.Setenv("HOME", dir)
+	path, err := DefaultPath()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := LoadDefault()
+	if err != nil || cfg.City != "" {
+		t.Errorf("LoadDefault() without a file = %+v, %v, want an empty config", cfg, err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(`city = "Paris"`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if cfg, err := LoadDefault(); err != nil || cfg.City != "Paris" {
+		t.Errorf("LoadDefault() = %+v, %v, want Paris", cfg, err)
+	}
+
+	if err := os.WriteFile(path, []byte("city ="), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadDefault(); err == nil {
+		t.Error("LoadDefault() ignored an invalid file")
+	}
+}
@@ -0,0 +1,152 @@
+// Package email sends forecast reports by SMTP, as a plain-text mail with
+// an HTML alternative.
+package email
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultTimeout bounds connecting to the mail server and sending.
+const DefaultTimeout = 30 * time.Second
+
+// Server is the SMTP server mail is sent through. Port 465 is spoken over
+// TLS from the start; other ports upgrade with STARTTLS when the server
+// offers it. Port defaults to 587, the submission port.
+type Server struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	// From is the sender, e.g. "Weather <weather@example.com>".
+	From string
+}
+
+// Message is a mail to To with a plain-text body and, when HTML is set, an
+// HTML version mail clients show instead.
+type Message struct {
+	To      []*mail.Address
+	Subject string
+	Text    string
+	HTML    string
+}
+
+// Send delivers m through s.
+func Send(ctx context.Context, s Server, m Message) error {
+	from, err := mail.ParseAddress(s.From)
+	if err != nil {
+		return fmt.Errorf("Invalid sender %q: %w", s.From, err)
+	}
+	if s.Port == 0 {
+		s.Port = 587
+	}
+	addr := net.JoinHostPort(s.Host, strconv.Itoa(s.Port))
+
+	ctx, cancel := context.WithTimeout(ctx, DefaultTimeout)
+	defer cancel()
+	var conn net.Conn
+	if s.Port == 465 {
+		d := &tls.Dialer{Config: &tls.Config{ServerName: s.Host}}
+		conn, err = d.DialContext(ctx, "tcp", addr)
+	} else {
+		var d net.Dialer
+		conn, err = d.DialContext(ctx, "tcp", addr)
+	}
+	if err != nil {
+		return fmt.Errorf("Could not reach mail server %s: %w", addr, err)
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	c, err := smtp.NewClient(conn, s.Host)
+	if err != nil {
+		return fmt.Errorf("Mail server %s: %w", addr, err)
+	}
+	defer c.Close()
+	if ok, _ := c.Extension("STARTTLS"); ok && s.Port != 465 {
+		if err := c.StartTLS(&tls.Config{ServerName: s.Host}); err != nil {
+			return fmt.Errorf("Mail server %s: %w", addr, err)
+		}
+	}
+	if s.Username != "" {
+		if err := c.Auth(smtp.PlainAuth("", s.Username, s.Password, s.Host)); err != nil {
+			return fmt.Errorf("Mail server %s refused the login: %w", addr, err)
+		}
+	}
+	if err := c.Mail(from.Address); err != nil {
+		return fmt.Errorf("Mail server %s refused sender %s: %w", addr, from.Address, err)
+	}
+	for _, to := range m.To {
+		if err := c.Rcpt(to.Address); err != nil {
+			return fmt.Errorf("Mail server %s refused recipient %s: %w", addr, to.Address, err)
+		}
+	}
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(m.bytes(from, time.Now())); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return c.Quit()
+}
+
+// bytes formats m as a MIME message from from, sent at date.
+func (m Message) bytes(from *mail.Address, date time.Time) []byte {
+	to := make([]string, len(m.To))
+	for i, a := range m.To {
+		to[i] = a.String()
+	}
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "From: %s\r\n", from)
+	fmt.Fprintf(&b, "To: %s\r\n", strings.Join(to, ", "))
+	fmt.Fprintf(&b, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", m.Subject))
+	fmt.Fprintf(&b, "Date: %s\r\n", date.Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+
+	if m.HTML == "" {
+		b.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+		b.WriteString("Content-Transfer-Encoding: quoted-printable\r\n\r\n")
+		writeQuoted(&b, m.Text)
+		return b.Bytes()
+	}
+	mw := multipart.NewWriter(&b)
+	fmt.Fprintf(&b, "Content-Type: multipart/alternative; boundary=%s\r\n\r\n", mw.Boundary())
+	// Clients show the last alternative they understand, so HTML goes last.
+	for _, part := range []struct{ contentType, body string }{
+		{"text/plain; charset=utf-8", m.Text},
+		{"text/html; charset=utf-8", m.HTML},
+	} {
+		pw, _ := mw.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		writeQuoted(pw, part.body)
+	}
+	mw.Close()
+	return b.Bytes()
+}
+
+func writeQuoted(w io.Writer, s string) {
+	qw := quotedprintable.NewWriter(w)
+	qw.Write([]byte(s))
+	qw.Close()
+}
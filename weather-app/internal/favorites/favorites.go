@@ -0,0 +1,110 @@
+// Package favorites persists named locations with their resolved
+// coordinates.
+package favorites
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"weather-app/internal/forecast"
+)
+
+type Store struct {
+	path      string
+	Default   string                       `json:"default,omitempty"`
+	Locations map[string]forecast.Location `json:"locations"`
+}
+
+// DefaultPath returns ~/.config/weather-app/favorites.json or the platform
+// equivalent.
+func DefaultPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "weather-app", "favorites.json"), nil
+}
+
+// Load reads the store at path. A missing file yields an empty store.
+func Load(path string) (*Store, error) {
+	s := &Store{path: path, Locations: map[string]forecast.Location{}}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+	if s.Locations == nil {
+		s.Locations = map[string]forecast.Location{}
+	}
+	return s, nil
+}
+
+func LoadDefault() (*Store, error) {
+	path, err := DefaultPath()
+	if err != nil {
+		return nil, err
+	}
+	return Load(path)
+}
+
+func (s *Store) Save() error {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(s.path, append(data, '\n'), 0o644)
+}
+
+func (s *Store) Add(name string, loc forecast.Location) {
+	s.Locations[name] = loc
+}
+
+func (s *Store) Remove(name string) error {
+	if _, ok := s.Locations[name]; !ok {
+		return fmt.Errorf("No favorite named %q", name)
+	}
+	delete(s.Locations, name)
+	if s.Default == name {
+		s.Default = ""
+	}
+	return nil
+}
+
+func (s *Store) Get(name string) (forecast.Location, error) {
+	loc, ok := s.Locations[name]
+	if !ok {
+		return forecast.Location{}, fmt.Errorf("No favorite named %q", name)
+	}
+	return loc, nil
+}
+
+func (s *Store) SetDefault(name string) error {
+	if _, ok := s.Locations[name]; !ok {
+		return fmt.Errorf("No favorite named %q", name)
+	}
+	s.Default = name
+	return nil
+}
+
+// Names returns the favorite names in alphabetical order.
+func (s *Store) Names() []string {
+	names := make([]string, 0, len(s.Locations))
+	for name := range s.Locations {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,127 @@
+package favorites
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"weather-app/internal/forecast"
+)
+
+var (
+	home = forecast.Location{Name: "The Hague", Country: "Netherlands", Latitude: 52.07667, Longitude: 4.29861, Timezone: "Europe/Amsterdam"}
+	work = forecast.Location{Name: "Utrecht", Country: "Netherlands", Latitude: 52.09083, Longitude: 5.12222}
+)
+
+func TestLoadMissing(t *testing.T) {
+	s, err := Load(filepath.Join(t.TempDir(), "favorites.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Default != "" || len(s.Names()) != 0 {
+		t.Errorf("Load() of a missing file = %+v, want an empty store", s)
+	}
+	// Adding to it must not need a nil check.
+	s.Add("home", home)
+}
+
+func TestSaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "weather-app", "favorites.json")
+	s, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.Add("work", work)
+	s.Add("home", home)
+	if err := s.SetDefault("home"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Default != "home" || !slices.Equal(got.Names(), []string{"home", "work"}) {
+		t.Errorf("reloaded store = %+v", got)
+	}
+	if loc, err := got.Get("home"); err != nil || loc.Name != home.Name || loc.Latitude != home.Latitude || loc.Timezone != home.Timezone {
+		t.Errorf("Get(home) = %+v, %v, want %+v", loc, err, home)
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr bool
+	}{
+		{"not JSON", "home: The Hague", true},
+		{"wrong type", `{"locations": []}`, true},
+		{"no locations", `{"default": ""}`, false},
+		{"null locations", `{"locations": null}`, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "favorites.json")
+			if err := os.WriteFile(path, []byte(tt.content), 0o600); err != nil {
+				t.Fatal(err)
+			}
+			s, err := Load(path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Load() error = %v, wantErr %t", err, tt.wantErr)
+			}
+			if err == nil && s.Locations == nil {
+				t.Error("Load() left Locations nil")
+			}
+		})
+	}
+}
+
+func TestRemove(t *testing.T) {
+	tests := []struct {
+		name        string
+		remove      string
+		wantErr     bool
+		wantDefault string
+		wantNames   []string
+	}{
+		{"the default", "home", false, "", []string{"work"}},
+		{"another", "work", false, "home", []string{"home"}},
+		{"unknown", "gym", true, "home", []string{"home", "work"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := Load(filepath.Join(t.TempDir(), "favorites.json"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			s.Add("home", home)
+			s.Add("work", work)
+			s.Default = "home"
+			if err := s.Remove(tt.remove); (err != nil) != tt.wantErr {
+				t.Fatalf("Remove(%q) error = %v, wantErr %t", tt.remove, err, tt.wantErr)
+			}
+			if s.Default != tt.wantDefault || !slices.Equal(s.Names(), tt.wantNames) {
+				t.Errorf("after Remove(%q): default %q, names %q, want %q, %q", tt.remove, s.Default, s.Names(), tt.wantDefault, tt.wantNames)
+			}
+		})
+	}
+}
+
+func TestUnknownName(t *testing.T) {
+	s, err := Load(filepath.Join(t.TempDir(), "favorites.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.Add("home", home)
+	if _, err := s.Get("gym"); err == nil {
+		t.Error("Get() of an unknown name succeeded")
+	}
+	if err := s.SetDefault("gym"); err == nil || s.Default != "" {
+		t.Errorf("SetDefault() of an unknown name = %v, default %q", err, s.Default)
+	}
+}
@@ -0,0 +1,568 @@
+package forecast
+
+import (
+	"encoding/json"
+	"math"
+	"time"
+)
+
+const dateLayout = "2006-01-02"
+
+type Forecast struct {
+	Location Location    `json:"location"`
+	Units    Units       `json:"units"`
+	Model    string      `json:"model,omitempty"`
+	Activity string      `json:"activity,omitempty"` // what the days' Score is for
+	Current  *Current    `json:"current,omitempty"`
+	Days     []Day       `json:"days,omitempty"`
+	Hours    []Hour      `json:"hours,omitempty"`
+	Alerts   []Alert     `json:"alerts,omitempty"`
+	Sea      []SeaDay    `json:"sea,omitempty"`
+	Pollen   []PollenDay `json:"pollen,omitempty"`
+	Agri     []AgriDay   `json:"agri,omitempty"`
+	// ChangedSince is when the earlier forecast the days' Changes are
+	// from was fetched.
+	ChangedSince *time.Time `json:"changed_since,omitempty"`
+	Summary      *Summary   `json:"summary,omitempty"`
+	// Cached is set when the provider served the forecast from its cache
+	// instead of fetching it.
+	Cached bool `json:"-"`
+}
+
+type Location struct {
+	Name      string  `json:"name,omitempty"`
+	Country   string  `json:"country,omitempty"`
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+	Timezone  string  `json:"timezone,omitempty"`
+	// Elevation is the height in metres the forecast is for: the grid
+	// cell's, or the one asked for with -elevation.
+	Elevation *float64 `json:"elevation,omitempty"`
+}
+
+type Units struct {
+	Temperature   string `json:"temperature"`
+	Precipitation string `json:"precipitation"`
+	WindSpeed     string `json:"wind_speed"`
+	Snow          string `json:"snow,omitempty"`
+}
+
+// Day holds the values for a single forecast day. Optional values are nil
+// when they were not requested or the API did not return them.
+type Day struct {
+	Date          Date       `json:"date"`
+	TempMax       float64    `json:"temp_max"`
+	TempMin       float64    `json:"temp_min"`
+	TempMaxP10    *float64   `json:"temp_max_p10,omitempty"` // ensemble percentiles
+	TempMaxP90    *float64   `json:"temp_max_p90,omitempty"`
+	TempMinP10    *float64   `json:"temp_min_p10,omitempty"`
+	TempMinP90    *float64   `json:"temp_min_p90,omitempty"`
+	FeelsMax      *float64   `json:"apparent_temperature_max,omitempty"`
+	FeelsMin      *float64   `json:"apparent_temperature_min,omitempty"`
+	Precipitation *float64   `json:"precipitation,omitempty"`
+	PrecipChance  *float64   `json:"precipitation_probability_max,omitempty"`
+	PrecipHours   *float64   `json:"precipitation_hours,omitempty"`
+	Snowfall      *float64   `json:"snowfall_sum,omitempty"`
+	HumidityMean  *float64   `json:"relative_humidity_mean,omitempty"`
+	HumidityMin   *float64   `json:"relative_humidity_min,omitempty"`
+	HumidityMax   *float64   `json:"relative_humidity_max,omitempty"`
+	DewPointMean  *float64   `json:"dew_point_mean,omitempty"`
+	DewPointMin   *float64   `json:"dew_point_min,omitempty"`
+	DewPointMax   *float64   `json:"dew_point_max,omitempty"`
+	PressureMean  *float64   `json:"surface_pressure_mean,omitempty"` // hPa
+	PressureTrend string     `json:"pressure_trend,omitempty"`
+	CloudCover    *float64   `json:"cloud_cover_mean,omitempty"`
+	Visibility    *float64   `json:"visibility_min,omitempty"` // metres
+	UVIndex       *float64   `json:"uv_index,omitempty"`
+	Sunrise       *time.Time `json:"sunrise,omitempty"`
+	Sunset        *time.Time `json:"sunset,omitempty"`
+	Daylight      *float64   `json:"daylight_duration,omitempty"` // seconds
+	Sunshine      *float64   `json:"sunshine_duration,omitempty"` // seconds
+	WindSpeedMax  *float64   `json:"wind_speed_max,omitempty"`
+	WindGustsMax  *float64   `json:"wind_gusts_max,omitempty"`
+	WindDirection *float64   `json:"wind_direction_dominant,omitempty"`
+	WeatherCode   *int       `json:"weather_code,omitempty"`
+	Description   string     `json:"description,omitempty"`
+	Warning       string     `json:"warning,omitempty"` // WarnFrost or WarnHeat
+	Score         *float64   `json:"score,omitempty"`   // 0 to 10 for Forecast.Activity
+	Wear          []string   `json:"what_to_wear,omitempty"`
+	Changes       []Change   `json:"changes,omitempty"` // since Forecast.ChangedSince
+	// Observed days are past days, fetched with -past-days, whose values
+	// are what the models saw rather than forecast.
+	Observed bool `json:"observed,omitempty"`
+}
+
+// Warnings of days whose low or high crosses the -warn-below or -warn-above
+// threshold.
+const (
+	WarnFrost = "frost"
+	WarnHeat  = "heat"
+)
+
+// MarkWarnings sets the Warning of the forecast days whose low is below
+// below or whose high is above above, thresholds in the days' unit. A nil
+// threshold is not checked. It returns the number of days marked; observed
+// days are not.
+func MarkWarnings(days []Day, below, above *float64) int {
+	n := 0
+	for i := range days {
+		d := &days[i]
+		d.Warning = ""
+		switch {
+		case d.Observed:
+			continue
+		case below != nil && d.TempMin < *below:
+			d.Warning = WarnFrost
+		case above != nil && d.TempMax > *above:
+			d.Warning = WarnHeat
+		default:
+			continue
+		}
+		n++
+	}
+	return n
+}
+
+// Summary sums up the forecast days, in their units. Precipitation and
+// RainyDays are nil when no day has its precipitation.
+type Summary struct {
+	Days          int      `json:"days"`
+	Warmest       Date     `json:"warmest"` // the day with the highest high
+	WarmestHigh   float64  `json:"warmest_high"`
+	Coldest       Date     `json:"coldest"` // the day with the lowest low
+	ColdestLow    float64  `json:"coldest_low"`
+	MeanHigh      float64  `json:"mean_high"`
+	MeanLow       float64  `json:"mean_low"`
+	Precipitation *float64 `json:"precipitation_total,omitempty"`
+	RainyDays     *int     `json:"rainy_days,omitempty"`
+}
+
+// Summarize sums up the days that are forecast, leaving out observed
+// ones. A day is rainy from wet precipitation on. It returns nil without
+// forecast days.
+func Summarize(days []Day, wet float64) *Summary {
+	var s Summary
+	var highs, lows float64
+	for _, d := range days {
+		if d.Observed {
+			continue
+		}
+		if s.Days == 0 || d.TempMax > s.WarmestHigh {
+			s.Warmest, s.WarmestHigh = d.Date, d.TempMax
+		}
+		if s.Days == 0 || d.TempMin < s.ColdestLow {
+			s.Coldest, s.ColdestLow = d.Date, d.TempMin
+		}
+		s.Days++
+		highs += d.TempMax
+		lows += d.TempMin
+		if d.Precipitation != nil {
+			if s.Precipitation == nil {
+				s.Precipitation, s.RainyDays = new(float64), new(int)
+			}
+			*s.Precipitation += *d.Precipitation
+			if *d.Precipitation >= wet {
+				*s.RainyDays++
+			}
+		}
+	}
+	if s.Days == 0 {
+		return nil
+	}
+	s.MeanHigh = math.Round(10*highs/float64(s.Days)) / 10
+	s.MeanLow = math.Round(10*lows/float64(s.Days)) / 10
+	if s.Precipitation != nil {
+		*s.Precipitation = math.Round(100**s.Precipitation) / 100
+	}
+	return &s
+}
+
+// What a Change is of.
+const (
+	ChangeHigh          = "high"
+	ChangeLow           = "low"
+	ChangePrecipitation = "precipitation"
+	ChangePrecipChance  = "precipitation_probability"
+	ChangeWind          = "wind"
+)
+
+// Change is a value of a day that moved noticeably from an earlier
+// forecast, in the forecast's units.
+type Change struct {
+	Of   string  `json:"of"`
+	From float64 `json:"from"`
+	To   float64 `json:"to"`
+}
+
+type Hour struct {
+	Time              time.Time `json:"time"`
+	Temperature       float64   `json:"temperature"`
+	Precipitation     *float64  `json:"precipitation,omitempty"`
+	PrecipProbability *float64  `json:"precipitation_probability,omitempty"`
+	WindSpeed         *float64  `json:"wind_speed,omitempty"`
+	WindDirection     *float64  `json:"wind_direction,omitempty"`
+	SnowDepth         *float64  `json:"snow_depth,omitempty"`
+	Humidity          *float64  `json:"relative_humidity,omitempty"`
+	DewPoint          *float64  `json:"dew_point,omitempty"`
+	Pressure          *float64  `json:"surface_pressure,omitempty"` // hPa
+	CloudCover        *float64  `json:"cloud_cover,omitempty"`
+	Visibility        *float64  `json:"visibility,omitempty"` // metres
+}
+
+type Current struct {
+	Time          time.Time `json:"time"`
+	Temperature   float64   `json:"temperature"`
+	WindSpeed     float64   `json:"wind_speed"`
+	WindDirection float64   `json:"wind_direction"`
+	WeatherCode   int       `json:"weather_code"`
+	Description   string    `json:"description"`
+	IsDay         bool      `json:"is_day"`
+}
+
+// Alert is a warning, watch or advisory in effect for the location. End is
+// nil when the issuer gave no end time.
+type Alert struct {
+	Event       string     `json:"event"`
+	Severity    string     `json:"severity,omitempty"`
+	Headline    string     `json:"headline,omitempty"`
+	Description string     `json:"description,omitempty"`
+	Instruction string     `json:"instruction,omitempty"`
+	Start       time.Time  `json:"start"`
+	End         *time.Time `json:"end,omitempty"`
+}
+
+// SeaDay is the marine forecast for a day. Heights are in metres, periods
+// in seconds and directions in degrees the waves come from.
+type SeaDay struct {
+	Date           Date     `json:"date"`
+	WaveHeight     *float64 `json:"wave_height_max,omitempty"`
+	WavePeriod     *float64 `json:"wave_period_max,omitempty"`
+	WaveDirection  *float64 `json:"wave_direction_dominant,omitempty"`
+	SwellHeight    *float64 `json:"swell_wave_height_max,omitempty"`
+	SwellPeriod    *float64 `json:"swell_wave_period_max,omitempty"`
+	SwellDirection *float64 `json:"swell_wave_direction_dominant,omitempty"`
+}
+
+// AgriDay holds the agricultural values of a day. GDD is the day's growing
+// degree days above a base temperature and GDDTotal their sum since the
+// first day; both are in the temperature unit. ET0 is the FAO reference
+// evapotranspiration in mm, SoilTemperature the mean at 6 cm and
+// SoilMoisture the mean volumetric water content at 3-9 cm, in percent.
+type AgriDay struct {
+	Date            Date     `json:"date"`
+	Observed        bool     `json:"observed,omitempty"`
+	TempMax         float64  `json:"temp_max"`
+	TempMin         float64  `json:"temp_min"`
+	GDD             float64  `json:"gdd"`
+	GDDTotal        float64  `json:"gdd_total"`
+	ET0             *float64 `json:"et0_fao_evapotranspiration,omitempty"`
+	SoilTemperature *float64 `json:"soil_temperature_6cm,omitempty"`
+	SoilMoisture    *float64 `json:"soil_moisture_3_to_9cm,omitempty"`
+}
+
+// GrowingDegreeDays fills in GDD and GDDTotal of days by the averaging
+// method: the amount the day's mean temperature exceeds base, or zero.
+func GrowingDegreeDays(days []AgriDay, base float64) {
+	total := 0.0
+	for i := range days {
+		gdd := max(0, (days[i].TempMax+days[i].TempMin)/2-base)
+		total += gdd
+		days[i].GDD = round(gdd, 1)
+		days[i].GDDTotal = round(total, 1)
+	}
+}
+
+// PollenDay is the pollen forecast for a day: the highest hourly count of
+// each kind in grains/m³, nil where the model has none.
+type PollenDay struct {
+	Date    Date     `json:"date"`
+	Alder   *float64 `json:"alder_pollen,omitempty"`
+	Birch   *float64 `json:"birch_pollen,omitempty"`
+	Grass   *float64 `json:"grass_pollen,omitempty"`
+	Mugwort *float64 `json:"mugwort_pollen,omitempty"`
+	Olive   *float64 `json:"olive_pollen,omitempty"`
+	Ragweed *float64 `json:"ragweed_pollen,omitempty"`
+}
+
+// PollenCount is the count of one kind of pollen and its level.
+type PollenCount struct {
+	Kind  string
+	Count float64
+	Level string
+}
+
+// Counts lists the kinds of pollen d has a count for, trees first.
+func (d PollenDay) Counts() []PollenCount {
+	var counts []PollenCount
+	for _, k := range []struct {
+		kind  string
+		count *float64
+		scale pollenScale
+	}{
+		{"Alder", d.Alder, treePollen},
+		{"Birch", d.Birch, treePollen},
+		{"Olive", d.Olive, treePollen},
+		{"Grass", d.Grass, grassPollen},
+		{"Mugwort", d.Mugwort, weedPollen},
+		{"Ragweed", d.Ragweed, weedPollen},
+	} {
+		if k.count != nil {
+			counts = append(counts, PollenCount{Kind: k.kind, Count: *k.count, Level: k.scale.level(*k.count)})
+		}
+	}
+	return counts
+}
+
+// Pollen levels, from none to very high.
+const (
+	PollenNone     = "none"
+	PollenLow      = "low"
+	PollenModerate = "moderate"
+	PollenHigh     = "high"
+	PollenVeryHigh = "very high"
+)
+
+// pollenScale holds the lowest counts in grains/m³ that are moderate, high
+// and very high. The scales are those of the US National Allergy Bureau.
+type pollenScale [3]float64
+
+var (
+	treePollen  = pollenScale{15, 90, 1500}
+	grassPollen = pollenScale{5, 20, 200}
+	weedPollen  = pollenScale{10, 50, 500}
+)
+
+func (s pollenScale) level(count float64) string {
+	switch {
+	case count >= s[2]:
+		return PollenVeryHigh
+	case count >= s[1]:
+		return PollenHigh
+	case count >= s[0]:
+		return PollenModerate
+	case count >= 1:
+		return PollenLow
+	}
+	return PollenNone
+}
+
+// Pressure trends of a day, from the change in surface pressure over it.
+const (
+	Rising  = "rising"
+	Falling = "falling"
+	Steady  = "steady"
+)
+
+// Date is a calendar day that marshals as YYYY-MM-DD.
+type Date struct {
+	time.Time
+}
+
+func ParseDate(s string, loc *time.Location) (Date, error) {
+	t, err := time.ParseInLocation(dateLayout, s, loc)
+	if err != nil {
+		return Date{}, err
+	}
+	return Date{t}, nil
+}
+
+func (d Date) String() string {
+	return d.Format(dateLayout)
+}
+
+func (d Date) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+func (d *Date) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	t, err := time.Parse(dateLayout, s)
+	if err != nil {
+		return err
+	}
+	d.Time = t
+	return nil
+}
+
+// In returns f with its times shown in loc. Dates stay those of the
+// location, as days are measured there.
+func (f Forecast) In(loc *time.Location) Forecast {
+	in := func(t *time.Time) *time.Time {
+		if t == nil {
+			return nil
+		}
+		v := t.In(loc)
+		return &v
+	}
+	if f.Current != nil {
+		c := *f.Current
+		c.Time = c.Time.In(loc)
+		f.Current = &c
+	}
+	days := make([]Day, len(f.Days))
+	for i, d := range f.Days {
+		d.Sunrise, d.Sunset = in(d.Sunrise), in(d.Sunset)
+		days[i] = d
+	}
+	hours := make([]Hour, len(f.Hours))
+	for i, h := range f.Hours {
+		h.Time = h.Time.In(loc)
+		hours[i] = h
+	}
+	alerts := make([]Alert, len(f.Alerts))
+	for i, a := range f.Alerts {
+		a.Start, a.End = a.Start.In(loc), in(a.End)
+		alerts[i] = a
+	}
+	f.Days, f.Hours, f.Alerts = days, hours, alerts
+	return f
+}
+
+// Label names the forecast in comparisons: the location, and the model when
+// one was chosen.
+func (f Forecast) Label() string {
+	if f.Model == "" {
+		return f.Location.Name
+	}
+	if f.Location.Name == "" {
+		return f.Model
+	}
+	return f.Location.Name + " (" + f.Model + ")"
+}
+
+// Difference compares the days two forecasts have in common, for choosing
+// between places. Deltas are To's values minus From's.
+type Difference struct {
+	From  Location        `json:"from"`
+	To    Location        `json:"to"`
+	Units Units           `json:"units"`
+	Days  []DayDifference `json:"days"`
+}
+
+type DayDifference struct {
+	Date          Date     `json:"date"`
+	From          Day      `json:"from"`
+	To            Day      `json:"to"`
+	TempMax       float64  `json:"temp_max_delta"`
+	TempMin       float64  `json:"temp_min_delta"`
+	Precipitation *float64 `json:"precipitation_delta,omitempty"`
+}
+
+// Diff returns the day by day difference of to from from, which must be in
+// the same units. Days are matched by date, each in its location's
+// timezone.
+func Diff(from, to Forecast) Difference {
+	d := Difference{From: from.Location, To: to.Location, Units: from.Units, Days: []DayDifference{}}
+	byDate := map[string]Day{}
+	for _, day := range to.Days {
+		byDate[day.Date.String()] = day
+	}
+	for _, a := range from.Days {
+		b, ok := byDate[a.Date.String()]
+		if !ok {
+			continue
+		}
+		dd := DayDifference{
+			Date:    a.Date,
+			From:    a,
+			To:      b,
+			TempMax: round(b.TempMax-a.TempMax, 1),
+			TempMin: round(b.TempMin-a.TempMin, 1),
+		}
+		if a.Precipitation != nil && b.Precipitation != nil {
+			p := round(*b.Precipitation-*a.Precipitation, 2)
+			dd.Precipitation = &p
+		}
+		d.Days = append(d.Days, dd)
+	}
+	return d
+}
+
+// compassPoints are the 16 points of the compass, clockwise from north.
+var compassPoints = []string{"N", "NNE", "NE", "ENE", "E", "ESE", "SE", "SSE", "S", "SSW", "SW", "WSW", "W", "WNW", "NW", "NNW"}
+
+// windArrows point downwind for wind from the 8 principal directions,
+// clockwise from north: a northerly blows south.
+var windArrows = []string{"↓", "↙", "←", "↖", "↑", "↗", "→", "↘"}
+
+// sector returns which of n equal sectors, the first centred on north,
+// holds the direction degrees.
+func sector(degrees float64, n int) int {
+	width := 360 / float64(n)
+	i := int(math.Round(math.Mod(degrees, 360) / width))
+	return (i%n + n) % n
+}
+
+// Compass returns the 16-point compass abbreviation of a direction in
+// degrees, e.g. "WSW" for 240.
+func Compass(degrees float64) string {
+	return compassPoints[sector(degrees, len(compassPoints))]
+}
+
+// WindArrow returns an arrow pointing where wind from degrees blows, e.g.
+// "↗" for a southwesterly.
+func WindArrow(degrees float64) string {
+	return windArrows[sector(degrees, len(windArrows))]
+}
+
+// WindRose is how often the wind came from each of the 8 principal
+// directions over a number of hours.
+type WindRose struct {
+	Location Location     `json:"location"`
+	Units    Units        `json:"units"`
+	Hours    int          `json:"hours"`
+	Calm     float64      `json:"calm_percent"`
+	Sectors  []WindSector `json:"sectors"`
+}
+
+// WindSector is the share of the hours the wind came from Direction, in
+// percent, and its mean speed in those hours.
+type WindSector struct {
+	Direction string  `json:"direction"`
+	Percent   float64 `json:"percent"`
+	MeanSpeed float64 `json:"mean_speed"`
+}
+
+// NewWindRose counts the hours of f by wind direction. Hours with a speed
+// below calm have no direction worth counting and are counted as calm.
+func NewWindRose(f Forecast, calm float64) WindRose {
+	rose := WindRose{Location: f.Location, Units: f.Units}
+	var hours, speeds [8]float64
+	var calmHours float64
+	for _, hour := range f.Hours {
+		if hour.WindSpeed == nil || hour.WindDirection == nil {
+			continue
+		}
+		rose.Hours++
+		if *hour.WindSpeed < calm {
+			calmHours++
+			continue
+		}
+		i := sector(*hour.WindDirection, len(hours))
+		hours[i]++
+		speeds[i] += *hour.WindSpeed
+	}
+	for i := range hours {
+		s := WindSector{Direction: compassPoints[2*i]}
+		if rose.Hours > 0 {
+			s.Percent = round(100*hours[i]/float64(rose.Hours), 1)
+		}
+		if hours[i] > 0 {
+			s.MeanSpeed = round(speeds[i]/hours[i], 1)
+		}
+		rose.Sectors = append(rose.Sectors, s)
+	}
+	if rose.Hours > 0 {
+		rose.Calm = round(100*calmHours/float64(rose.Hours), 1)
+	}
+	return rose
+}
+
+// round drops the float noise of subtracting values of one decimal.
+func round(v float64, decimals int) float64 {
+	p := math.Pow(10, float64(decimals))
+	return math.Round(v*p) / p
+}
@@ -0,0 +1,277 @@
+package forecast
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+	"time"
+)
+
+func ptr(v float64) *float64 { return &v }
+
+func date(day int) Date {
+	return Date{time.Date(2024, 6, day, 0, 0, 0, 0, time.UTC)}
+}
+
+func TestMarkWarnings(t *testing.T) {
+	days := []Day{
+		{TempMin: -2, TempMax: 5},
+		{TempMin: 10, TempMax: 36},
+		{TempMin: 10, TempMax: 20, Warning: WarnHeat},
+		{TempMin: -5, TempMax: 40, Observed: true},
+		{TempMin: -1, TempMax: 38},
+	}
+	tests := []struct {
+		name         string
+		below, above *float64
+		want         []string
+	}{
+		{"no thresholds", nil, nil, []string{"", "", "", "", ""}},
+		{"below", ptr(0), nil, []string{WarnFrost, "", "", "", WarnFrost}},
+		{"above", nil, ptr(35), []string{"", WarnHeat, "", "", WarnHeat}},
+		{"both, frost first", ptr(0), ptr(35), []string{WarnFrost, WarnHeat, "", "", WarnFrost}},
+		{"zero is a threshold", nil, ptr(0), []string{WarnHeat, WarnHeat, WarnHeat, "", WarnHeat}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := slices.Clone(days)
+			n := MarkWarnings(d, tt.below, tt.above)
+			var got []string
+			marked := 0
+			for _, day := range d {
+				got = append(got, day.Warning)
+				if day.Warning != "" {
+					marked++
+				}
+			}
+			if !slices.Equal(got, tt.want) || n != marked {
+				t.Errorf("MarkWarnings() = %d, %q, want %q", n, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	tests := []struct {
+		name string
+		days []Day
+		want *Summary
+	}{
+		{"no days", nil, nil},
+		{"only observed", []Day{{Date: date(1), TempMax: 20, Observed: true}}, nil},
+		{
+			"no precipitation",
+			[]Day{{Date: date(1), TempMax: 20, TempMin: 10}, {Date: date(2), TempMax: 25, TempMin: 8}},
+			&Summary{Days: 2, Warmest: date(2), WarmestHigh: 25, Coldest: date(2), ColdestLow: 8, MeanHigh: 22.5, MeanLow: 9},
+		},
+		{
+			"some precipitation",
+			[]Day{
+				{Date: date(1), TempMax: 30, TempMin: 5, Observed: true, Precipitation: ptr(20)},
+				{Date: date(2), TempMax: 20, TempMin: 10, Precipitation: ptr(0.5)},
+				{Date: date(3), TempMax: 21, TempMin: 11},
+				{Date: date(4), TempMax: 19, TempMin: 12, Precipitation: ptr(4.25)},
+			},
+			&Summary{Days: 3, Warmest: date(3), WarmestHigh: 21, Coldest: date(2), ColdestLow: 10, MeanHigh: 20, MeanLow: 11, Precipitation: ptr(4.75), RainyDays: new(int)},
+		},
+	}
+	*tests[3].want.RainyDays = 1
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Summarize(tt.days, 1)
+			gotJSON, _ := json.Marshal(got)
+			wantJSON, _ := json.Marshal(tt.want)
+			if string(gotJSON) != string(wantJSON) {
+				t.Errorf("Summarize() = %s, want %s", gotJSON, wantJSON)
+			}
+		})
+	}
+}
+
+func TestDiff(t *testing.T) {
+	from := Forecast{
+		Location: Location{Name: "The Hague"},
+		Days: []Day{
+			{Date: date(1), TempMax: 18.3, TempMin: 10.1, Precipitation: ptr(1.2)},
+			{Date: date(2), TempMax: 19, TempMin: 11},
+			{Date: date(3), TempMax: 20, TempMin: 12, Precipitation: ptr(0)},
+		},
+	}
+	to := Forecast{
+		Location: Location{Name: "Utrecht"},
+		Days: []Day{
+			{Date: date(2), TempMax: 21.5, TempMin: 10, Precipitation: ptr(3)},
+			{Date: date(1), TempMax: 19.4, TempMin: 9.8, Precipitation: ptr(0.1)},
+		},
+	}
+	d := Diff(from, to)
+	if d.From.Name != "The Hague" || d.To.Name != "Utrecht" {
+		t.Errorf("Diff() of %q and %q", d.From.Name, d.To.Name)
+	}
+	if len(d.Days) != 2 {
+		t.Fatalf("Diff() has %d days, want the 2 in common", len(d.Days))
+	}
+	want := []struct {
+		date             Date
+		tempMax, tempMin float64
+		precip           *float64
+	}{
+		{date(1), 1.1, -0.3, ptr(-1.1)},
+		{date(2), 2.5, -1, nil},
+	}
+	for i, w := range want {
+		got := d.Days[i]
+		if got.Date != w.date || got.TempMax != w.tempMax || got.TempMin != w.tempMin {
+			t.Errorf("day %d = %s %g/%g, want %s %g/%g", i, got.Date, got.TempMax, got.TempMin, w.date, w.tempMax, w.tempMin)
+		}
+		if (got.Precipitation == nil) != (w.precip == nil) || (w.precip != nil && *got.Precipitation != *w.precip) {
+			t.Errorf("day %d precipitation = %v, want %v", i, got.Precipitation, w.precip)
+		}
+	}
+
+	if d := Diff(from, Forecast{}); d.Days == nil || len(d.Days) != 0 {
+		t.Errorf("Diff() without common days = %#v, want an empty list", d.Days)
+	}
+}
+
+func TestCompass(t *testing.T) {
+	tests := []struct {
+		degrees     float64
+		point, wind string
+	}{
+		{0, "N", "↓"},
+		{11, "N", "↓"},
+		{12, "NNE", "↓"},
+		{45, "NE", "↙"},
+		{240, "WSW", "↗"},
+		{350, "N", "↓"},
+		{360, "N", "↓"},
+		{720 + 90, "E", "←"},
+		{-90, "W", "→"},
+	}
+	for _, tt := range tests {
+		if got := Compass(tt.degrees); got != tt.point {
+			t.Errorf("Compass(%g) = %s, want %s", tt.degrees, got, tt.point)
+		}
+		if got := WindArrow(tt.degrees); got != tt.wind {
+			t.Errorf("WindArrow(%g) = %s, want %s", tt.degrees, got, tt.wind)
+		}
+	}
+}
+
+func TestNewWindRose(t *testing.T) {
+	f := Forecast{Hours: []Hour{
+		{WindSpeed: ptr(10), WindDirection: ptr(0)},
+		{WindSpeed: ptr(20), WindDirection: ptr(350)},
+		{WindSpeed: ptr(6), WindDirection: ptr(270)},
+		{WindSpeed: ptr(1), WindDirection: ptr(90)},
+		{WindSpeed: ptr(30)},
+		{WindDirection: ptr(180)},
+		{},
+	}}
+	rose := NewWindRose(f, 2)
+	if rose.Hours != 4 || rose.Calm != 25 {
+		t.Errorf("NewWindRose() counted %d hours, %g%% calm, want 4 and 25%%", rose.Hours, rose.Calm)
+	}
+	if len(rose.Sectors) != 8 {
+		t.Fatalf("NewWindRose() has %d sectors, want 8", len(rose.Sectors))
+	}
+	want := map[string]WindSector{
+		"N": {Direction: "N", Percent: 50, MeanSpeed: 15},
+		"W": {Direction: "W", Percent: 25, MeanSpeed: 6},
+		"E": {Direction: "E"},
+	}
+	for _, s := range rose.Sectors {
+		if w, ok := want[s.Direction]; ok && s != w {
+			t.Errorf("sector %s = %+v, want %+v", s.Direction, s, w)
+		}
+	}
+
+	if empty := NewWindRose(Forecast{}, 2); empty.Hours != 0 || empty.Calm != 0 || empty.Sectors[0].Percent != 0 {
+		t.Errorf("NewWindRose() without hours = %+v", empty)
+	}
+}
+
+func TestPollenCounts(t *testing.T) {
+	d := PollenDay{Birch: ptr(100), Grass: ptr(0.4), Ragweed: ptr(600), Alder: ptr(15)}
+	want := []PollenCount{
+		{"Alder", 15, PollenModerate},
+		{"Birch", 100, PollenHigh},
+		{"Grass", 0.4, PollenNone},
+		{"Ragweed", 600, PollenVeryHigh},
+	}
+	if got := d.Counts(); !slices.Equal(got, want) {
+		t.Errorf("Counts() = %+v, want %+v", got, want)
+	}
+	if got := (PollenDay{}).Counts(); got != nil {
+		t.Errorf("Counts() without counts = %+v", got)
+	}
+}
+
+func TestGrowingDegreeDays(t *testing.T) {
+	days := []AgriDay{{TempMax: 20, TempMin: 10}, {TempMax: 8, TempMin: 2}, {TempMax: 25.3, TempMin: 12.1}}
+	GrowingDegreeDays(days, 10)
+	want := [][2]float64{{5, 5}, {0, 5}, {8.7, 13.7}}
+	for i, w := range want {
+		if days[i].GDD != w[0] || days[i].GDDTotal != w[1] {
+			t.Errorf("day %d GDD = %g, total %g, want %g, %g", i, days[i].GDD, days[i].GDDTotal, w[0], w[1])
+		}
+	}
+}
+
+func TestDateJSON(t *testing.T) {
+	data, err := json.Marshal(date(3))
+	if err != nil || string(data) != `"2024-06-03"` {
+		t.Fatalf("Marshal() = %s, %v", data, err)
+	}
+	var d Date
+	if err := json.Unmarshal(data, &d); err != nil || d != date(3) {
+		t.Errorf("Unmarshal(%s) = %s, %v", data, d, err)
+	}
+	for _, bad := range []string{`"2024-6-3"`, `"03/06/2024"`, `20240603`} {
+		if err := json.Unmarshal([]byte(bad), &d); err == nil {
+			t.Errorf("Unmarshal(%s) accepted it", bad)
+		}
+	}
+}
+
+func TestIn(t *testing.T) {
+	amsterdam, err := time.LoadLocation("Europe/Amsterdam")
+	if err != nil {
+		t.Skip(err)
+	}
+	sunrise := time.Date(2024, 6, 3, 3, 20, 0, 0, time.UTC)
+	f := Forecast{
+		Days:   []Day{{Date: date(3), Sunrise: &sunrise}, {Date: date(4)}},
+		Alerts: []Alert{{Start: sunrise}},
+	}
+	got := f.In(amsterdam)
+	if got.Days[0].Date != date(3) {
+		t.Errorf("date moved to %s", got.Days[0].Date)
+	}
+	if h := got.Days[0].Sunrise.Hour(); h != 5 {
+		t.Errorf("sunrise at %d h, want 5 h in Amsterdam", h)
+	}
+	if got.Days[1].Sunrise != nil || got.Alerts[0].End != nil {
+		t.Error("In() filled in a missing time")
+	}
+	if f.Days[0].Sunrise.Location() != time.UTC {
+		t.Error("In() modified its input")
+	}
+}
+
+func TestLabel(t *testing.T) {
+	tests := []struct {
+		f    Forecast
+		want string
+	}{
+		{Forecast{Location: Location{Name: "Paris"}}, "Paris"},
+		{Forecast{Location: Location{Name: "Paris"}, Model: "icon"}, "Paris (icon)"},
+		{Forecast{Model: "icon"}, "icon"},
+	}
+	for _, tt := range tests {
+		if got := tt.f.Label(); got != tt.want {
+			t.Errorf("Label() = %q, want %q", got, tt.want)
+		}
+	}
+}
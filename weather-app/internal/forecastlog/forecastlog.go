@@ -0,0 +1,262 @@
+// Package forecastlog keeps every fetched daily forecast in a SQLite
+// database, so forecasts can later be compared with what actually happened.
+package forecastlog
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	_ "modernc.org/sqlite"
+
+	"weather-app/internal/forecast"
+	"weather-app/internal/units"
+)
+
+// schema stores one row per fetch and one per forecast day. Values are
+// metric: °C, mm and km/h. lead_days is how many days ahead of the fetch,
+// in the location's timezone, the day was forecast.
+const schema = `
+CREATE TABLE IF NOT EXISTS fetches (
+	id         INTEGER PRIMARY KEY,
+	fetched_at TEXT NOT NULL,
+	provider   TEXT NOT NULL,
+	location   TEXT NOT NULL,
+	country    TEXT NOT NULL,
+	latitude   REAL NOT NULL,
+	longitude  REAL NOT NULL,
+	timezone   TEXT NOT NULL
+);
+CREATE TABLE IF NOT EXISTS days (
+	fetch_id                      INTEGER NOT NULL REFERENCES fetches(id),
+	date                          TEXT NOT NULL,
+	lead_days                     INTEGER NOT NULL,
+	temp_max                      REAL NOT NULL,
+	temp_min                      REAL NOT NULL,
+	precipitation                 REAL,
+	precipitation_probability_max REAL,
+	snowfall_sum                  REAL,
+	wind_speed_max                REAL,
+	uv_index                      REAL,
+	weather_code                  INTEGER,
+	PRIMARY KEY (fetch_id, date)
+);
+CREATE INDEX IF NOT EXISTS days_date ON days(date);
+CREATE VIEW IF NOT EXISTS forecasts AS
+	SELECT f.fetched_at, f.provider, f.location, f.country, f.latitude, f.longitude, d.*
+	FROM days d JOIN fetches f ON f.id = d.fetch_id;
+`
+
+type DB struct {
+	db *sql.DB
+}
+
+// Open opens the database at path, creating it and its tables when needed.
+func Open(path string) (*DB, error) {
+	if dir := filepath.Dir(path); dir != "" {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return nil, err
+		}
+	}
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := db.Exec(schema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("opening %s: %w", path, err)
+	}
+	return &DB{db: db}, nil
+}
+
+func (d *DB) Close() error {
+	return d.db.Close()
+}
+
+// leadDays counts the calendar days from the fetch to date.
+func leadDays(fetched time.Time, date forecast.Date) int {
+	fetched = fetched.In(date.Location())
+	today := time.Date(fetched.Year(), fetched.Month(), fetched.Day(), 0, 0, 0, 0, date.Location())
+	return int(date.Sub(today).Round(time.Hour).Hours() / 24)
+}
+
+// Add records the daily forecast f, fetched from provider at fetched. f
+// must be in metric units. Observed past days are not forecasts, so they
+// are left out.
+func (d *DB) Add(ctx context.Context, provider string, f forecast.Forecast, fetched time.Time) error {
+	tx, err := d.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	loc := f.Location
+	res, err := tx.ExecContext(ctx,
+		`INSERT INTO fetches (fetched_at, provider, location, country, latitude, longitude, timezone) VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		fetched.UTC().Format(time.RFC3339), provider, loc.Name, loc.Country, loc.Latitude, loc.Longitude, loc.Timezone)
+	if err != nil {
+		return err
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return err
+	}
+
+	for _, day := range f.Days {
+		if day.Observed {
+			continue
+		}
+		_, err := tx.ExecContext(ctx,
+			`INSERT INTO days (fetch_id, date, lead_days, temp_max, temp_min, precipitation, precipitation_probability_max, snowfall_sum, wind_speed_max, uv_index, weather_code) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			id, day.Date.String(), leadDays(fetched, day.Date), day.TempMax, day.TempMin,
+			day.Precipitation, day.PrecipChance, day.Snowfall, day.WindSpeedMax, day.UVIndex, day.WeatherCode)
+		if err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// Query runs a SQL query and returns its column names and rows, with NULLs
+// as empty strings.
+func (d *DB) Query(ctx context.Context, query string, args ...any) ([]string, [][]string, error) {
+	rows, err := d.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, nil, err
+	}
+	var records [][]string
+	for rows.Next() {
+		values := make([]sql.NullString, len(columns))
+		ptrs := make([]any, len(columns))
+		for i := range values {
+			ptrs[i] = &values[i]
+		}
+		if err := rows.Scan(ptrs...); err != nil {
+			return nil, nil, err
+		}
+		record := make([]string, len(columns))
+		for i, v := range values {
+			record[i] = v.String
+		}
+		records = append(records, record)
+	}
+	return columns, records, rows.Err()
+}
+
+// Day is a logged forecast day with the fetch it came from.
+type Day struct {
+	Provider      string
+	Location      forecast.Location
+	Date          string
+	LeadDays      int
+	TempMax       float64
+	TempMin       float64
+	Precipitation *float64
+}
+
+// DaysBefore returns the logged forecast days dated before date, given as
+// YYYY-MM-DD, ordered by location and date. Past days that older versions
+// logged along with -past-days are skipped.
+func (d *DB) DaysBefore(ctx context.Context, date string) ([]Day, error) {
+	rows, err := d.db.QueryContext(ctx, `
+		SELECT provider, location, country, latitude, longitude, timezone, date, lead_days, temp_max, temp_min, precipitation
+		FROM days JOIN fetches ON fetches.id = days.fetch_id
+		WHERE date < ? AND lead_days >= 0
+		ORDER BY latitude, longitude, date, lead_days`, date)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var days []Day
+	for rows.Next() {
+		var day Day
+		var precip sql.NullFloat64
+		loc := &day.Location
+		err := rows.Scan(&day.Provider, &loc.Name, &loc.Country, &loc.Latitude, &loc.Longitude, &loc.Timezone,
+			&day.Date, &day.LeadDays, &day.TempMax, &day.TempMin, &precip)
+		if err != nil {
+			return nil, err
+		}
+		if precip.Valid {
+			day.Precipitation = &precip.Float64
+		}
+		days = append(days, day)
+	}
+	return days, rows.Err()
+}
+
+// Latest returns the last forecast logged from provider for the place at
+// loc's coordinates, in metric units, and when it was fetched. ok is false
+// when there is none.
+func (d *DB) Latest(ctx context.Context, provider string, loc forecast.Location) (f forecast.Forecast, fetched time.Time, ok bool, err error) {
+	var id int64
+	var at, timezone string
+	err = d.db.QueryRowContext(ctx, `
+		SELECT id, fetched_at, timezone FROM fetches
+		WHERE provider = ? AND latitude = ? AND longitude = ?
+		ORDER BY fetched_at DESC, id DESC LIMIT 1`,
+		provider, loc.Latitude, loc.Longitude).Scan(&id, &at, &timezone)
+	if err == sql.ErrNoRows {
+		return f, fetched, false, nil
+	}
+	if err != nil {
+		return f, fetched, false, err
+	}
+	if fetched, err = time.Parse(time.RFC3339, at); err != nil {
+		return f, fetched, false, fmt.Errorf("fetch %d: %w", id, err)
+	}
+	tz, err := time.LoadLocation(timezone)
+	if err != nil {
+		tz = time.UTC
+	}
+
+	rows, err := d.db.QueryContext(ctx, `
+		SELECT date, temp_max, temp_min, precipitation, precipitation_probability_max, snowfall_sum, wind_speed_max, uv_index, weather_code
+		FROM days WHERE fetch_id = ? ORDER BY date`, id)
+	if err != nil {
+		return f, fetched, false, err
+	}
+	defer rows.Close()
+
+	f = forecast.Forecast{Location: loc, Units: units.Metric.Units()}
+	for rows.Next() {
+		var day forecast.Day
+		var date string
+		var code sql.NullInt64
+		var precip, chance, snow, wind, uv sql.NullFloat64
+		if err := rows.Scan(&date, &day.TempMax, &day.TempMin, &precip, &chance, &snow, &wind, &uv, &code); err != nil {
+			return f, fetched, false, err
+		}
+		if day.Date, err = forecast.ParseDate(date, tz); err != nil {
+			return f, fetched, false, fmt.Errorf("fetch %d: %w", id, err)
+		}
+		day.Precipitation = optional(precip)
+		day.PrecipChance = optional(chance)
+		day.Snowfall = optional(snow)
+		day.WindSpeedMax = optional(wind)
+		day.UVIndex = optional(uv)
+		if code.Valid {
+			c := int(code.Int64)
+			day.WeatherCode = &c
+		}
+		f.Days = append(f.Days, day)
+	}
+	return f, fetched, true, rows.Err()
+}
+
+func optional(v sql.NullFloat64) *float64 {
+	if !v.Valid {
+		return nil
+	}
+	return &v.Float64
+}
@@ -0,0 +1,49 @@
+package forecastlog
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"weather-app/internal/forecast"
+)
+
+func TestObservedDaysNotLogged(t *testing.T) {
+	db, err := Open(filepath.Join(t.TempDir(), "log.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	day := func(s string, observed bool) forecast.Day {
+		date, err := forecast.ParseDate(s, time.UTC)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return forecast.Day{Date: date, TempMax: 20, TempMin: 10, Observed: observed}
+	}
+	f := forecast.Forecast{
+		Location: forecast.Location{Name: "Berlin", Latitude: 52.52, Longitude: 13.41},
+		Days:     []forecast.Day{day("2024-06-01", true), day("2024-06-02", false), day("2024-06-03", false)},
+	}
+	fetched := time.Date(2024, 6, 2, 9, 0, 0, 0, time.UTC)
+	if err := db.Add(context.Background(), "open-meteo", f, fetched); err != nil {
+		t.Fatal(err)
+	}
+
+	days, err := db.DaysBefore(context.Background(), "2024-06-04")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, d := range days {
+		got = append(got, d.Date)
+		if d.LeadDays < 0 {
+			t.Errorf("%s logged with lead_days %d", d.Date, d.LeadDays)
+		}
+	}
+	if len(got) != 2 || got[0] != "2024-06-02" || got[1] != "2024-06-03" {
+		t.Errorf("logged days = %v, want 2024-06-02 and 2024-06-03", got)
+	}
+}
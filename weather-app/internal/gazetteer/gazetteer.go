@@ -0,0 +1,87 @@
+// Package gazetteer is a small embedded list of major world cities, used to
+// resolve common lookups without calling the geocoding API.
+package gazetteer
+
+import (
+	_ "embed"
+	"encoding/csv"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+
+	"weather-app/pkg/openmeteo"
+)
+
+//go:embed cities.csv
+var citiesCSV string
+
+var (
+	loadOnce sync.Once
+	cities   []openmeteo.GeocodingResult
+)
+
+// load parses the embedded list. It is part of the binary, so a malformed
+// file is a programming error.
+func load() {
+	records, err := csv.NewReader(strings.NewReader(citiesCSV)).ReadAll()
+	if err != nil {
+		panic("gazetteer: " + err.Error())
+	}
+	for _, r := range records[1:] {
+		lat, err1 := strconv.ParseFloat(r[4], 64)
+		lon, err2 := strconv.ParseFloat(r[5], 64)
+		population, err3 := strconv.Atoi(r[6])
+		if err1 != nil || err2 != nil || err3 != nil {
+			panic("gazetteer: bad record for " + r[0])
+		}
+		cities = append(cities, openmeteo.GeocodingResult{
+			Name:        r[0],
+			Admin1:      r[1],
+			Country:     r[2],
+			CountryCode: r[3],
+			Latitude:    lat,
+			Longitude:   lon,
+			Population:  population,
+			Timezone:    r[7],
+		})
+	}
+}
+
+// Gazetteer implements openmeteo.Gazetteer over the embedded city list.
+type Gazetteer struct{}
+
+// Lookup returns the cities called name in country, ignoring case. The
+// country may be a name or an ISO code.
+func (Gazetteer) Lookup(name, country string) []openmeteo.GeocodingResult {
+	loadOnce.Do(load)
+	var matches []openmeteo.GeocodingResult
+	for _, c := range cities {
+		if strings.EqualFold(c.Name, name) && c.InCountry(country) {
+			matches = append(matches, c)
+		}
+	}
+	return matches
+}
+
+// Nearest returns the city closest to lat, lon and its great-circle
+// distance in km. ok is false for an empty list.
+func Nearest(lat, lon float64) (city openmeteo.GeocodingResult, km float64, ok bool) {
+	loadOnce.Do(load)
+	km = math.Inf(1)
+	for _, c := range cities {
+		if d := distance(lat, lon, c.Latitude, c.Longitude); d < km {
+			city, km, ok = c, d, true
+		}
+	}
+	return city, km, ok
+}
+
+// distance is the haversine distance between two points, in km.
+func distance(lat1, lon1, lat2, lon2 float64) float64 {
+	const earthRadius = 6371
+	rad := math.Pi / 180
+	dLat, dLon := (lat2-lat1)*rad, (lon2-lon1)*rad
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1*rad)*math.Cos(lat2*rad)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadius * math.Asin(math.Sqrt(a))
+}
@@ -0,0 +1,147 @@
+// Package geoip finds the approximate location of this machine from its
+// public IP address.
+package geoip
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"weather-app/internal/forecast"
+)
+
+// DefaultTimeout bounds a single lookup.
+const DefaultTimeout = 5 * time.Second
+
+// Locator looks up the current location.
+type Locator interface {
+	Locate(ctx context.Context) (forecast.Location, error)
+}
+
+var providers = map[string]func(*http.Client) Locator{
+	"ip-api": func(c *http.Client) Locator { return &IPAPI{HTTPClient: c} },
+	"ipinfo": func(c *http.Client) Locator { return &IPInfo{HTTPClient: c} },
+}
+
+// DefaultProvider is the provider used when none is chosen.
+const DefaultProvider = "ip-api"
+
+// Providers returns the names accepted by New.
+func Providers() []string {
+	names := make([]string, 0, len(providers))
+	for name := range providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// New returns the named provider. A nil client uses one with DefaultTimeout.
+func New(name string, client *http.Client) (Locator, error) {
+	newLocator, ok := providers[name]
+	if !ok {
+		return nil, fmt.Errorf("Unknown location provider %q, expected one of %s", name, strings.Join(Providers(), ", "))
+	}
+	if client == nil {
+		client = &http.Client{Timeout: DefaultTimeout}
+	}
+	return newLocator(client), nil
+}
+
+func getJSON(ctx context.Context, client *http.Client, url string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("locating by IP address: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("locating by IP address: %s", resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+// IPAPI uses the free ip-api.com service.
+type IPAPI struct {
+	HTTPClient *http.Client
+	// URL overrides the endpoint, mainly for tests.
+	URL string
+}
+
+func (p *IPAPI) Locate(ctx context.Context) (forecast.Location, error) {
+	url := p.URL
+	if url == "" {
+		url = "http://ip-api.com/json/?fields=status,message,country,city,lat,lon,timezone"
+	}
+	var body struct {
+		Status   string  `json:"status"`
+		Message  string  `json:"message"`
+		Country  string  `json:"country"`
+		City     string  `json:"city"`
+		Lat      float64 `json:"lat"`
+		Lon      float64 `json:"lon"`
+		Timezone string  `json:"timezone"`
+	}
+	if err := getJSON(ctx, p.HTTPClient, url, &body); err != nil {
+		return forecast.Location{}, err
+	}
+	if body.Status != "success" {
+		return forecast.Location{}, fmt.Errorf("ip-api: %s", body.Message)
+	}
+	return forecast.Location{
+		Name:      body.City,
+		Country:   body.Country,
+		Latitude:  body.Lat,
+		Longitude: body.Lon,
+		Timezone:  body.Timezone,
+	}, nil
+}
+
+// IPInfo uses ipinfo.io. Token is optional for low request volumes.
+type IPInfo struct {
+	HTTPClient *http.Client
+	Token      string
+	// URL overrides the endpoint, mainly for tests.
+	URL string
+}
+
+func (p *IPInfo) Locate(ctx context.Context) (forecast.Location, error) {
+	url := p.URL
+	if url == "" {
+		url = "https://ipinfo.io/json"
+	}
+	if p.Token != "" {
+		url += "?token=" + p.Token
+	}
+	var body struct {
+		City     string `json:"city"`
+		Country  string `json:"country"`
+		Loc      string `json:"loc"`
+		Timezone string `json:"timezone"`
+	}
+	if err := getJSON(ctx, p.HTTPClient, url, &body); err != nil {
+		return forecast.Location{}, err
+	}
+	lat, lon, ok := strings.Cut(body.Loc, ",")
+	if !ok {
+		return forecast.Location{}, errors.New("ipinfo: response has no coordinates")
+	}
+	place := forecast.Location{Name: body.City, Country: body.Country, Timezone: body.Timezone}
+	var err error
+	if place.Latitude, err = strconv.ParseFloat(lat, 64); err != nil {
+		return forecast.Location{}, fmt.Errorf("ipinfo: bad latitude %q", lat)
+	}
+	if place.Longitude, err = strconv.ParseFloat(lon, 64); err != nil {
+		return forecast.Location{}, fmt.Errorf("ipinfo: bad longitude %q", lon)
+	}
+	return place, nil
+}
@@ -0,0 +1,119 @@
+// Package httpclient builds the HTTP clients the app talks to its APIs
+// with. They share one transport, so connections are kept alive and reused
+// across requests, commands of -watch and the workers of -batch.
+package httpclient
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+)
+
+// UserAgent identifies the app to the APIs, unless a request sets its own.
+const UserAgent = "weather-app (github.com/gravi1984/saas-hackthon)"
+
+// maxIdlePerHost keeps a connection open for each of the parallel workers
+// fetching several cities; the default of two would close the rest after
+// every round.
+const maxIdlePerHost = 16
+
+// transport is http.DefaultTransport with more idle connections per host.
+// It negotiates HTTP/2 and, as no request sets Accept-Encoding itself, asks
+// for gzip and decompresses responses transparently.
+var transport = &http.Transport{
+	Proxy: http.ProxyFromEnvironment,
+	DialContext: (&net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+	}).DialContext,
+	ForceAttemptHTTP2:     true,
+	MaxIdleConns:          100,
+	MaxIdleConnsPerHost:   maxIdlePerHost,
+	IdleConnTimeout:       90 * time.Second,
+	TLSHandshakeTimeout:   10 * time.Second,
+	ExpectContinueTimeout: 1 * time.Second,
+}
+
+// Settings change how the shared transport connects. The zero value keeps
+// the defaults.
+type Settings struct {
+	// Proxy is an http, https or socks5 URL every request is sent through,
+	// instead of the one in $HTTPS_PROXY or $HTTP_PROXY.
+	Proxy string
+	// CACerts are PEM files whose certificates are trusted besides the
+	// system's, for networks whose proxy intercepts TLS with its own
+	// certificate authority.
+	CACerts []string
+	// Insecure skips verifying the certificates of servers, leaving
+	// connections open to anyone on the network path.
+	Insecure bool
+}
+
+// Configure applies s to the shared transport, for every client built
+// afterwards. On an error the transport is left as it was.
+func Configure(s Settings) error {
+	proxy := transport.Proxy
+	if s.Proxy != "" {
+		u, err := url.Parse(s.Proxy)
+		if err != nil || u.Host == "" {
+			return fmt.Errorf("Invalid proxy %q, expected a URL, e.g. http://proxy.example.com:8080", s.Proxy)
+		}
+		switch u.Scheme {
+		case "http", "https", "socks5":
+		default:
+			return fmt.Errorf("Unsupported proxy scheme %q, expected http, https or socks5", u.Scheme)
+		}
+		proxy = http.ProxyURL(u)
+	}
+
+	var tlsConfig *tls.Config
+	if len(s.CACerts) > 0 || s.Insecure {
+		tlsConfig = &tls.Config{InsecureSkipVerify: s.Insecure}
+	}
+	if len(s.CACerts) > 0 {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		for _, path := range s.CACerts {
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return err
+			}
+			if !pool.AppendCertsFromPEM(data) {
+				return fmt.Errorf("No PEM certificates in %s", path)
+			}
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	transport.Proxy = proxy
+	if tlsConfig != nil {
+		transport.TLSClientConfig = tlsConfig
+	}
+	return nil
+}
+
+// New returns a client on the shared transport that gives up on a request,
+// including reading its body, after timeout. Zero means no timeout.
+func New(timeout time.Duration) *http.Client {
+	return &http.Client{Timeout: timeout, Transport: userAgent{transport}}
+}
+
+// userAgent sets the User-Agent header of requests that have none.
+type userAgent struct {
+	next http.RoundTripper
+}
+
+func (t userAgent) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Header.Get("User-Agent") == "" {
+		req = req.Clone(req.Context())
+		req.Header.Set("User-Agent", UserAgent)
+	}
+	return t.next.RoundTrip(req)
+}
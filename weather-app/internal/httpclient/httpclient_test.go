@@ -0,0 +1,190 @@
+package httpclient
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"log"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// restore puts the shared transport back as it was once the test is done.
+func restore(t *testing.T) {
+	proxy, tlsConfig := transport.Proxy, transport.TLSClientConfig
+	t.Cleanup(func() {
+		transport.Proxy, transport.TLSClientConfig = proxy, tlsConfig
+		transport.CloseIdleConnections()
+	})
+	transport.TLSClientConfig = nil
+}
+
+func TestConfigureProxy(t *testing.T) {
+	tests := []struct {
+		url     string
+		wantErr bool
+	}{
+		{"http://proxy.example.com:8080", false},
+		{"https://proxy.example.com", false},
+		{"socks5://127.0.0.1:1080", false},
+		{"ftp://proxy.example.com", true},
+		{"proxy.example.com:8080", true},
+		{"http://", true},
+		{"://bad", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			restore(t)
+			transport.Proxy = nil
+			err := Configure(Settings{Proxy: tt.url})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Configure(%q) error = %v, wantErr %t", tt.url, err, tt.wantErr)
+			}
+			if tt.wantErr {
+				if transport.Proxy != nil {
+					t.Error("proxy set despite the error")
+				}
+				return
+			}
+			req, _ := http.NewRequest(http.MethodGet, "https://api.open-meteo.com/v1/forecast", nil)
+			got, err := transport.Proxy(req)
+			if err != nil || got == nil || got.String() != tt.url {
+				t.Errorf("proxy for a request = %v, %v, want %s", got, err, tt.url)
+			}
+		})
+	}
+}
+
+func TestProxyUsed(t *testing.T) {
+	restore(t)
+	var target string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		target = r.URL.String()
+	}))
+	defer proxy.Close()
+	if err := Configure(Settings{Proxy: proxy.URL}); err != nil {
+		t.Fatal(err)
+	}
+	resp, err := New(0).Get("http://api.example.com/v1/forecast")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if target != "http://api.example.com/v1/forecast" {
+		t.Errorf("proxy got a request for %q", target)
+	}
+}
+
+// tlsServer starts a server with a certificate of its own that no system
+// trusts. Its log of the rejected handshakes is discarded.
+func tlsServer(t *testing.T) *httptest.Server {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "weather-app test"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		IPAddresses:           []net.IP{net.IPv4(127, 0, 0, 1), net.IPv6loopback},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	s.Config.ErrorLog = log.New(io.Discard, "", 0)
+	s.TLS = &tls.Config{Certificates: []tls.Certificate{{Certificate: [][]byte{der}, PrivateKey: key}}}
+	s.StartTLS()
+	t.Cleanup(s.Close)
+	return s
+}
+
+// writeCACert writes the certificate of s to a PEM file and returns its path.
+func writeCACert(t *testing.T, s *httptest.Server) string {
+	path := filepath.Join(t.TempDir(), "ca.pem")
+	data := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: s.Certificate().Raw})
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestTLS(t *testing.T) {
+	tests := []struct {
+		name     string
+		caCerts  int // of the servers, in order
+		insecure bool
+		wantErr  []bool
+	}{
+		{"untrusted", 0, false, []bool{true, true}},
+		{"one CA bundle", 1, false, []bool{false, true}},
+		{"two CA bundles", 2, false, []bool{false, false}},
+		{"insecure", 0, true, []bool{false, false}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			restore(t)
+			servers := []*httptest.Server{tlsServer(t), tlsServer(t)}
+			var caCerts []string
+			for _, s := range servers[:tt.caCerts] {
+				caCerts = append(caCerts, writeCACert(t, s))
+			}
+			if err := Configure(Settings{CACerts: caCerts, Insecure: tt.insecure}); err != nil {
+				t.Fatal(err)
+			}
+			for i, s := range servers {
+				resp, err := New(0).Get(s.URL)
+				if err == nil {
+					resp.Body.Close()
+				}
+				if (err != nil) != tt.wantErr[i] {
+					t.Errorf("server %d: got error %v, wantErr %t", i, err, tt.wantErr[i])
+				}
+			}
+		})
+	}
+}
+
+func TestConfigureErrors(t *testing.T) {
+	restore(t)
+	good := writeCACert(t, tlsServer(t))
+	bad := filepath.Join(t.TempDir(), "bad.pem")
+	if err := os.WriteFile(bad, []byte("not a certificate"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name     string
+		settings Settings
+	}{
+		{"no certificates", Settings{CACerts: []string{good, bad}}},
+		{"missing file", Settings{CACerts: []string{filepath.Join(t.TempDir(), "missing.pem")}}},
+		{"bad proxy", Settings{Proxy: "ftp://proxy.example.com", CACerts: []string{good}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := Configure(tt.settings); err == nil {
+				t.Error("no error")
+			}
+			if transport.TLSClientConfig != nil {
+				t.Error("TLS config changed despite the error")
+			}
+		})
+	}
+}
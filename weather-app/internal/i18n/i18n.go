@@ -0,0 +1,154 @@
+// Package i18n translates weather descriptions, day names and dates for
+// the -lang flag and formats dates, times and numbers for -locale. English
+// and ISO dates are the defaults.
+package i18n
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"weather-app/internal/forecast"
+	"weather-app/internal/wmo"
+)
+
+// Languages lists the accepted -lang values.
+var Languages = []string{"en", "de", "fr", "es", "nl"}
+
+type language struct {
+	// weekdays are abbreviated day names, Sunday first.
+	weekdays [7]string
+	// months are abbreviated month names, January first.
+	months     [12]string
+	dateLayout string
+	conditions map[int]string
+}
+
+var english = language{
+	weekdays:   [7]string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"},
+	months:     [12]string{"Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"},
+	dateLayout: "2006-01-02",
+}
+
+var languages = map[string]language{
+	"en": english,
+	"de": {
+		weekdays:   [7]string{"So", "Mo", "Di", "Mi", "Do", "Fr", "Sa"},
+		months:     [12]string{"Jan", "Feb", "Mär", "Apr", "Mai", "Jun", "Jul", "Aug", "Sep", "Okt", "Nov", "Dez"},
+		dateLayout: "02.01.2006",
+		conditions: map[int]string{
+			0: "Klarer Himmel", 1: "Überwiegend klar", 2: "Teilweise bewölkt", 3: "Bedeckt",
+			45: "Nebel", 48: "Nebel mit Reifablagerung",
+			51: "Leichter Nieselregen", 53: "Mäßiger Nieselregen", 55: "Starker Nieselregen",
+			56: "Leichter gefrierender Nieselregen", 57: "Starker gefrierender Nieselregen",
+			61: "Leichter Regen", 63: "Mäßiger Regen", 65: "Starker Regen",
+			66: "Leichter gefrierender Regen", 67: "Starker gefrierender Regen",
+			71: "Leichter Schneefall", 73: "Mäßiger Schneefall", 75: "Starker Schneefall", 77: "Schneegriesel",
+			80: "Leichte Regenschauer", 81: "Mäßige Regenschauer", 82: "Heftige Regenschauer",
+			85: "Leichte Schneeschauer", 86: "Starke Schneeschauer",
+			95: "Gewitter", 96: "Gewitter mit leichtem Hagel", 99: "Gewitter mit starkem Hagel",
+		},
+	},
+	"fr": {
+		weekdays:   [7]string{"dim.", "lun.", "mar.", "mer.", "jeu.", "ven.", "sam."},
+		months:     [12]string{"jan", "fév", "mar", "avr", "mai", "jun", "jul", "aoû", "sep", "oct", "nov", "déc"},
+		dateLayout: "02/01/2006",
+		conditions: map[int]string{
+			0: "Ciel dégagé", 1: "Plutôt dégagé", 2: "Partiellement nuageux", 3: "Couvert",
+			45: "Brouillard", 48: "Brouillard givrant",
+			51: "Bruine légère", 53: "Bruine modérée", 55: "Bruine dense",
+			56: "Bruine verglaçante légère", 57: "Bruine verglaçante dense",
+			61: "Pluie faible", 63: "Pluie modérée", 65: "Pluie forte",
+			66: "Pluie verglaçante faible", 67: "Pluie verglaçante forte",
+			71: "Neige faible", 73: "Neige modérée", 75: "Neige forte", 77: "Neige en grains",
+			80: "Averses de pluie faibles", 81: "Averses de pluie modérées", 82: "Averses de pluie violentes",
+			85: "Averses de neige faibles", 86: "Averses de neige fortes",
+			95: "Orage", 96: "Orage avec grêle faible", 99: "Orage avec grêle forte",
+		},
+	},
+	"es": {
+		weekdays:   [7]string{"dom", "lun", "mar", "mié", "jue", "vie", "sáb"},
+		months:     [12]string{"ene", "feb", "mar", "abr", "may", "jun", "jul", "ago", "sep", "oct", "nov", "dic"},
+		dateLayout: "02/01/2006",
+		conditions: map[int]string{
+			0: "Cielo despejado", 1: "Mayormente despejado", 2: "Parcialmente nublado", 3: "Cubierto",
+			45: "Niebla", 48: "Niebla con escarcha",
+			51: "Llovizna ligera", 53: "Llovizna moderada", 55: "Llovizna densa",
+			56: "Llovizna helada ligera", 57: "Llovizna helada densa",
+			61: "Lluvia ligera", 63: "Lluvia moderada", 65: "Lluvia fuerte",
+			66: "Lluvia helada ligera", 67: "Lluvia helada fuerte",
+			71: "Nevada ligera", 73: "Nevada moderada", 75: "Nevada fuerte", 77: "Granos de nieve",
+			80: "Chubascos ligeros", 81: "Chubascos moderados", 82: "Chubascos violentos",
+			85: "Chubascos de nieve ligeros", 86: "Chubascos de nieve fuertes",
+			95: "Tormenta", 96: "Tormenta con granizo ligero", 99: "Tormenta con granizo fuerte",
+		},
+	},
+	"nl": {
+		weekdays:   [7]string{"zo", "ma", "di", "wo", "do", "vr", "za"},
+		months:     [12]string{"jan", "feb", "mrt", "apr", "mei", "jun", "jul", "aug", "sep", "okt", "nov", "dec"},
+		dateLayout: "02-01-2006",
+		conditions: map[int]string{
+			0: "Onbewolkt", 1: "Overwegend helder", 2: "Half bewolkt", 3: "Bewolkt",
+			45: "Mist", 48: "Mist met rijp",
+			51: "Lichte motregen", 53: "Matige motregen", 55: "Dichte motregen",
+			56: "Lichte onderkoelde motregen", 57: "Dichte onderkoelde motregen",
+			61: "Lichte regen", 63: "Matige regen", 65: "Zware regen",
+			66: "Lichte onderkoelde regen", 67: "Zware onderkoelde regen",
+			71: "Lichte sneeuwval", 73: "Matige sneeuwval", 75: "Zware sneeuwval", 77: "Motsneeuw",
+			80: "Lichte regenbuien", 81: "Matige regenbuien", 82: "Zware regenbuien",
+			85: "Lichte sneeuwbuien", 86: "Zware sneeuwbuien",
+			95: "Onweer", 96: "Onweer met lichte hagel", 99: "Onweer met zware hagel",
+		},
+	},
+}
+
+// Parse checks a -lang value; the empty string is English.
+func Parse(lang string) (string, error) {
+	lang = strings.ToLower(lang)
+	if lang == "" {
+		return "en", nil
+	}
+	if !slices.Contains(Languages, lang) {
+		return "", fmt.Errorf("Unknown language %q, expected one of %s", lang, strings.Join(Languages, ", "))
+	}
+	return lang, nil
+}
+
+func lookup(lang string) language {
+	if l, ok := languages[lang]; ok {
+		return l
+	}
+	return english
+}
+
+// Description describes a weather code in lang, in English when there is
+// no translation.
+func Description(code int, lang string) string {
+	if d, ok := lookup(lang).conditions[code]; ok {
+		return d
+	}
+	return wmo.Description(code)
+}
+
+// Translate returns f with the descriptions of its weather codes in lang.
+func Translate(f forecast.Forecast, lang string) forecast.Forecast {
+	if lookup(lang).conditions == nil {
+		return f
+	}
+	if f.Current != nil {
+		c := *f.Current
+		c.Description = Description(c.WeatherCode, lang)
+		f.Current = &c
+	}
+	if f.Days != nil {
+		days := make([]forecast.Day, len(f.Days))
+		for i, day := range f.Days {
+			if day.WeatherCode != nil {
+				day.Description = Description(*day.WeatherCode, lang)
+			}
+			days[i] = day
+		}
+		f.Days = days
+	}
+	return f
+}
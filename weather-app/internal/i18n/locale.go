@@ -0,0 +1,155 @@
+package i18n
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Locale formats dates, times and numbers in table output. The zero Locale
+// writes ISO dates, 24-hour times and decimal points.
+type Locale struct {
+	// Lang is the language of day and month names.
+	Lang string
+	// dates is a time layout in which Mon and Jan stand for the day and
+	// month names of Lang. Empty means the numeric date of Lang.
+	dates  string
+	comma  bool
+	hour12 bool
+}
+
+var locales = map[string]Locale{
+	"en_US": {Lang: "en", dates: "Mon Jan _2", hour12: true},
+	"en_CA": {Lang: "en", dates: "Mon Jan _2", hour12: true},
+	"en_AU": {Lang: "en", dates: "Mon _2 Jan", hour12: true},
+	"en_GB": {Lang: "en", dates: "Mon _2 Jan"},
+	"en_IE": {Lang: "en", dates: "Mon _2 Jan"},
+	"de_DE": {Lang: "de", dates: "Mon _2. Jan", comma: true},
+	"de_AT": {Lang: "de", dates: "Mon _2. Jan", comma: true},
+	"de_CH": {Lang: "de", dates: "Mon _2. Jan"},
+	"fr_FR": {Lang: "fr", dates: "Mon _2 Jan", comma: true},
+	"fr_BE": {Lang: "fr", dates: "Mon _2 Jan", comma: true},
+	"fr_CA": {Lang: "fr", dates: "Mon _2 Jan", comma: true},
+	"es_ES": {Lang: "es", dates: "Mon _2 Jan", comma: true},
+	"es_MX": {Lang: "es", dates: "Mon _2 Jan", hour12: true},
+	"nl_NL": {Lang: "nl", dates: "Mon _2 Jan", comma: true},
+	"nl_BE": {Lang: "nl", dates: "Mon _2 Jan", comma: true},
+}
+
+// regions picks the locale of a language given without a region, or with
+// one that has no entry in locales.
+var regions = map[string]string{
+	"en": "en_US",
+	"de": "de_DE",
+	"fr": "fr_FR",
+	"es": "es_ES",
+	"nl": "nl_NL",
+}
+
+// Locales lists the accepted -locale values.
+func Locales() []string {
+	names := []string{"C"}
+	for name := range locales {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// ParseLocale reads a locale name such as de_DE, de-DE, de or
+// de_DE.UTF-8. C and POSIX, like the empty string, keep ISO formats.
+func ParseLocale(name string) (Locale, error) {
+	name, _, _ = strings.Cut(name, ".")
+	name, _, _ = strings.Cut(name, "@")
+	if name == "" || name == "C" || name == "POSIX" {
+		return Locale{}, nil
+	}
+	lang, region, _ := strings.Cut(strings.ReplaceAll(name, "-", "_"), "_")
+	lang = strings.ToLower(lang)
+	if l, ok := locales[lang+"_"+strings.ToUpper(region)]; ok {
+		return l, nil
+	}
+	if l, ok := locales[regions[lang]]; ok {
+		return l, nil
+	}
+	return Locale{}, fmt.Errorf("Unknown locale %q, expected e.g. en_US, en_GB, de_DE or fr_FR", name)
+}
+
+// FromEnv is the locale of LC_ALL or, when unset, LC_TIME. Locales it does
+// not know keep ISO formats.
+func FromEnv() Locale {
+	name := os.Getenv("LC_ALL")
+	if name == "" {
+		name = os.Getenv("LC_TIME")
+	}
+	l, err := ParseLocale(name)
+	if err != nil {
+		return Locale{}
+	}
+	return l
+}
+
+// Weekday is the abbreviated name of the day of t.
+func (l Locale) Weekday(t time.Time) string {
+	return lookup(l.Lang).weekdays[t.Weekday()]
+}
+
+// Date formats the date of t, e.g. "Mon Jun  3" in en_US, or 2024-06-03
+// and 03.06.2024 without a locale in English and German.
+func (l Locale) Date(t time.Time) string {
+	if l.dates == "" {
+		return t.Format(lookup(l.Lang).dateLayout)
+	}
+	names := lookup(l.Lang)
+	var b strings.Builder
+	layout := l.dates
+	for layout != "" {
+		switch {
+		case strings.HasPrefix(layout, "Mon"):
+			b.WriteString(names.weekdays[t.Weekday()])
+			layout = layout[3:]
+		case strings.HasPrefix(layout, "Jan"):
+			b.WriteString(names.months[t.Month()-1])
+			layout = layout[3:]
+		default:
+			end := len(layout)
+			for _, name := range []string{"Mon", "Jan"} {
+				if i := strings.Index(layout, name); i >= 0 {
+					end = min(end, i)
+				}
+			}
+			b.WriteString(t.Format(layout[:end]))
+			layout = layout[end:]
+		}
+	}
+	return b.String()
+}
+
+// Day is Date with the day name in front, unless Date already has it.
+func (l Locale) Day(t time.Time) string {
+	if strings.Contains(l.dates, "Mon") {
+		return l.Date(t)
+	}
+	return l.Weekday(t) + " " + l.Date(t)
+}
+
+// Time formats the time of day of t, e.g. 21:52 or 9:52 PM.
+func (l Locale) Time(t time.Time) string {
+	if l.hour12 {
+		return t.Format("3:04 PM")
+	}
+	return t.Format("15:04")
+}
+
+// Number formats v with the given number of decimals and the locale's
+// decimal separator.
+func (l Locale) Number(v float64, decimals int) string {
+	s := strconv.FormatFloat(v, 'f', decimals, 64)
+	if l.comma {
+		s = strings.Replace(s, ".", ",", 1)
+	}
+	return s
+}
@@ -0,0 +1,62 @@
+// Package notify shows native desktop notifications by running the
+// platform's notification tool: notify-send (libnotify) on Linux and the BSDs,
+// osascript on macOS and a PowerShell toast on Windows.
+package notify
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// AppName is shown as the sender of the notification where supported.
+const AppName = "weather-app"
+
+// windowsToast shows a toast from the WEATHER_APP_TITLE and WEATHER_APP_BODY
+// environment variables, which avoids quoting the text into the script.
+const windowsToast = `
+[Windows.UI.Notifications.ToastNotificationManager, Windows.UI.Notifications, ContentType = WindowsRuntime] > $null
+$template = [Windows.UI.Notifications.ToastNotificationManager]::GetTemplateContent([Windows.UI.Notifications.ToastTemplateType]::ToastText02)
+$text = $template.GetElementsByTagName('text')
+$text.Item(0).AppendChild($template.CreateTextNode($env:WEATHER_APP_TITLE)) > $null
+$text.Item(1).AppendChild($template.CreateTextNode($env:WEATHER_APP_BODY)) > $null
+$toast = [Windows.UI.Notifications.ToastNotification]::new($template)
+[Windows.UI.Notifications.ToastNotificationManager]::CreateToastNotifier('` + AppName + `').Show($toast)
+`
+
+// command returns the command that shows a notification on this platform.
+func command(ctx context.Context, title, body string) (*exec.Cmd, error) {
+	switch runtime.GOOS {
+	case "darwin":
+		return exec.CommandContext(ctx, "osascript",
+			"-e", "on run argv",
+			"-e", "display notification (item 2 of argv) with title (item 1 of argv)",
+			"-e", "end run",
+			title, body), nil
+	case "windows":
+		cmd := exec.CommandContext(ctx, "powershell", "-NoProfile", "-NonInteractive", "-Command", windowsToast)
+		cmd.Env = append(os.Environ(), "WEATHER_APP_TITLE="+title, "WEATHER_APP_BODY="+body)
+		return cmd, nil
+	case "linux", "freebsd", "openbsd", "netbsd":
+		return exec.CommandContext(ctx, "notify-send", "--app-name", AppName, title, body), nil
+	}
+	return nil, fmt.Errorf("Desktop notifications are not supported on %s", runtime.GOOS)
+}
+
+// Send shows a notification with title and body.
+func Send(ctx context.Context, title, body string) error {
+	cmd, err := command(ctx, title, body)
+	if err != nil {
+		return err
+	}
+	if out, err := cmd.CombinedOutput(); err != nil {
+		if msg := strings.TrimSpace(string(out)); msg != "" {
+			return fmt.Errorf("%s: %w: %s", cmd.Args[0], err, msg)
+		}
+		return fmt.Errorf("%s: %w", cmd.Args[0], err)
+	}
+	return nil
+}
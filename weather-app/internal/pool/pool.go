@@ -0,0 +1,55 @@
+// Package pool runs jobs on a bounded number of workers and collects every
+// result, so that one failing job does not hide the others.
+package pool
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Result is the outcome of one job.
+type Result[T any] struct {
+	Value T
+	Err   error
+}
+
+// Run calls fn for every job on at most workers goroutines and returns the
+// results in the order of the jobs. Each call gets its own context, which
+// is cancelled when the call returns or, when timeout is positive, after
+// timeout. Jobs not yet started when ctx is done fail with its error.
+func Run[J, T any](ctx context.Context, workers int, timeout time.Duration, jobs []J, fn func(context.Context, J) (T, error)) []Result[T] {
+	results := make([]Result[T], len(jobs))
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for range max(1, min(workers, len(jobs))) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				results[i] = run(ctx, timeout, jobs[i], fn)
+			}
+		}()
+	}
+	for i := range jobs {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+	return results
+}
+
+func run[J, T any](ctx context.Context, timeout time.Duration, job J, fn func(context.Context, J) (T, error)) Result[T] {
+	if err := ctx.Err(); err != nil {
+		return Result[T]{Err: err}
+	}
+	var cancel context.CancelFunc
+	if timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+	} else {
+		ctx, cancel = context.WithCancel(ctx)
+	}
+	defer cancel()
+	v, err := fn(ctx, job)
+	return Result[T]{Value: v, Err: err}
+}
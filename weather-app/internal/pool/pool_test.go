@@ -0,0 +1,107 @@
+package pool
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestResultsInJobOrder(t *testing.T) {
+	jobs := []int{5, 1, 4, 2, 3}
+	for _, workers := range []int{0, 1, 2, len(jobs), 10} {
+		// Later jobs finish first, so results arrive out of order.
+		results := Run(context.Background(), workers, 0, jobs, func(ctx context.Context, n int) (int, error) {
+			time.Sleep(time.Duration(n) * time.Millisecond)
+			if n == 4 {
+				return 0, errors.New("four")
+			}
+			return n * 10, nil
+		})
+		if len(results) != len(jobs) {
+			t.Fatalf("%d workers: got %d results, want %d", workers, len(results), len(jobs))
+		}
+		for i, n := range jobs {
+			r := results[i]
+			if n == 4 {
+				if r.Err == nil || r.Err.Error() != "four" {
+					t.Errorf("%d workers: job %d: got error %v, want four", workers, i, r.Err)
+				}
+				continue
+			}
+			if r.Err != nil || r.Value != n*10 {
+				t.Errorf("%d workers: job %d = %d, %v, want %d", workers, i, r.Value, r.Err, n*10)
+			}
+		}
+	}
+}
+
+func TestWorkerLimit(t *testing.T) {
+	var running, most atomic.Int32
+	Run(context.Background(), 3, 0, make([]struct{}, 12), func(ctx context.Context, _ struct{}) (struct{}, error) {
+		n := running.Add(1)
+		for {
+			m := most.Load()
+			if n <= m || most.CompareAndSwap(m, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		running.Add(-1)
+		return struct{}{}, nil
+	})
+	if got := most.Load(); got > 3 {
+		t.Errorf("%d jobs ran at once, want at most 3", got)
+	}
+}
+
+func TestCancelledJobsNotStarted(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var started atomic.Int32
+	results := Run(ctx, 1, 0, []int{0, 1, 2, 3}, func(ctx context.Context, i int) (int, error) {
+		started.Add(1)
+		if i == 1 {
+			cancel()
+		}
+		return i, nil
+	})
+	if n := started.Load(); n != 2 {
+		t.Errorf("started %d jobs, want 2", n)
+	}
+	for i, r := range results {
+		if wantErr := i >= 2; wantErr != errors.Is(r.Err, context.Canceled) {
+			t.Errorf("job %d: got error %v", i, r.Err)
+		}
+	}
+}
+
+func TestPerJobTimeout(t *testing.T) {
+	results := Run(context.Background(), 2, 20*time.Millisecond, []time.Duration{0, time.Minute}, func(ctx context.Context, d time.Duration) (string, error) {
+		select {
+		case <-time.After(d):
+			return "done", nil
+		case <-ctx.Done():
+			return "", ctx.Err()
+		}
+	})
+	if results[0].Err != nil || results[0].Value != "done" {
+		t.Errorf("quick job = %q, %v, want done", results[0].Value, results[0].Err)
+	}
+	if !errors.Is(results[1].Err, context.DeadlineExceeded) {
+		t.Errorf("slow job: got %v, want a deadline error", results[1].Err)
+	}
+}
+
+func TestContextCancelledAfterJob(t *testing.T) {
+	var done []context.Context
+	Run(context.Background(), 1, 0, []int{1, 2}, func(ctx context.Context, _ int) (int, error) {
+		done = append(done, ctx)
+		return 0, nil
+	})
+	for i, ctx := range done {
+		if ctx.Err() == nil {
+			t.Errorf("job %d: context still live after the job returned", i)
+		}
+	}
+}
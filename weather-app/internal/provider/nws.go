@@ -0,0 +1,235 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"weather-app/internal/forecast"
+	"weather-app/internal/units"
+	"weather-app/pkg/nws"
+)
+
+// NWS serves forecasts for US locations from the National Weather Service.
+// It has no geocoding of its own, so places are looked up with Geocoder.
+type NWS struct {
+	Client   *nws.Client
+	Geocoder Geocoder
+}
+
+// errModel is returned when a weather model is chosen: NWS publishes a
+// single forecast.
+var errModel = errors.New("nws has no choice of weather model, try -provider open-meteo")
+
+func (p *NWS) Name() string {
+	return "nws"
+}
+
+func (p *NWS) Geocode(ctx context.Context, name, country string) ([]Place, error) {
+	return p.Geocoder.Geocode(ctx, name, country)
+}
+
+func (p *NWS) GeocodePostcode(ctx context.Context, code, country string) ([]Place, error) {
+	postcodes, ok := p.Geocoder.(PostcodeGeocoder)
+	if !ok {
+		return nil, errors.New("Postal codes cannot be looked up with this geocoder")
+	}
+	return postcodes.GeocodePostcode(ctx, code, country)
+}
+
+// compassDegrees maps the 16 compass points NWS reports wind directions in
+// to degrees.
+var compassDegrees = map[string]float64{
+	"N": 0, "NNE": 22.5, "NE": 45, "ENE": 67.5,
+	"E": 90, "ESE": 112.5, "SE": 135, "SSE": 157.5,
+	"S": 180, "SSW": 202.5, "SW": 225, "WSW": 247.5,
+	"W": 270, "WNW": 292.5, "NW": 315, "NNW": 337.5,
+}
+
+func windDirection(s string) *float64 {
+	d, ok := compassDegrees[s]
+	if !ok {
+		return nil
+	}
+	return &d
+}
+
+// windSpeed parses speeds such as "10 mph" or "5 to 10 mph", keeping the
+// upper bound, and returns them in km/h.
+func windSpeed(s string) *float64 {
+	fields := strings.Fields(s)
+	if len(fields) < 2 {
+		return nil
+	}
+	v, err := strconv.ParseFloat(fields[len(fields)-2], 64)
+	if err != nil {
+		return nil
+	}
+	from := units.MilesPerHour
+	if fields[len(fields)-1] == "km/h" {
+		from = units.KilometresPerHour
+	}
+	kmh := math.Round(units.Speed(v, from, units.KilometresPerHour)*10) / 10
+	return &kmh
+}
+
+// celsius converts a period temperature, which NWS reports in Fahrenheit by
+// default.
+func celsius(period nws.Period) float64 {
+	from := units.Fahrenheit
+	if period.TemperatureUnit == "C" {
+		from = units.Celsius
+	}
+	return math.Round(units.Temperature(period.Temperature, from, units.Celsius)*10) / 10
+}
+
+// point looks up the grid cell for place and fills in its time zone, which
+// the forecast times are shown in.
+func (p *NWS) point(ctx context.Context, place *forecast.Location) (*nws.Point, *time.Location, error) {
+	pt, err := p.Client.Points(ctx, place.Latitude, place.Longitude)
+	if err != nil {
+		return nil, nil, err
+	}
+	place.Timezone = pt.TimeZone
+	loc, err := time.LoadLocation(pt.TimeZone)
+	if err != nil {
+		loc = time.Local
+	}
+	return pt, loc, nil
+}
+
+// DailyForecast combines each daytime period with the night that follows.
+// Tonight's period on its own, and a last day without its night, are left
+// out since they lack a high or a low.
+func (p *NWS) DailyForecast(ctx context.Context, place forecast.Location, opts Options) (forecast.Forecast, error) {
+	if opts.Model != "" {
+		return forecast.Forecast{}, errModel
+	}
+	pt, loc, err := p.point(ctx, &place)
+	if err != nil {
+		return forecast.Forecast{}, err
+	}
+	periods, err := p.Client.Forecast(ctx, pt.Forecast)
+	if err != nil {
+		return forecast.Forecast{}, err
+	}
+
+	f := forecast.Forecast{Location: place, Units: units.Metric.Units()}
+	for i := 0; i+1 < len(periods); i++ {
+		day, night := periods[i], periods[i+1]
+		if !day.IsDaytime || night.IsDaytime {
+			continue
+		}
+		start := day.StartTime.In(loc)
+		d := forecast.Day{
+			Date:        forecast.Date{Time: time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, loc)},
+			TempMax:     celsius(day),
+			TempMin:     celsius(night),
+			Description: day.ShortForecast,
+		}
+		if opts.Precipitation {
+			d.PrecipChance = maxValue(day.ProbabilityOfPrecipitation.Value, night.ProbabilityOfPrecipitation.Value)
+		}
+		if opts.Wind {
+			d.WindSpeedMax = windSpeed(day.WindSpeed)
+			d.WindDirection = windDirection(day.WindDirection)
+		}
+		f.Days = append(f.Days, d)
+		if opts.Days > 0 && len(f.Days) == opts.Days {
+			break
+		}
+	}
+	return f, nil
+}
+
+func maxValue(a, b *float64) *float64 {
+	switch {
+	case a == nil:
+		return b
+	case b == nil || *a >= *b:
+		return a
+	}
+	return b
+}
+
+func (p *NWS) HourlyForecast(ctx context.Context, place forecast.Location, opts Options, hours int) (forecast.Forecast, error) {
+	if opts.Model != "" {
+		return forecast.Forecast{}, errModel
+	}
+	pt, loc, err := p.point(ctx, &place)
+	if err != nil {
+		return forecast.Forecast{}, err
+	}
+	periods, err := p.Client.Forecast(ctx, pt.ForecastHourly)
+	if err != nil {
+		return forecast.Forecast{}, err
+	}
+
+	f := forecast.Forecast{Location: place, Units: units.Metric.Units()}
+	for i, period := range periods {
+		if i == hours {
+			break
+		}
+		f.Hours = append(f.Hours, forecast.Hour{
+			Time:              period.StartTime.In(loc),
+			Temperature:       celsius(period),
+			PrecipProbability: period.ProbabilityOfPrecipitation.Value,
+			WindSpeed:         windSpeed(period.WindSpeed),
+			WindDirection:     windDirection(period.WindDirection),
+			Humidity:          period.RelativeHumidity.Value,
+			DewPoint:          period.Dewpoint.Value,
+		})
+	}
+	return f, nil
+}
+
+// severityRank orders alerts by the CAP severity levels NWS uses.
+var severityRank = map[string]int{
+	"Extreme":  0,
+	"Severe":   1,
+	"Moderate": 2,
+	"Minor":    3,
+}
+
+func rank(severity string) int {
+	if r, ok := severityRank[severity]; ok {
+		return r
+	}
+	return len(severityRank)
+}
+
+// Alerts returns the active alerts for place, most severe first.
+func (p *NWS) Alerts(ctx context.Context, place forecast.Location) ([]forecast.Alert, error) {
+	active, err := p.Client.Alerts(ctx, place.Latitude, place.Longitude)
+	if err != nil {
+		return nil, err
+	}
+	alerts := make([]forecast.Alert, len(active))
+	for i, a := range active {
+		alert := forecast.Alert{
+			Event:       a.Event,
+			Severity:    a.Severity,
+			Headline:    a.Headline,
+			Description: a.Description,
+			Instruction: a.Instruction,
+			Start:       a.Effective,
+			End:         a.Ends,
+		}
+		if a.Onset != nil {
+			alert.Start = *a.Onset
+		}
+		if alert.End == nil && !a.Expires.IsZero() {
+			expires := a.Expires
+			alert.End = &expires
+		}
+		alerts[i] = alert
+	}
+	sort.SliceStable(alerts, func(i, j int) bool {
+		return rank(alerts[i].Severity) < rank(alerts[j].Severity)
+	})
+	return alerts, nil
+}
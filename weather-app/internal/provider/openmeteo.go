@@ -0,0 +1,715 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"weather-app/internal/forecast"
+	"weather-app/internal/units"
+	"weather-app/internal/wmo"
+	"weather-app/pkg/openmeteo"
+)
+
+// OpenMeteo serves forecasts and geocoding from open-meteo.com.
+type OpenMeteo struct {
+	Client *openmeteo.Client
+}
+
+func (p *OpenMeteo) Name() string {
+	return "open-meteo"
+}
+
+func (p *OpenMeteo) Geocode(ctx context.Context, name, country string) ([]Place, error) {
+	results, err := p.Client.FindCities(ctx, name, country)
+	if err != nil {
+		return nil, err
+	}
+	return places(results), nil
+}
+
+func (p *OpenMeteo) GeocodePostcode(ctx context.Context, code, country string) ([]Place, error) {
+	results, err := p.Client.FindPostcode(ctx, code, country)
+	if err != nil {
+		return nil, err
+	}
+	return places(results), nil
+}
+
+// Elsewhere returns the places in other countries that Geocode found when
+// none were in the requested one, best match first, or nil for any other
+// error.
+func Elsewhere(err error) []Place {
+	var nf *openmeteo.NotFoundError
+	if !errors.As(err, &nf) {
+		return nil
+	}
+	return places(nf.Elsewhere)
+}
+
+func places(results []openmeteo.GeocodingResult) []Place {
+	places := make([]Place, len(results))
+	for i, r := range results {
+		places[i] = Place{
+			Location: forecast.Location{
+				Name:      r.Name,
+				Country:   r.Country,
+				Latitude:  r.Latitude,
+				Longitude: r.Longitude,
+			},
+			Region:     r.Admin1,
+			Population: r.Population,
+		}
+	}
+	return places
+}
+
+func dailyVariables(opts Options) []string {
+	daily := []string{"temperature_2m_max", "temperature_2m_min", "weathercode"}
+	if opts.Precipitation {
+		daily = append(daily, "precipitation_sum", "precipitation_probability_max", "precipitation_hours")
+	}
+	if opts.Sunrise {
+		daily = append(daily, "sunrise")
+	}
+	if opts.Sunset {
+		daily = append(daily, "sunset")
+	}
+	if opts.Daylight {
+		daily = append(daily, "daylight_duration", "sunshine_duration")
+	}
+	if opts.UVIndex {
+		daily = append(daily, "uv_index_max")
+	}
+	if opts.Snow {
+		daily = append(daily, "snowfall_sum")
+	}
+	if opts.Feels {
+		daily = append(daily, "apparent_temperature_max", "apparent_temperature_min")
+	}
+	if opts.Wind {
+		daily = append(daily, "windspeed_10m_max", "windgusts_10m_max", "winddirection_10m_dominant")
+	}
+	return daily
+}
+
+// humidityVariables are fetched hourly, also for daily forecasts, which
+// summarise them per day.
+var humidityVariables = []string{"relative_humidity_2m", "dew_point_2m"}
+
+// cloudVariables are likewise hourly only.
+var cloudVariables = []string{"cloud_cover", "visibility"}
+
+// request returns a forecast request for place without any variables set,
+// from model if one is given. Values are always requested in metric units.
+func request(place forecast.Location, model string) openmeteo.ForecastRequest {
+	req := openmeteo.ForecastRequest{
+		Latitude:  place.Latitude,
+		Longitude: place.Longitude,
+	}
+	if model != "" {
+		req.Models = []string{model}
+	}
+	return req
+}
+
+func (p *OpenMeteo) DailyForecast(ctx context.Context, place forecast.Location, opts Options) (forecast.Forecast, error) {
+	req := request(place, opts.Model)
+	req.Elevation = opts.Elevation
+	req.Daily = dailyVariables(opts)
+	req.ForecastDays = opts.Days
+	req.PastDays = opts.PastDays
+	if opts.Humidity {
+		req.Hourly = append(req.Hourly, humidityVariables...)
+	}
+	if opts.Pressure {
+		req.Hourly = append(req.Hourly, "surface_pressure")
+	}
+	if opts.Clouds {
+		req.Hourly = append(req.Hourly, cloudVariables...)
+	}
+	resp, err := p.Client.Forecast(ctx, req)
+	if err != nil {
+		return forecast.Forecast{}, err
+	}
+	f, err := dailyForecast(resp, place, units.Metric.Units(), opts.PastDays)
+	f.Model = opts.Model
+	f.Cached = resp.Cached
+	if err != nil || !opts.Ensemble {
+		return f, err
+	}
+	return f, p.addSpread(ctx, place, opts.Days, f.Days)
+}
+
+// markObserved flags the days before today, which are the first pastDays
+// of the response.
+func markObserved(daily *openmeteo.DailyData, pastDays int, days []forecast.Day) {
+	if daily == nil {
+		return
+	}
+	for i := range days {
+		days[i].Observed = pastDays >= len(daily.Time) || days[i].Date.String() < daily.Time[pastDays]
+	}
+}
+
+// ensembleModel is the ensemble asked for the spread of the daily
+// temperatures. Its 51 members reach 15 days ahead.
+const ensembleModel = "ecmwf_ifs025"
+
+// addSpread fills in the 10th and 90th percentile of the ensemble members'
+// highs and lows. Days beyond the ensemble's range keep no spread.
+func (p *OpenMeteo) addSpread(ctx context.Context, place forecast.Location, days int, out []forecast.Day) error {
+	req := request(place, "")
+	req.Daily = []string{"temperature_2m_max", "temperature_2m_min"}
+	req.Models = []string{ensembleModel}
+	req.ForecastDays = days
+	resp, err := p.Client.Ensemble(ctx, req)
+	if err != nil {
+		return fmt.Errorf("ensemble: %w", err)
+	}
+	if resp.Daily == nil {
+		return nil
+	}
+
+	index := map[string]int{}
+	for i, date := range resp.Daily.Time {
+		index[date] = i
+	}
+	for i := range out {
+		j, ok := index[out[i].Date.String()]
+		if !ok {
+			continue
+		}
+		out[i].TempMaxP10, out[i].TempMaxP90 = spread(resp.Daily.Members["temperature_2m_max"], j)
+		out[i].TempMinP10, out[i].TempMinP90 = spread(resp.Daily.Members["temperature_2m_min"], j)
+	}
+	return nil
+}
+
+// spread returns the 10th and 90th percentile of the members' values at i,
+// or nils when no member has one.
+func spread(members [][]*float64, i int) (p10, p90 *float64) {
+	var values []float64
+	for _, m := range members {
+		if v := nullableAt(m, i); v != nil {
+			values = append(values, *v)
+		}
+	}
+	if len(values) == 0 {
+		return nil, nil
+	}
+	slices.Sort(values)
+	low, high := percentile(values, 0.1), percentile(values, 0.9)
+	return &low, &high
+}
+
+// percentile interpolates between the two closest of the sorted values,
+// rounded to 0.1.
+func percentile(sorted []float64, p float64) float64 {
+	pos := p * float64(len(sorted)-1)
+	i := int(pos)
+	v := sorted[i]
+	if i+1 < len(sorted) {
+		v += (sorted[i+1] - v) * (pos - float64(i))
+	}
+	return math.Round(v*10) / 10
+}
+
+func (p *OpenMeteo) HourlyForecast(ctx context.Context, place forecast.Location, opts Options, hours int) (forecast.Forecast, error) {
+	req := request(place, opts.Model)
+	req.Elevation = opts.Elevation
+	req.Hourly = []string{"temperature_2m", "precipitation_probability", "wind_speed_10m", "wind_direction_10m"}
+	if opts.Precipitation {
+		req.Hourly = append(req.Hourly, "precipitation")
+	}
+	if opts.Snow {
+		req.Hourly = append(req.Hourly, "snow_depth")
+	}
+	if opts.Humidity {
+		req.Hourly = append(req.Hourly, humidityVariables...)
+	}
+	if opts.Pressure {
+		req.Hourly = append(req.Hourly, "surface_pressure")
+	}
+	if opts.Clouds {
+		req.Hourly = append(req.Hourly, cloudVariables...)
+	}
+	req.ForecastHours = hours
+	resp, err := p.Client.Forecast(ctx, req)
+	if err != nil {
+		return forecast.Forecast{}, err
+	}
+	f, err := hourlyForecast(resp, place, units.Metric.Units())
+	f.Model = opts.Model
+	return f, err
+}
+
+func (p *OpenMeteo) CurrentForecast(ctx context.Context, place forecast.Location) (forecast.Forecast, error) {
+	req := request(place, "")
+	req.CurrentWeather = true
+	resp, err := p.Client.Forecast(ctx, req)
+	if err != nil {
+		return forecast.Forecast{}, err
+	}
+	return currentForecast(resp, place, units.Metric.Units())
+}
+
+// Archive returns the recorded daily weather between start and end, given as
+// YYYY-MM-DD. The archive has no probabilities, so those are never asked for.
+func (p *OpenMeteo) Archive(ctx context.Context, place forecast.Location, opts Options, start, end string) (forecast.Forecast, error) {
+	req := request(place, "")
+	req.Daily = slices.DeleteFunc(dailyVariables(opts), func(v string) bool { return v == "precipitation_probability_max" })
+	req.StartDate = start
+	req.EndDate = end
+	resp, err := p.Client.Archive(ctx, req)
+	if err != nil {
+		return forecast.Forecast{}, err
+	}
+	return dailyForecast(resp, place, units.Metric.Units(), 0)
+}
+
+// marineVariables are the daily wave and swell variables of the marine API.
+var marineVariables = []string{
+	"wave_height_max", "wave_period_max", "wave_direction_dominant",
+	"swell_wave_height_max", "swell_wave_period_max", "swell_wave_direction_dominant",
+}
+
+// MarineForecast returns the daily wave and swell forecast. Days without any
+// marine value, as on land away from the coast, are left out.
+func (p *OpenMeteo) MarineForecast(ctx context.Context, place forecast.Location, days int) (forecast.Forecast, error) {
+	req := request(place, "")
+	req.Daily = marineVariables
+	req.ForecastDays = days
+	resp, err := p.Client.Marine(ctx, req)
+	if err != nil {
+		return forecast.Forecast{}, err
+	}
+	return marineForecast(resp, place)
+}
+
+// pollenVariables are the hourly pollen variables of the air quality API.
+var pollenVariables = []string{
+	"alder_pollen", "birch_pollen", "grass_pollen", "mugwort_pollen", "olive_pollen", "ragweed_pollen",
+}
+
+// PollenForecast returns the daily highest pollen counts. The air quality
+// API only has them for Europe; days without any, as elsewhere, are left
+// out.
+func (p *OpenMeteo) PollenForecast(ctx context.Context, place forecast.Location, days int) (forecast.Forecast, error) {
+	req := request(place, "")
+	req.Hourly = pollenVariables
+	req.ForecastDays = days
+	resp, err := p.Client.AirQuality(ctx, req)
+	if err != nil {
+		return forecast.Forecast{}, err
+	}
+	return pollenForecast(resp, place)
+}
+
+// AgriForecast returns the daily highs and lows, reference
+// evapotranspiration and soil temperature and moisture, in metric units.
+// Growing degree days are left for the caller, which knows the base.
+func (p *OpenMeteo) AgriForecast(ctx context.Context, place forecast.Location, days, pastDays int) (forecast.Forecast, error) {
+	req := request(place, "")
+	req.Daily = []string{"temperature_2m_max", "temperature_2m_min", "et0_fao_evapotranspiration"}
+	req.Hourly = []string{"soil_temperature_6cm", "soil_moisture_3_to_9cm"}
+	req.ForecastDays = days
+	req.PastDays = pastDays
+	resp, err := p.Client.Forecast(ctx, req)
+	if err != nil {
+		return forecast.Forecast{}, err
+	}
+	return agriForecast(resp, place, pastDays)
+}
+
+// snowDepth returns a snow depth in cm. Open-Meteo reports it in metres,
+// unlike snowfall.
+func snowDepth(values []*float64, i int) *float64 {
+	v := nullableAt(values, i)
+	if v == nil {
+		return nil
+	}
+	cm := math.Round(*v*1000) / 10
+	return &cm
+}
+
+func timeAt(resp *openmeteo.ForecastResponse, values []string, i int) *time.Time {
+	if i >= len(values) {
+		return nil
+	}
+	return parseTime(resp, &values[i])
+}
+
+// parseTime parses a local time of resp, or returns nil when s is null or
+// not a time.
+func parseTime(resp *openmeteo.ForecastResponse, s *string) *time.Time {
+	if s == nil {
+		return nil
+	}
+	t, err := resp.ParseTime(*s)
+	if err != nil {
+		return nil
+	}
+	return &t
+}
+
+// dailyForecast assembles the days of resp, the first pastDays of which
+// are observed.
+func dailyForecast(resp *openmeteo.ForecastResponse, place forecast.Location, units forecast.Units, pastDays int) (forecast.Forecast, error) {
+	place.Timezone, place.Elevation = resp.Timezone, resp.Elevation
+	f := forecast.Forecast{Location: place, Units: units}
+	if resp.Daily == nil {
+		return f, nil
+	}
+
+	for i := range resp.Daily.Time {
+		day, ok, err := dailyDay(resp, i)
+		if err != nil {
+			return forecast.Forecast{}, err
+		}
+		if ok {
+			f.Days = append(f.Days, day)
+		}
+	}
+	if pastDays > 0 {
+		markObserved(resp.Daily, pastDays, f.Days)
+	}
+	dailyHumidity(resp.Hourly, f.Days)
+	dailyPressure(resp.Hourly, f.Days)
+	dailyClouds(resp.Hourly, f.Days)
+	return f, nil
+}
+
+// dailyDay assembles day i of resp from the daily arrays, any of which may
+// be short or hold nulls. Missing optional values are left nil. ok is false
+// when the day has no high or low, which happens for the last days of a
+// model's range.
+func dailyDay(resp *openmeteo.ForecastResponse, i int) (day forecast.Day, ok bool, err error) {
+	daily := resp.Daily
+	date, err := resp.ParseTime(daily.Time[i])
+	if err != nil {
+		return forecast.Day{}, false, err
+	}
+	high, low := nullableAt(daily.TemperatureMax, i), nullableAt(daily.TemperatureMin, i)
+	if high == nil || low == nil {
+		return forecast.Day{}, false, nil
+	}
+	day = forecast.Day{
+		Date:          forecast.Date{Time: date},
+		TempMax:       *high,
+		TempMin:       *low,
+		FeelsMax:      nullableAt(daily.ApparentMax, i),
+		FeelsMin:      nullableAt(daily.ApparentMin, i),
+		Precipitation: nullableAt(daily.PrecipitationSum, i),
+		PrecipChance:  nullableAt(daily.PrecipProbMax, i),
+		PrecipHours:   nullableAt(daily.PrecipHours, i),
+		Snowfall:      nullableAt(daily.SnowfallSum, i),
+		UVIndex:       nullableAt(daily.UVIndexMax, i),
+		Sunrise:       parseTime(resp, nullableAt(daily.Sunrise, i)),
+		Sunset:        parseTime(resp, nullableAt(daily.Sunset, i)),
+		Daylight:      nullableAt(daily.DaylightDuration, i),
+		Sunshine:      nullableAt(daily.SunshineDuration, i),
+		WindSpeedMax:  nullableAt(daily.WindSpeedMax, i),
+		WindGustsMax:  nullableAt(daily.WindGustsMax, i),
+		WindDirection: nullableAt(daily.WindDirectionDominant, i),
+		WeatherCode:   nullableAt(daily.WeatherCode, i),
+	}
+	if day.WeatherCode != nil {
+		day.Description = wmo.Description(*day.WeatherCode)
+	}
+	return day, true, nil
+}
+
+// dailyStats returns the mean, minimum and maximum of the hourly values of
+// each day, keyed by YYYY-MM-DD. Nulls are left out.
+func dailyStats(times []string, values []*float64) map[string][3]float64 {
+	stats := map[string][3]float64{}
+	counts := map[string]int{}
+	for i := 0; i < len(times) && i < len(values); i++ {
+		if len(times[i]) < len("2006-01-02") || values[i] == nil {
+			continue
+		}
+		date, v := times[i][:len("2006-01-02")], *values[i]
+		s, ok := stats[date]
+		if !ok {
+			s = [3]float64{0, v, v}
+		}
+		s[0] += v
+		s[1] = min(s[1], v)
+		s[2] = max(s[2], v)
+		stats[date] = s
+		counts[date]++
+	}
+	for date, s := range stats {
+		s[0] = math.Round(s[0]/float64(counts[date])*10) / 10
+		stats[date] = s
+	}
+	return stats
+}
+
+// dailyHumidity fills in the humidity and dew point of days from hourly
+// values, when they were requested.
+func dailyHumidity(hourly *openmeteo.HourlyData, days []forecast.Day) {
+	if hourly == nil {
+		return
+	}
+	humidity := dailyStats(hourly.Time, hourly.RelativeHumidity)
+	dewPoint := dailyStats(hourly.Time, hourly.DewPoint)
+	for i := range days {
+		date := days[i].Date.String()
+		if s, ok := humidity[date]; ok {
+			days[i].HumidityMean, days[i].HumidityMin, days[i].HumidityMax = &s[0], &s[1], &s[2]
+		}
+		if s, ok := dewPoint[date]; ok {
+			days[i].DewPointMean, days[i].DewPointMin, days[i].DewPointMax = &s[0], &s[1], &s[2]
+		}
+	}
+}
+
+// dailyClouds fills in the mean cloud cover and the lowest visibility of
+// days from hourly values, when they were requested.
+func dailyClouds(hourly *openmeteo.HourlyData, days []forecast.Day) {
+	if hourly == nil {
+		return
+	}
+	clouds := dailyStats(hourly.Time, hourly.CloudCover)
+	visibility := dailyStats(hourly.Time, hourly.Visibility)
+	for i := range days {
+		date := days[i].Date.String()
+		if s, ok := clouds[date]; ok {
+			days[i].CloudCover = &s[0]
+		}
+		if s, ok := visibility[date]; ok {
+			days[i].Visibility = &s[1]
+		}
+	}
+}
+
+// steadyPressure is the change in hPa over a day below which pressure
+// counts as steady.
+const steadyPressure = 3
+
+// dailyPressure fills in the mean surface pressure of days, and the trend
+// of today, the first day that is not observed, from its first and last
+// hourly value.
+func dailyPressure(hourly *openmeteo.HourlyData, days []forecast.Day) {
+	if hourly == nil || len(days) == 0 {
+		return
+	}
+	pressure := dailyStats(hourly.Time, hourly.SurfacePressure)
+	for i := range days {
+		if s, ok := pressure[days[i].Date.String()]; ok {
+			days[i].PressureMean = &s[0]
+		}
+	}
+
+	first := slices.IndexFunc(days, func(d forecast.Day) bool { return !d.Observed })
+	if first < 0 {
+		return
+	}
+	today := days[first].Date.String()
+	var values []float64
+	for i := 0; i < len(hourly.Time) && i < len(hourly.SurfacePressure); i++ {
+		if strings.HasPrefix(hourly.Time[i], today) && hourly.SurfacePressure[i] != nil {
+			values = append(values, *hourly.SurfacePressure[i])
+		}
+	}
+	if len(values) < 2 {
+		return
+	}
+	switch change := values[len(values)-1] - values[0]; {
+	case change >= steadyPressure:
+		days[first].PressureTrend = forecast.Rising
+	case change <= -steadyPressure:
+		days[first].PressureTrend = forecast.Falling
+	default:
+		days[first].PressureTrend = forecast.Steady
+	}
+}
+
+// nullableAt returns the value at i, or nil when values ends before it.
+func nullableAt[T any](values []*T, i int) *T {
+	if i >= len(values) {
+		return nil
+	}
+	return values[i]
+}
+
+func marineForecast(resp *openmeteo.ForecastResponse, place forecast.Location) (forecast.Forecast, error) {
+	place.Timezone, place.Elevation = resp.Timezone, resp.Elevation
+	f := forecast.Forecast{Location: place, Units: units.Metric.Units()}
+	if resp.Daily == nil {
+		return f, nil
+	}
+
+	daily := resp.Daily
+	for i := range daily.Time {
+		date, err := resp.ParseTime(daily.Time[i])
+		if err != nil {
+			return forecast.Forecast{}, err
+		}
+		day := forecast.SeaDay{
+			Date:           forecast.Date{Time: date},
+			WaveHeight:     nullableAt(daily.WaveHeightMax, i),
+			WavePeriod:     nullableAt(daily.WavePeriodMax, i),
+			WaveDirection:  nullableAt(daily.WaveDirectionDominant, i),
+			SwellHeight:    nullableAt(daily.SwellWaveHeightMax, i),
+			SwellPeriod:    nullableAt(daily.SwellWavePeriodMax, i),
+			SwellDirection: nullableAt(daily.SwellWaveDirectionDominant, i),
+		}
+		if day.WaveHeight == nil && day.SwellHeight == nil {
+			continue
+		}
+		f.Sea = append(f.Sea, day)
+	}
+	return f, nil
+}
+
+func agriForecast(resp *openmeteo.ForecastResponse, place forecast.Location, pastDays int) (forecast.Forecast, error) {
+	place.Timezone, place.Elevation = resp.Timezone, resp.Elevation
+	f := forecast.Forecast{Location: place, Units: units.Metric.Units()}
+	if resp.Daily == nil {
+		return f, nil
+	}
+
+	var soilTemperature, soilMoisture map[string][3]float64
+	if hourly := resp.Hourly; hourly != nil {
+		soilTemperature = dailyStats(hourly.Time, hourly.SoilTemperature6cm)
+		// Moisture comes as a fraction; as a percentage the means keep
+		// their precision.
+		percent := make([]*float64, len(hourly.SoilMoisture3To9cm))
+		for i, v := range hourly.SoilMoisture3To9cm {
+			if v != nil {
+				p := *v * 100
+				percent[i] = &p
+			}
+		}
+		soilMoisture = dailyStats(hourly.Time, percent)
+	}
+	daily := resp.Daily
+	for i := range daily.Time {
+		high, low := nullableAt(daily.TemperatureMax, i), nullableAt(daily.TemperatureMin, i)
+		if high == nil || low == nil {
+			continue
+		}
+		date, err := resp.ParseTime(daily.Time[i])
+		if err != nil {
+			return forecast.Forecast{}, err
+		}
+		day := forecast.AgriDay{
+			Date:     forecast.Date{Time: date},
+			Observed: i < pastDays,
+			TempMax:  *high,
+			TempMin:  *low,
+			ET0:      nullableAt(daily.ET0, i),
+		}
+		if s, ok := soilTemperature[daily.Time[i]]; ok {
+			day.SoilTemperature = &s[0]
+		}
+		if s, ok := soilMoisture[daily.Time[i]]; ok {
+			day.SoilMoisture = &s[0]
+		}
+		f.Agri = append(f.Agri, day)
+	}
+	return f, nil
+}
+
+func pollenForecast(resp *openmeteo.ForecastResponse, place forecast.Location) (forecast.Forecast, error) {
+	place.Timezone, place.Elevation = resp.Timezone, resp.Elevation
+	f := forecast.Forecast{Location: place, Units: units.Metric.Units()}
+	if resp.Hourly == nil {
+		return f, nil
+	}
+
+	hourly := resp.Hourly
+	for i, t := range hourly.Time {
+		// Hours are local, so their first ten characters are the date.
+		if len(t) < len("2006-01-02") {
+			continue
+		}
+		date := t[:len("2006-01-02")]
+		if len(f.Pollen) == 0 || f.Pollen[len(f.Pollen)-1].Date.String() != date {
+			d, err := resp.ParseTime(date)
+			if err != nil {
+				return forecast.Forecast{}, err
+			}
+			f.Pollen = append(f.Pollen, forecast.PollenDay{Date: forecast.Date{Time: d}})
+		}
+		day := &f.Pollen[len(f.Pollen)-1]
+		day.Alder = maxOf(day.Alder, nullableAt(hourly.AlderPollen, i))
+		day.Birch = maxOf(day.Birch, nullableAt(hourly.BirchPollen, i))
+		day.Grass = maxOf(day.Grass, nullableAt(hourly.GrassPollen, i))
+		day.Mugwort = maxOf(day.Mugwort, nullableAt(hourly.MugwortPollen, i))
+		day.Olive = maxOf(day.Olive, nullableAt(hourly.OlivePollen, i))
+		day.Ragweed = maxOf(day.Ragweed, nullableAt(hourly.RagweedPollen, i))
+	}
+	f.Pollen = slices.DeleteFunc(f.Pollen, func(d forecast.PollenDay) bool { return len(d.Counts()) == 0 })
+	return f, nil
+}
+
+// maxOf returns the larger of two optional values.
+func maxOf(a, b *float64) *float64 {
+	if a == nil || (b != nil && *b > *a) {
+		return b
+	}
+	return a
+}
+
+func hourlyForecast(resp *openmeteo.ForecastResponse, place forecast.Location, units forecast.Units) (forecast.Forecast, error) {
+	place.Timezone, place.Elevation = resp.Timezone, resp.Elevation
+	f := forecast.Forecast{Location: place, Units: units}
+	if resp.Hourly == nil {
+		return f, nil
+	}
+
+	hourly := resp.Hourly
+	for i := 0; i < len(hourly.Time) && i < len(hourly.Temperature); i++ {
+		t := timeAt(resp, hourly.Time, i)
+		if t == nil || hourly.Temperature[i] == nil {
+			continue
+		}
+		f.Hours = append(f.Hours, forecast.Hour{
+			Time:              *t,
+			Temperature:       *hourly.Temperature[i],
+			Precipitation:     nullableAt(hourly.Precipitation, i),
+			PrecipProbability: nullableAt(hourly.PrecipitationProbability, i),
+			WindSpeed:         nullableAt(hourly.WindSpeed, i),
+			WindDirection:     nullableAt(hourly.WindDirection, i),
+			SnowDepth:         snowDepth(hourly.SnowDepth, i),
+			Humidity:          nullableAt(hourly.RelativeHumidity, i),
+			DewPoint:          nullableAt(hourly.DewPoint, i),
+			Pressure:          nullableAt(hourly.SurfacePressure, i),
+			CloudCover:        nullableAt(hourly.CloudCover, i),
+			Visibility:        nullableAt(hourly.Visibility, i),
+		})
+	}
+	return f, nil
+}
+
+func currentForecast(resp *openmeteo.ForecastResponse, place forecast.Location, units forecast.Units) (forecast.Forecast, error) {
+	place.Timezone, place.Elevation = resp.Timezone, resp.Elevation
+	f := forecast.Forecast{Location: place, Units: units}
+	if resp.CurrentWeather == nil {
+		return f, nil
+	}
+
+	cw := resp.CurrentWeather
+	t, err := resp.ParseTime(cw.Time)
+	if err != nil {
+		return forecast.Forecast{}, err
+	}
+	f.Current = &forecast.Current{
+		Time:          t,
+		Temperature:   cw.Temperature,
+		WindSpeed:     cw.WindSpeed,
+		WindDirection: cw.WindDirection,
+		WeatherCode:   cw.WeatherCode,
+		Description:   wmo.Description(cw.WeatherCode),
+		IsDay:         cw.IsDay == 1,
+	}
+	return f, nil
+}
@@ -0,0 +1,77 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"weather-app/internal/forecast"
+	"weather-app/internal/units"
+	"weather-app/pkg/openmeteo"
+)
+
+func TestElsewhere(t *testing.T) {
+	nf := &openmeteo.NotFoundError{
+		Name:    "The Hague",
+		Country: "France",
+		Elsewhere: []openmeteo.GeocodingResult{
+			{Name: "The Hague", Country: "Netherlands", Admin1: "South Holland", Latitude: 52.08, Longitude: 4.3, Population: 514861},
+			{Name: "The Hague", Country: "United States", Admin1: "Pennsylvania", Latitude: 40.1, Longitude: -75.5},
+		},
+	}
+	tests := []struct {
+		name string
+		err  error
+		want []string
+	}{
+		{"not found", nf, []string{"Netherlands", "United States"}},
+		{"wrapped", fmt.Errorf("geocoding: %w", nf), []string{"Netherlands", "United States"}},
+		{"nothing elsewhere", &openmeteo.NotFoundError{Name: "Atlantis"}, []string{}},
+		{"other error", errors.New("timeout"), nil},
+		{"no error", nil, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Elsewhere(tt.err)
+			if (got == nil) != (tt.want == nil) || len(got) != len(tt.want) {
+				t.Fatalf("Elsewhere() = %+v, want places in %q", got, tt.want)
+			}
+			for i, p := range got {
+				if p.Country != tt.want[i] {
+					t.Errorf("place %d in %s, want %s", i, p.Country, tt.want[i])
+				}
+			}
+		})
+	}
+	if p := Elsewhere(nf)[0]; p.Region != "South Holland" || p.Latitude != 52.08 || p.Population != 514861 {
+		t.Errorf("got %+v, want the region, coordinates and population of the result", p)
+	}
+}
+
+func TestHourlyNullsNotAvailable(t *testing.T) {
+	v := 12.5
+	resp := &openmeteo.ForecastResponse{
+		Timezone: "UTC",
+		Hourly: &openmeteo.HourlyData{
+			Time:                     []string{"2024-06-03T12:00", "2024-06-03T13:00"},
+			Temperature:              []*float64{&v, &v},
+			PrecipitationProbability: []*float64{nil, nil},
+			Visibility:               []*float64{nil, &v},
+		},
+	}
+	f, err := hourlyForecast(resp, forecast.Location{Name: "The Hague"}, units.Metric.Units())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(f.Hours) != 2 {
+		t.Fatalf("got %d hours, want 2", len(f.Hours))
+	}
+	for i, h := range f.Hours {
+		if h.PrecipProbability != nil {
+			t.Errorf("hour %d: precipitation probability %v, want none", i, *h.PrecipProbability)
+		}
+	}
+	if f.Hours[0].Visibility != nil || f.Hours[1].Visibility == nil {
+		t.Errorf("visibility = %v, %v, want only the second hour's", f.Hours[0].Visibility, f.Hours[1].Visibility)
+	}
+}
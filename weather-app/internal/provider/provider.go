@@ -0,0 +1,131 @@
+// Package provider puts the weather services behind a common interface, so
+// the commands do not depend on a single backend.
+package provider
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+
+	"weather-app/internal/forecast"
+	"weather-app/internal/httpclient"
+	"weather-app/pkg/nws"
+	"weather-app/pkg/openmeteo"
+)
+
+// Options choose the optional daily variables and how many days to fetch.
+type Options struct {
+	Precipitation bool
+	UVIndex       bool
+	Sunrise       bool
+	Sunset        bool
+	Daylight      bool
+	Wind          bool
+	Feels         bool
+	Snow          bool
+	Humidity      bool
+	Pressure      bool
+	Clouds        bool
+	Ensemble      bool
+	Days          int
+	// PastDays is the number of days before today to fetch along with the
+	// forecast, for providers that keep them.
+	PastDays int
+	// Model names the weather model to forecast with; empty is the
+	// provider's default.
+	Model string
+	// Elevation in metres overrides the elevation of the location, for
+	// providers that downscale to it.
+	Elevation *float64
+}
+
+// Place is a geocoding match.
+type Place struct {
+	forecast.Location
+	Region     string
+	Population int
+}
+
+// Geocoder finds places by name.
+type Geocoder interface {
+	// Geocode returns every place called name in country, best match first.
+	Geocode(ctx context.Context, name, country string) ([]Place, error)
+}
+
+// PostcodeGeocoder is implemented by geocoders that also find places by
+// postal code.
+type PostcodeGeocoder interface {
+	// GeocodePostcode returns the places with postal code code in country,
+	// given as a name or an ISO 3166-1 code.
+	GeocodePostcode(ctx context.Context, code, country string) ([]Place, error)
+}
+
+// Provider is a weather backend. Forecasts are returned in metric units and
+// converted for display by the caller.
+type Provider interface {
+	Geocoder
+	Name() string
+	DailyForecast(ctx context.Context, place forecast.Location, opts Options) (forecast.Forecast, error)
+	HourlyForecast(ctx context.Context, place forecast.Location, opts Options, hours int) (forecast.Forecast, error)
+}
+
+// CurrentProvider is implemented by providers that report the current
+// conditions.
+type CurrentProvider interface {
+	CurrentForecast(ctx context.Context, place forecast.Location) (forecast.Forecast, error)
+}
+
+// AlertProvider is implemented by providers that publish severe weather
+// alerts.
+type AlertProvider interface {
+	Alerts(ctx context.Context, place forecast.Location) ([]forecast.Alert, error)
+}
+
+// MarineProvider is implemented by providers that forecast waves and swell.
+type MarineProvider interface {
+	MarineForecast(ctx context.Context, place forecast.Location, days int) (forecast.Forecast, error)
+}
+
+// PollenProvider is implemented by providers that forecast pollen.
+type PollenProvider interface {
+	PollenForecast(ctx context.Context, place forecast.Location, days int) (forecast.Forecast, error)
+}
+
+// AgriProvider is implemented by providers that forecast evapotranspiration
+// and soil conditions. Past days come before the forecast ones.
+type AgriProvider interface {
+	AgriForecast(ctx context.Context, place forecast.Location, days, pastDays int) (forecast.Forecast, error)
+}
+
+// DefaultName is the provider used when none is chosen.
+const DefaultName = "open-meteo"
+
+var providers = map[string]func(*openmeteo.Client, *slog.Logger) Provider{
+	"open-meteo": func(c *openmeteo.Client, _ *slog.Logger) Provider { return &OpenMeteo{Client: c} },
+	"nws": func(c *openmeteo.Client, logger *slog.Logger) Provider {
+		return &NWS{Client: nws.NewClient(nws.WithLogger(logger), nws.WithHTTPClient(httpclient.New(nws.DefaultTimeout))), Geocoder: &OpenMeteo{Client: c}}
+	},
+}
+
+// Names returns the names accepted by New.
+func Names() []string {
+	names := make([]string, 0, len(providers))
+	for name := range providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// New returns the named provider. Providers without a geocoding service of
+// their own look places up with client; those with their own API client log
+// its requests to logger.
+func New(name string, client *openmeteo.Client, logger *slog.Logger) (Provider, error) {
+	newProvider, ok := providers[name]
+	if !ok {
+		return nil, fmt.Errorf("Unknown weather provider %q, expected one of %s", name, strings.Join(Names(), ", "))
+	}
+	return newProvider(client, logger), nil
+}
@@ -0,0 +1,38 @@
+package render
+
+import (
+	"fmt"
+	"io"
+
+	"weather-app/internal/forecast"
+	"weather-app/internal/i18n"
+	"weather-app/internal/units"
+)
+
+// agriTable prints one line per day with the growing degree days and the
+// water and soil values gardeners and farmers plan with.
+func agriTable(w io.Writer, f forecast.Forecast, loc i18n.Locale) {
+	degrees := units.Degrees(f.Units.Temperature)
+	for i, day := range f.Agri {
+		if day.Observed && i == 0 {
+			fmt.Fprintln(w, section("Observed"))
+		} else if !day.Observed && i > 0 && f.Agri[i-1].Observed {
+			fmt.Fprintln(w, section("Forecast"))
+		}
+		output := fmt.Sprintf("%s | %s/%s %s | GDD: %s (total %s)", loc.Date(day.Date.Time),
+			loc.Number(day.TempMax, 0), loc.Number(day.TempMin, 0), degrees,
+			loc.Number(day.GDD, 1), loc.Number(day.GDDTotal, 1))
+		if day.ET0 != nil {
+			output += fmt.Sprintf(" | ET0: %s mm", loc.Number(*day.ET0, 1))
+		}
+		if day.SoilTemperature != nil {
+			output += fmt.Sprintf(" | Soil: %s %s", loc.Number(*day.SoilTemperature, 1), degrees)
+			if day.SoilMoisture != nil {
+				output += fmt.Sprintf(", %s%% moist", loc.Number(*day.SoilMoisture, 0))
+			}
+		} else if day.SoilMoisture != nil {
+			output += fmt.Sprintf(" | Soil: %s%% moist", loc.Number(*day.SoilMoisture, 0))
+		}
+		fmt.Fprintln(w, output)
+	}
+}
@@ -0,0 +1,40 @@
+package render
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"weather-app/internal/forecast"
+)
+
+const alertTimeLayout = "Mon Jan 2 15:04"
+
+// alertList prints alerts as a block meant to stand out above the forecast:
+// severe and extreme alerts in red, the others in yellow.
+func alertList(w io.Writer, alerts []forecast.Alert, s styler) {
+	for _, a := range alerts {
+		color := yellow
+		if a.Severity == "Severe" || a.Severity == "Extreme" {
+			color = red
+		}
+		severity := a.Severity
+		if severity == "" {
+			severity = "Alert"
+		}
+		fmt.Fprintln(w, s.paint(fmt.Sprintf("!! %s: %s", strings.ToUpper(severity), a.Event), color))
+		if a.Headline != "" {
+			fmt.Fprintf(w, "   %s\n", a.Headline)
+		}
+		fmt.Fprintf(w, "   %s\n", alertWindow(a))
+	}
+	fmt.Fprintln(w)
+}
+
+// alertWindow describes when an alert is in effect.
+func alertWindow(a forecast.Alert) string {
+	if a.End == nil {
+		return "From " + a.Start.Format(alertTimeLayout)
+	}
+	return fmt.Sprintf("From %s until %s", a.Start.Format(alertTimeLayout), a.End.Format(alertTimeLayout))
+}
@@ -0,0 +1,80 @@
+package render
+
+import (
+	"errors"
+	"io"
+	"math"
+	"strings"
+	"text/template"
+
+	"weather-app/internal/forecast"
+	"weather-app/internal/units"
+	"weather-app/internal/wmo"
+)
+
+// DefaultBrief is the template of -brief, giving lines such as
+// "The Hague: 18°/11°C, light rain (70%), UV 4".
+const DefaultBrief = `{{.Place}}: {{.High}}°/{{.Low}}{{.Degrees}}` +
+	`{{with .Description}}, {{lower .}}{{end}}{{with .PrecipChance}} ({{.}}%){{end}}{{with .UV}}, UV {{.}}{{end}}`
+
+// BriefLine is what a -brief template is executed with: the first day of a
+// forecast, rounded for a status bar. Pointers are nil when not forecast.
+type BriefLine struct {
+	Place        string
+	Date         string
+	High         int
+	Low          int
+	Degrees      string
+	Description  string
+	Icon         string
+	PrecipChance *int
+	Precip       *float64
+	UV           *int
+	WindMax      *int
+}
+
+// ParseBrief parses a -brief template. An empty text is DefaultBrief.
+func ParseBrief(text string) (*template.Template, error) {
+	if text == "" {
+		text = DefaultBrief
+	}
+	return template.New("brief").Funcs(templateFuncs).Parse(text)
+}
+
+// Brief writes the first day of f as a single line.
+func Brief(w io.Writer, t *template.Template, f forecast.Forecast) error {
+	if len(f.Days) == 0 {
+		return errors.New("No forecast for today")
+	}
+	day := f.Days[0]
+	line := BriefLine{
+		Place:        placeName(f.Location),
+		Date:         day.Date.Format("2006-01-02"),
+		High:         int(math.Round(day.TempMax)),
+		Low:          int(math.Round(day.TempMin)),
+		Degrees:      units.Degrees(f.Units.Temperature),
+		Description:  day.Description,
+		PrecipChance: roundedPtr(day.PrecipChance),
+		Precip:       day.Precipitation,
+		UV:           roundedPtr(day.UVIndex),
+		WindMax:      roundedPtr(day.WindSpeedMax),
+	}
+	if day.WeatherCode != nil {
+		line.Icon = wmo.Icon(*day.WeatherCode, "emoji")
+	}
+	var b strings.Builder
+	if err := t.Execute(&b, line); err != nil {
+		return err
+	}
+	// Status bars take the first line only.
+	_, err := io.WriteString(w, strings.TrimRight(b.String(), "\n")+"\n")
+	return err
+}
+
+func roundedPtr(v *float64) *int {
+	if v == nil {
+		return nil
+	}
+	n := int(math.Round(*v))
+	return &n
+}
@@ -0,0 +1,54 @@
+package render
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"weather-app/internal/forecast"
+	"weather-app/internal/units"
+)
+
+func TestBrief(t *testing.T) {
+	ptr := func(v float64) *float64 { return &v }
+	code := 61
+	f := forecast.Forecast{
+		Location: forecast.Location{Name: "The Hague", Country: "Netherlands"},
+		Units:    units.Metric.Units(),
+		Days: []forecast.Day{{
+			Date: forecast.Date{Time: time.Date(2024, 6, 3, 0, 0, 0, 0, time.UTC)}, TempMax: 17.6, TempMin: 10.5,
+			Description: "Light rain", WeatherCode: &code, PrecipChance: ptr(69.5), UVIndex: ptr(3.6),
+		}},
+	}
+	bare := f
+	bare.Days = []forecast.Day{{Date: f.Days[0].Date, TempMax: 17.6, TempMin: 10.5}}
+	tests := []struct {
+		name     string
+		template string
+		f        forecast.Forecast
+		want     string
+		wantErr  bool
+	}{
+		{"default", "", f, "The Hague: 18°/11°C, light rain (70%), UV 4\n", false},
+		{"nil fields left out", "", bare, "The Hague: 18°/11°C\n", false},
+		{"imperial", "", units.Convert(bare, units.Imperial.Units()), "The Hague: 64°/51°F\n", false},
+		{"custom", "{{.Date}} {{.High}}{{.Degrees}}{{with .WindMax}} wind {{.}}{{end}}\n\n", bare, "2024-06-03 18°C\n", false},
+		{"no days", "", forecast.Forecast{}, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpl, err := ParseBrief(tt.template)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var buf bytes.Buffer
+			err = Brief(&buf, tmpl, tt.f)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Brief() error = %v, wantErr %t", err, tt.wantErr)
+			}
+			if buf.String() != tt.want {
+				t.Errorf("Brief() = %q, want %q", buf.String(), tt.want)
+			}
+		})
+	}
+}
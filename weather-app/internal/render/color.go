@@ -0,0 +1,119 @@
+package render
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"weather-app/internal/forecast"
+	"weather-app/internal/units"
+)
+
+const reset = "\x1b[0m"
+
+// temperatureScale maps upper bounds in °C to 256-color palette entries,
+// running from deep blue for frost to red for heat.
+var temperatureScale = []struct {
+	below float64
+	color int
+}{
+	{-10, 21},
+	{0, 33},
+	{5, 39},
+	{10, 51},
+	{15, 48},
+	{20, 46},
+	{25, 226},
+	{30, 208},
+	{35, 202},
+}
+
+const (
+	hottest = 196
+	cyan    = 51
+	yellow  = 226
+	red     = 196
+)
+
+type styler struct {
+	color bool
+}
+
+func (s styler) paint(text string, color int) string {
+	if !s.color {
+		return text
+	}
+	return fmt.Sprintf("\x1b[38;5;%dm%s%s", color, text, reset)
+}
+
+// temp colors text by where temp (in unit) falls on the temperature scale.
+func (s styler) temp(text string, temp float64, unit string) string {
+	c := units.Temperature(temp, unit, units.Celsius)
+	for _, step := range temperatureScale {
+		if c < step.below {
+			return s.paint(text, step.color)
+		}
+	}
+	return s.paint(text, hottest)
+}
+
+func (s styler) precip(text string) string {
+	return s.paint(text, cyan)
+}
+
+// uv highlights high (6+) UV values in yellow and very high (8+) in red.
+// With advice, every category has the color the WHO gives it.
+func (s styler) uv(text string, uv float64, advice bool) string {
+	if advice {
+		return s.paint(text, UVCategoryOf(uv).color)
+	}
+	switch {
+	case uv >= 8:
+		return s.paint(text, red)
+	case uv >= 6:
+		return s.paint(text, yellow)
+	default:
+		return text
+	}
+}
+
+// warning marks a day of frost or heat in the colors of its end of the
+// temperature scale.
+func (s styler) warning(kind string) string {
+	if kind == forecast.WarnFrost {
+		return s.paint("!! Frost", 33)
+	}
+	return s.paint("!! Heat", hottest)
+}
+
+// score colors an activity score green from 7, yellow from 4 and red
+// below.
+func (s styler) score(text string, score float64) string {
+	switch {
+	case score >= 7:
+		return s.paint(text, 46)
+	case score >= 4:
+		return s.paint(text, yellow)
+	}
+	return s.paint(text, red)
+}
+
+// ColorSupported reports whether w is a terminal that should get colors.
+// NO_COLOR (https://no-color.org) and TERM=dumb disable colors.
+func ColorSupported(w io.Writer) bool {
+	if _, ok := os.LookupEnv("NO_COLOR"); ok {
+		return false
+	}
+	if os.Getenv("TERM") == "dumb" {
+		return false
+	}
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	stat, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return stat.Mode()&os.ModeCharDevice != 0
+}
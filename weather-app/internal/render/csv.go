@@ -0,0 +1,276 @@
+package render
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"weather-app/internal/forecast"
+)
+
+// column extracts one CSV field; ok is false when the value is missing.
+type column[T any] struct {
+	name  string
+	value func(T) (v string, ok bool)
+}
+
+func number(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+func optionalNumber(v *float64) (string, bool) {
+	if v == nil {
+		return "", false
+	}
+	return number(*v), true
+}
+
+func optionalTime(t *time.Time) (string, bool) {
+	if t == nil {
+		return "", false
+	}
+	return t.Format(time.RFC3339), true
+}
+
+var dayColumns = []column[forecast.Day]{
+	{"date", func(d forecast.Day) (string, bool) { return d.Date.String(), true }},
+	{"temp_max", func(d forecast.Day) (string, bool) { return number(d.TempMax), true }},
+	{"temp_min", func(d forecast.Day) (string, bool) { return number(d.TempMin), true }},
+	{"temp_max_p10", func(d forecast.Day) (string, bool) { return optionalNumber(d.TempMaxP10) }},
+	{"temp_max_p90", func(d forecast.Day) (string, bool) { return optionalNumber(d.TempMaxP90) }},
+	{"temp_min_p10", func(d forecast.Day) (string, bool) { return optionalNumber(d.TempMinP10) }},
+	{"temp_min_p90", func(d forecast.Day) (string, bool) { return optionalNumber(d.TempMinP90) }},
+	{"apparent_temperature_max", func(d forecast.Day) (string, bool) { return optionalNumber(d.FeelsMax) }},
+	{"apparent_temperature_min", func(d forecast.Day) (string, bool) { return optionalNumber(d.FeelsMin) }},
+	{"precipitation", func(d forecast.Day) (string, bool) { return optionalNumber(d.Precipitation) }},
+	{"precipitation_probability_max", func(d forecast.Day) (string, bool) { return optionalNumber(d.PrecipChance) }},
+	{"precipitation_hours", func(d forecast.Day) (string, bool) { return optionalNumber(d.PrecipHours) }},
+	{"snowfall_sum", func(d forecast.Day) (string, bool) { return optionalNumber(d.Snowfall) }},
+	{"relative_humidity_mean", func(d forecast.Day) (string, bool) { return optionalNumber(d.HumidityMean) }},
+	{"relative_humidity_min", func(d forecast.Day) (string, bool) { return optionalNumber(d.HumidityMin) }},
+	{"relative_humidity_max", func(d forecast.Day) (string, bool) { return optionalNumber(d.HumidityMax) }},
+	{"dew_point_mean", func(d forecast.Day) (string, bool) { return optionalNumber(d.DewPointMean) }},
+	{"dew_point_min", func(d forecast.Day) (string, bool) { return optionalNumber(d.DewPointMin) }},
+	{"dew_point_max", func(d forecast.Day) (string, bool) { return optionalNumber(d.DewPointMax) }},
+	{"surface_pressure_mean", func(d forecast.Day) (string, bool) { return optionalNumber(d.PressureMean) }},
+	{"pressure_trend", func(d forecast.Day) (string, bool) { return d.PressureTrend, d.PressureTrend != "" }},
+	{"cloud_cover_mean", func(d forecast.Day) (string, bool) { return optionalNumber(d.CloudCover) }},
+	{"visibility_min", func(d forecast.Day) (string, bool) { return optionalNumber(d.Visibility) }},
+	{"uv_index", func(d forecast.Day) (string, bool) { return optionalNumber(d.UVIndex) }},
+	{"sunrise", func(d forecast.Day) (string, bool) { return optionalTime(d.Sunrise) }},
+	{"sunset", func(d forecast.Day) (string, bool) { return optionalTime(d.Sunset) }},
+	{"daylight_duration", func(d forecast.Day) (string, bool) { return optionalNumber(d.Daylight) }},
+	{"sunshine_duration", func(d forecast.Day) (string, bool) { return optionalNumber(d.Sunshine) }},
+	{"wind_speed_max", func(d forecast.Day) (string, bool) { return optionalNumber(d.WindSpeedMax) }},
+	{"wind_gusts_max", func(d forecast.Day) (string, bool) { return optionalNumber(d.WindGustsMax) }},
+	{"wind_direction_dominant", func(d forecast.Day) (string, bool) { return optionalNumber(d.WindDirection) }},
+	{"weather_code", func(d forecast.Day) (string, bool) {
+		if d.WeatherCode == nil {
+			return "", false
+		}
+		return strconv.Itoa(*d.WeatherCode), true
+	}},
+	{"description", func(d forecast.Day) (string, bool) { return d.Description, d.Description != "" }},
+	{"warning", func(d forecast.Day) (string, bool) { return d.Warning, d.Warning != "" }},
+	{"score", func(d forecast.Day) (string, bool) { return optionalNumber(d.Score) }},
+	{"what_to_wear", func(d forecast.Day) (string, bool) { return strings.Join(d.Wear, "; "), len(d.Wear) > 0 }},
+}
+
+var hourColumns = []column[forecast.Hour]{
+	{"time", func(h forecast.Hour) (string, bool) { return h.Time.Format(time.RFC3339), true }},
+	{"temperature", func(h forecast.Hour) (string, bool) { return number(h.Temperature), true }},
+	{"precipitation", func(h forecast.Hour) (string, bool) { return optionalNumber(h.Precipitation) }},
+	{"precipitation_probability", func(h forecast.Hour) (string, bool) { return optionalNumber(h.PrecipProbability) }},
+	{"wind_speed", func(h forecast.Hour) (string, bool) { return optionalNumber(h.WindSpeed) }},
+	{"wind_direction", func(h forecast.Hour) (string, bool) { return optionalNumber(h.WindDirection) }},
+	{"snow_depth", func(h forecast.Hour) (string, bool) { return optionalNumber(h.SnowDepth) }},
+	{"relative_humidity", func(h forecast.Hour) (string, bool) { return optionalNumber(h.Humidity) }},
+	{"dew_point", func(h forecast.Hour) (string, bool) { return optionalNumber(h.DewPoint) }},
+	{"surface_pressure", func(h forecast.Hour) (string, bool) { return optionalNumber(h.Pressure) }},
+	{"cloud_cover", func(h forecast.Hour) (string, bool) { return optionalNumber(h.CloudCover) }},
+	{"visibility", func(h forecast.Hour) (string, bool) { return optionalNumber(h.Visibility) }},
+}
+
+var currentColumns = []column[forecast.Current]{
+	{"time", func(c forecast.Current) (string, bool) { return c.Time.Format(time.RFC3339), true }},
+	{"temperature", func(c forecast.Current) (string, bool) { return number(c.Temperature), true }},
+	{"wind_speed", func(c forecast.Current) (string, bool) { return number(c.WindSpeed), true }},
+	{"wind_direction", func(c forecast.Current) (string, bool) { return number(c.WindDirection), true }},
+	{"weather_code", func(c forecast.Current) (string, bool) { return strconv.Itoa(c.WeatherCode), true }},
+	{"description", func(c forecast.Current) (string, bool) { return c.Description, true }},
+}
+
+var seaColumns = []column[forecast.SeaDay]{
+	{"date", func(d forecast.SeaDay) (string, bool) { return d.Date.String(), true }},
+	{"wave_height_max", func(d forecast.SeaDay) (string, bool) { return optionalNumber(d.WaveHeight) }},
+	{"wave_period_max", func(d forecast.SeaDay) (string, bool) { return optionalNumber(d.WavePeriod) }},
+	{"wave_direction_dominant", func(d forecast.SeaDay) (string, bool) { return optionalNumber(d.WaveDirection) }},
+	{"swell_wave_height_max", func(d forecast.SeaDay) (string, bool) { return optionalNumber(d.SwellHeight) }},
+	{"swell_wave_period_max", func(d forecast.SeaDay) (string, bool) { return optionalNumber(d.SwellPeriod) }},
+	{"swell_wave_direction_dominant", func(d forecast.SeaDay) (string, bool) { return optionalNumber(d.SwellDirection) }},
+}
+
+var agriColumns = []column[forecast.AgriDay]{
+	{"date", func(d forecast.AgriDay) (string, bool) { return d.Date.String(), true }},
+	{"temp_max", func(d forecast.AgriDay) (string, bool) { return number(d.TempMax), true }},
+	{"temp_min", func(d forecast.AgriDay) (string, bool) { return number(d.TempMin), true }},
+	{"gdd", func(d forecast.AgriDay) (string, bool) { return number(d.GDD), true }},
+	{"gdd_total", func(d forecast.AgriDay) (string, bool) { return number(d.GDDTotal), true }},
+	{"et0_fao_evapotranspiration", func(d forecast.AgriDay) (string, bool) { return optionalNumber(d.ET0) }},
+	{"soil_temperature_6cm", func(d forecast.AgriDay) (string, bool) { return optionalNumber(d.SoilTemperature) }},
+	{"soil_moisture_3_to_9cm", func(d forecast.AgriDay) (string, bool) { return optionalNumber(d.SoilMoisture) }},
+}
+
+var pollenColumns = []column[forecast.PollenDay]{
+	{"date", func(d forecast.PollenDay) (string, bool) { return d.Date.String(), true }},
+	{"alder_pollen", func(d forecast.PollenDay) (string, bool) { return optionalNumber(d.Alder) }},
+	{"birch_pollen", func(d forecast.PollenDay) (string, bool) { return optionalNumber(d.Birch) }},
+	{"olive_pollen", func(d forecast.PollenDay) (string, bool) { return optionalNumber(d.Olive) }},
+	{"grass_pollen", func(d forecast.PollenDay) (string, bool) { return optionalNumber(d.Grass) }},
+	{"mugwort_pollen", func(d forecast.PollenDay) (string, bool) { return optionalNumber(d.Mugwort) }},
+	{"ragweed_pollen", func(d forecast.PollenDay) (string, bool) { return optionalNumber(d.Ragweed) }},
+}
+
+// present keeps the columns that have a value in at least one row.
+func present[T any](columns []column[T], rows []T) []column[T] {
+	var kept []column[T]
+	for _, c := range columns {
+		for _, row := range rows {
+			if _, ok := c.value(row); ok {
+				kept = append(kept, c)
+				break
+			}
+		}
+	}
+	return kept
+}
+
+func writeRows[T any](w *csv.Writer, prefix []string, columns []column[T], rows []T) error {
+	for _, record := range records(prefix, columns, rows) {
+		if err := w.Write(record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// records turns rows into their fields, each record starting with prefix.
+func records[T any](prefix []string, columns []column[T], rows []T) [][]string {
+	var out [][]string
+	for _, row := range rows {
+		record := append([]string{}, prefix...)
+		for _, c := range columns {
+			v, _ := c.value(row)
+			record = append(record, v)
+		}
+		out = append(out, record)
+	}
+	return out
+}
+
+func header[T any](prefix []string, columns []column[T]) []string {
+	names := append([]string{}, prefix...)
+	for _, c := range columns {
+		names = append(names, c.name)
+	}
+	return names
+}
+
+// CSV writes one row per hour in hourly forecasts, a single row for current
+// conditions, one row per day of marine, pollen and agricultural forecasts and one row per
+// day otherwise, with only the columns that were
+// requested.
+func CSV(w io.Writer, f forecast.Forecast) error {
+	return CSVComparison(w, []forecast.Forecast{f})
+}
+
+// CSVComparison writes the rows of several forecasts. With more than one
+// forecast every row starts with the location name, and the model when one
+// was chosen.
+func CSVComparison(w io.Writer, forecasts []forecast.Forecast) error {
+	cw := csv.NewWriter(w)
+	var prefix []string
+	if len(forecasts) > 1 {
+		prefix = []string{"location"}
+	}
+
+	var days []forecast.Day
+	var hours []forecast.Hour
+	var currents []forecast.Current
+	var sea []forecast.SeaDay
+	var pollen []forecast.PollenDay
+	var agri []forecast.AgriDay
+	for _, f := range forecasts {
+		agri = append(agri, f.Agri...)
+		days = append(days, f.Days...)
+		hours = append(hours, f.Hours...)
+		sea = append(sea, f.Sea...)
+		pollen = append(pollen, f.Pollen...)
+		if f.Current != nil {
+			currents = append(currents, *f.Current)
+		}
+	}
+
+	if len(currents) > 0 {
+		cw.Write(header(prefix, currentColumns))
+		for _, f := range forecasts {
+			if f.Current == nil {
+				continue
+			}
+			if err := writeRows(cw, rowPrefix(prefix, f), currentColumns, []forecast.Current{*f.Current}); err != nil {
+				return err
+			}
+		}
+	} else if len(sea) > 0 {
+		columns := present(seaColumns, sea)
+		cw.Write(header(prefix, columns))
+		for _, f := range forecasts {
+			if err := writeRows(cw, rowPrefix(prefix, f), columns, f.Sea); err != nil {
+				return err
+			}
+		}
+	} else if len(agri) > 0 {
+		columns := present(agriColumns, agri)
+		cw.Write(header(prefix, columns))
+		for _, f := range forecasts {
+			if err := writeRows(cw, rowPrefix(prefix, f), columns, f.Agri); err != nil {
+				return err
+			}
+		}
+	} else if len(pollen) > 0 {
+		columns := present(pollenColumns, pollen)
+		cw.Write(header(prefix, columns))
+		for _, f := range forecasts {
+			if err := writeRows(cw, rowPrefix(prefix, f), columns, f.Pollen); err != nil {
+				return err
+			}
+		}
+	} else if len(hours) > 0 {
+		columns := present(hourColumns, hours)
+		cw.Write(header(prefix, columns))
+		for _, f := range forecasts {
+			if err := writeRows(cw, rowPrefix(prefix, f), columns, f.Hours); err != nil {
+				return err
+			}
+		}
+	} else {
+		columns := present(dayColumns, days)
+		cw.Write(header(prefix, columns))
+		for _, f := range forecasts {
+			if err := writeRows(cw, rowPrefix(prefix, f), columns, f.Days); err != nil {
+				return err
+			}
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func rowPrefix(prefix []string, f forecast.Forecast) []string {
+	if len(prefix) == 0 {
+		return nil
+	}
+	return []string{f.Label()}
+}
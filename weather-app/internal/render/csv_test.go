@@ -0,0 +1,75 @@
+package render
+
+import (
+	"bytes"
+	"encoding/csv"
+	"slices"
+	"testing"
+	"time"
+
+	"weather-app/internal/forecast"
+)
+
+func TestCSVColumns(t *testing.T) {
+	ptr := func(v float64) *float64 { return &v }
+	day := func(d int) forecast.Date {
+		return forecast.Date{Time: time.Date(2024, 6, d, 0, 0, 0, 0, time.UTC)}
+	}
+	tests := []struct {
+		name      string
+		forecasts []forecast.Forecast
+		want      [][]string
+	}{
+		{
+			"required only",
+			[]forecast.Forecast{{Days: []forecast.Day{{Date: day(3), TempMax: 18.2, TempMin: 9}}}},
+			[][]string{{"date", "temp_max", "temp_min"}, {"2024-06-03", "18.2", "9"}},
+		},
+		{
+			"missing values are empty",
+			[]forecast.Forecast{{Days: []forecast.Day{
+				{Date: day(3), TempMax: 18, TempMin: 9, Precipitation: ptr(0)},
+				{Date: day(4), TempMax: 19, TempMin: 10, UVIndex: ptr(4.5)},
+			}}},
+			[][]string{
+				{"date", "temp_max", "temp_min", "precipitation", "uv_index"},
+				{"2024-06-03", "18", "9", "0", ""},
+				{"2024-06-04", "19", "10", "", "4.5"},
+			},
+		},
+		{
+			"comparison",
+			[]forecast.Forecast{
+				{Location: forecast.Location{Name: "Paris"}, Days: []forecast.Day{{Date: day(3), TempMax: 25, TempMin: 14}}},
+				{Location: forecast.Location{Name: "Paris"}, Model: "icon", Days: []forecast.Day{{Date: day(3), TempMax: 24, TempMin: 13, WindSpeedMax: ptr(12)}}},
+			},
+			[][]string{
+				{"location", "date", "temp_max", "temp_min", "wind_speed_max"},
+				{"Paris", "2024-06-03", "25", "14", ""},
+				{"Paris (icon)", "2024-06-03", "24", "13", "12"},
+			},
+		},
+		{
+			"hourly",
+			[]forecast.Forecast{{Hours: []forecast.Hour{
+				{Time: time.Date(2024, 6, 3, 12, 0, 0, 0, time.UTC), Temperature: 17, CloudCover: ptr(80)},
+			}}},
+			[][]string{{"time", "temperature", "cloud_cover"}, {"2024-06-03T12:00:00Z", "17", "80"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := CSVComparison(&buf, tt.forecasts); err != nil {
+				t.Fatal(err)
+			}
+			got, err := csv.NewReader(&buf).ReadAll()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !slices.EqualFunc(got, tt.want, slices.Equal) {
+				t.Errorf("CSV = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package render
+
+import (
+	"fmt"
+	"io"
+
+	"weather-app/internal/forecast"
+	"weather-app/internal/i18n"
+	"weather-app/internal/units"
+)
+
+// Difference writes how the days of one place compare with another's.
+func Difference(w io.Writer, format string, d forecast.Difference, opts Options) error {
+	switch format {
+	case "json":
+		return JSON(w, d)
+	case "yaml":
+		return YAML(w, d)
+	case "table":
+		diffTable(w, d, opts.Locale)
+		return nil
+	default:
+		return fmt.Errorf("a comparison cannot be written as %s, use table, json or yaml", format)
+	}
+}
+
+func diffTable(w io.Writer, d forecast.Difference, loc i18n.Locale) {
+	from, to := placeName(d.From), placeName(d.To)
+	fmt.Fprintf(w, "%s vs %s, differences are %s minus %s\n", from, to, to, from)
+	degrees := units.Degrees(d.Units.Temperature)
+	for _, day := range d.Days {
+		output := fmt.Sprintf("%s | High: %s vs %s %s (%s) | Low: %s vs %s %s (%s)",
+			loc.Date(day.Date.Time),
+			loc.Number(day.From.TempMax, 1), loc.Number(day.To.TempMax, 1), degrees, signed(day.TempMax, 1, loc),
+			loc.Number(day.From.TempMin, 1), loc.Number(day.To.TempMin, 1), degrees, signed(day.TempMin, 1, loc))
+		if day.Precipitation != nil {
+			output += fmt.Sprintf(" | Precip: %s vs %s %s (%s)",
+				loc.Number(*day.From.Precipitation, 2), loc.Number(*day.To.Precipitation, 2), d.Units.Precipitation, signed(*day.Precipitation, 2, loc))
+		}
+		fmt.Fprintln(w, output)
+	}
+}
+
+// signed formats a difference with its sign, "+1.5" or "-0.3".
+func signed(v float64, decimals int, loc i18n.Locale) string {
+	if v > 0 {
+		return "+" + loc.Number(v, decimals)
+	}
+	return loc.Number(v, decimals)
+}
+
+// placeName is the name of a location, or its coordinates when it has none.
+func placeName(l forecast.Location) string {
+	if l.Name == "" {
+		return fmt.Sprintf("%.2f, %.2f", l.Latitude, l.Longitude)
+	}
+	return l.Name
+}
@@ -0,0 +1,127 @@
+package render
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strings"
+
+	"weather-app/internal/forecast"
+	"weather-app/internal/i18n"
+	"weather-app/internal/units"
+)
+
+var blocks = []rune("▁▂▃▄▅▆▇█")
+
+const graphHeight = 10
+
+// sparkChar returns the block character for v on the lo..hi scale.
+func sparkChar(v, lo, hi float64) string {
+	if hi <= lo {
+		return string(blocks[len(blocks)/2])
+	}
+	i := int(math.Round((v - lo) / (hi - lo) * float64(len(blocks)-1)))
+	i = max(0, min(i, len(blocks)-1))
+	return string(blocks[i])
+}
+
+// tempRange returns the lowest low and highest high of the forecast.
+func tempRange(days []forecast.Day) (lo, hi float64) {
+	for i, day := range days {
+		if i == 0 || day.TempMin < lo {
+			lo = day.TempMin
+		}
+		if i == 0 || day.TempMax > hi {
+			hi = day.TempMax
+		}
+	}
+	return lo, hi
+}
+
+// sparklines writes one sparkline of the highs and one of the lows, both on
+// the week's overall scale.
+func sparklines(w io.Writer, f forecast.Forecast, s styler) {
+	lo, hi := tempRange(f.Days)
+	var highs, lows strings.Builder
+	for _, day := range f.Days {
+		highs.WriteString(s.temp(sparkChar(day.TempMax, lo, hi), day.TempMax, f.Units.Temperature))
+		lows.WriteString(s.temp(sparkChar(day.TempMin, lo, hi), day.TempMin, f.Units.Temperature))
+	}
+	fmt.Fprintf(w, "Highs %s  Lows %s  (%.0f to %.0f %s)\n", highs.String(), lows.String(), lo, hi, units.Degrees(f.Units.Temperature))
+}
+
+// graph draws a vertical chart with one bar per day spanning its low to its
+// high temperature.
+func graph(w io.Writer, f forecast.Forecast, s styler, loc i18n.Locale) {
+	if len(f.Days) == 0 {
+		return
+	}
+	lo, hi := tempRange(f.Days)
+	lo, hi = math.Floor(lo), math.Ceil(hi)
+	step := (hi - lo) / graphHeight
+	if step <= 0 {
+		step = 1
+	}
+
+	fmt.Fprintf(w, "  %s\n", units.Degrees(f.Units.Temperature))
+	for r := graphHeight - 1; r >= 0; r-- {
+		bottom := lo + float64(r)*step
+		top := bottom + step
+		var row strings.Builder
+		fmt.Fprintf(&row, "%4.0f ┤", top)
+		for _, day := range f.Days {
+			if day.TempMin < top && day.TempMax >= bottom {
+				row.WriteString("  " + s.temp("██", bottom, f.Units.Temperature))
+			} else {
+				row.WriteString("    ")
+			}
+		}
+		fmt.Fprintln(w, strings.TrimRight(row.String(), " "))
+	}
+
+	fmt.Fprintln(w, "     └"+strings.Repeat("─", 4*len(f.Days)))
+	var labels strings.Builder
+	labels.WriteString("      ")
+	// Weekday names repeat beyond a week, so longer forecasts are labelled
+	// with the day of the month.
+	for _, day := range f.Days {
+		label := day.Date.Format(" 02")
+		if len(f.Days) <= 7 {
+			label = fmt.Sprintf("%-3.3s", loc.Weekday(day.Date.Time))
+		}
+		labels.WriteString(" " + label)
+	}
+	fmt.Fprintln(w, labels.String())
+}
+
+// precipBars draws the precipitation of one day's hours as 24 columns, one
+// per hour of the day, on a scale up to hi. Dry hours are dots and hours
+// outside the forecast are blank. ok is false when no hour has a value.
+func precipBars(hours []forecast.Hour, hi float64) (bars string, dayMax float64, ok bool) {
+	columns := []rune(strings.Repeat(" ", 24))
+	for _, hour := range hours {
+		if hour.Precipitation == nil {
+			continue
+		}
+		v := *hour.Precipitation
+		c := '·'
+		if v > 0 {
+			c = []rune(sparkChar(v, 0, hi))[0]
+		}
+		columns[hour.Time.Hour()] = c
+		dayMax = max(dayMax, v)
+		ok = true
+	}
+	return string(columns), dayMax, ok
+}
+
+// maxPrecip returns the heaviest hourly precipitation of the forecast.
+func maxPrecip(hours []forecast.Hour) float64 {
+	var hi float64
+	for _, hour := range hours {
+		if hour.Precipitation != nil {
+			hi = max(hi, *hour.Precipitation)
+		}
+	}
+	return hi
+}
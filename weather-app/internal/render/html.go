@@ -0,0 +1,135 @@
+package render
+
+import (
+	_ "embed"
+	"fmt"
+	"html/template"
+	"io"
+	"math"
+	"strings"
+
+	"weather-app/internal/forecast"
+	"weather-app/internal/units"
+)
+
+//go:embed report.html.tmpl
+var reportTemplate string
+
+var report = template.Must(template.New("report").Parse(reportTemplate))
+
+type htmlReport struct {
+	Lang     string
+	Title    string
+	Sections []htmlSection
+}
+
+// htmlSection is one forecast of the report. Title is empty when the
+// report has a single forecast, which the page title already names.
+type htmlSection struct {
+	Title  string
+	Alerts []forecast.Alert
+	Chart  *svgChart
+	Head   []string
+	Rows   [][]string
+}
+
+// svgChart is the temperature chart of a daily forecast, laid out for the
+// template: polyline points for the highs and lows and the axis labels.
+type svgChart struct {
+	Width, Height            int
+	Left, Right, Top, Bottom float64
+	Highs, Lows              string
+	Ticks                    []svgText
+}
+
+type svgText struct {
+	X, Y   float64
+	Anchor string
+	Text   string
+}
+
+// Chart dimensions in pixels. The margins leave room for the axis labels.
+const (
+	chartWidth  = 640
+	chartHeight = 220
+	chartLeft   = 44
+	chartRight  = 16
+	chartTop    = 12
+	chartBottom = 28
+)
+
+// HTML writes f as a self-contained HTML page with a table and, for daily
+// forecasts, a chart of the highs and lows.
+func HTML(w io.Writer, f forecast.Forecast, opts Options) error {
+	return HTMLComparison(w, []forecast.Forecast{f}, opts)
+}
+
+// HTMLComparison writes one section per forecast on a single page.
+func HTMLComparison(w io.Writer, forecasts []forecast.Forecast, opts Options) error {
+	page := htmlReport{Lang: opts.Locale.Lang, Title: "Weather forecast"}
+	if page.Lang == "" {
+		page.Lang = "en"
+	}
+	if len(forecasts) == 1 && forecasts[0].Label() != "" {
+		page.Title = "Weather for " + forecasts[0].Label()
+	}
+	for _, f := range forecasts {
+		section := htmlSection{Alerts: f.Alerts, Chart: temperatureChart(f, opts)}
+		if len(forecasts) > 1 {
+			section.Title = f.Label()
+		}
+		section.Head, section.Rows = tabulate([]forecast.Forecast{f}, nil, opts.Locale)
+		page.Sections = append(page.Sections, section)
+	}
+	return report.Execute(w, page)
+}
+
+// temperatureChart plots the daily highs and lows of f, or returns nil when
+// f has no days.
+func temperatureChart(f forecast.Forecast, opts Options) *svgChart {
+	if len(f.Days) == 0 {
+		return nil
+	}
+	c := &svgChart{
+		Width:  chartWidth,
+		Height: chartHeight,
+		Left:   chartLeft,
+		Right:  chartWidth - chartRight,
+		Top:    chartTop,
+		Bottom: chartHeight - chartBottom,
+	}
+	lo, hi := tempRange(f.Days)
+	lo, hi = lo-1, hi+1
+
+	// Coordinates are rounded to a tenth of a pixel to keep the page small.
+	x := func(i int) float64 {
+		if len(f.Days) == 1 {
+			return (c.Left + c.Right) / 2
+		}
+		return math.Round((c.Left+float64(i)*(c.Right-c.Left)/float64(len(f.Days)-1))*10) / 10
+	}
+	y := func(t float64) float64 {
+		return math.Round((c.Bottom-(t-lo)/(hi-lo)*(c.Bottom-c.Top))*10) / 10
+	}
+
+	var highs, lows []string
+	for i, day := range f.Days {
+		highs = append(highs, fmt.Sprintf("%g,%g", x(i), y(day.TempMax)))
+		lows = append(lows, fmt.Sprintf("%g,%g", x(i), y(day.TempMin)))
+		label := opts.Locale.Weekday(day.Date.Time)
+		if len(f.Days) > 7 {
+			label = day.Date.Format("2")
+		}
+		c.Ticks = append(c.Ticks, svgText{X: x(i), Y: c.Bottom + 18, Anchor: "middle", Text: label})
+	}
+	c.Highs, c.Lows = strings.Join(highs, " "), strings.Join(lows, " ")
+
+	degrees := units.Degrees(f.Units.Temperature)
+	for _, t := range []float64{lo, (lo + hi) / 2, hi} {
+		c.Ticks = append(c.Ticks, svgText{
+			X: c.Left - 6, Y: y(t) + 4, Anchor: "end",
+			Text: opts.Locale.Number(t, 0) + " " + degrees,
+		})
+	}
+	return c
+}
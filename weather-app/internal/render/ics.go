@@ -0,0 +1,154 @@
+package render
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"weather-app/internal/forecast"
+	"weather-app/internal/units"
+)
+
+const icsDateLayout = "20060102"
+
+var icsEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`)
+
+// icsWriter writes content lines with CRLF endings, folded at 75 octets as
+// RFC 5545 requires.
+type icsWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (iw *icsWriter) line(name, value string) {
+	if iw.err != nil {
+		return
+	}
+	line := name + ":" + value
+	var b strings.Builder
+	// Continuation lines start with a space, leaving room for 74 octets.
+	for limit := 75; len(line) > limit; limit = 74 {
+		cut := limit
+		for !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		b.WriteString(line[:cut] + "\r\n ")
+		line = line[cut:]
+	}
+	b.WriteString(line + "\r\n")
+	_, iw.err = io.WriteString(iw.w, b.String())
+}
+
+// daySummary is the event title, e.g. "22°C, slight rain, UV 6".
+func daySummary(day forecast.Day, u forecast.Units) string {
+	parts := []string{fmt.Sprintf("%.0f%s", day.TempMax, units.Degrees(u.Temperature))}
+	if day.Description != "" {
+		parts = append(parts, strings.ToLower(day.Description[:1])+day.Description[1:])
+	}
+	if day.UVIndex != nil {
+		parts = append(parts, fmt.Sprintf("UV %.0f", *day.UVIndex))
+	}
+	return strings.Join(parts, ", ")
+}
+
+func dayDetails(day forecast.Day, u forecast.Units) string {
+	lines := []string{fmt.Sprintf("High %.1f%s, low %.1f%s", day.TempMax, units.Degrees(u.Temperature), day.TempMin, units.Degrees(u.Temperature))}
+	if day.Precipitation != nil {
+		line := fmt.Sprintf("Precipitation: %.1f %s", *day.Precipitation, u.Precipitation)
+		if likelihood := precipLikelihood(day); likelihood != "" {
+			line += " (" + likelihood + ")"
+		}
+		lines = append(lines, line)
+	}
+	if day.Snowfall != nil {
+		lines = append(lines, fmt.Sprintf("Snowfall: %.1f %s", *day.Snowfall, u.Snow))
+	}
+	if feels := feelsText(day, u.Temperature); feels != "" {
+		lines = append(lines, feels)
+	}
+	if humidity := humidityText(day, u.Temperature); humidity != "" {
+		lines = append(lines, humidity)
+	}
+	if day.PressureMean != nil {
+		line := fmt.Sprintf("Pressure: %.0f hPa", *day.PressureMean)
+		if day.PressureTrend != "" {
+			line += ", " + day.PressureTrend
+		}
+		lines = append(lines, line)
+	}
+	if day.WindSpeedMax != nil {
+		lines = append(lines, fmt.Sprintf("Wind: %.1f %s", *day.WindSpeedMax, u.WindSpeed))
+	}
+	if day.Sunrise != nil && day.Sunset != nil {
+		lines = append(lines, fmt.Sprintf("Sunrise %s, sunset %s", day.Sunrise.Format("15:04"), day.Sunset.Format("15:04")))
+	}
+	if daylight := daylightText(day); daylight != "" {
+		lines = append(lines, daylight)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// ICS writes an iCalendar document with one all-day event per forecast day.
+func ICS(w io.Writer, f forecast.Forecast, opts Options) error {
+	return ICSComparison(w, []forecast.Forecast{f}, opts)
+}
+
+// ICSComparison writes the days of several forecasts into one calendar.
+// With more than one forecast every summary starts with the location name.
+func ICSComparison(w io.Writer, forecasts []forecast.Forecast, opts Options) error {
+	for _, f := range forecasts {
+		if f.Current != nil || len(f.Hours) > 0 {
+			return errors.New("ics output needs a daily forecast")
+		}
+	}
+	stamp := opts.Timestamp
+	if stamp.IsZero() {
+		stamp = time.Now()
+	}
+
+	iw := &icsWriter{w: w}
+	iw.line("BEGIN", "VCALENDAR")
+	iw.line("VERSION", "2.0")
+	iw.line("PRODID", "-//weather-app//forecast//EN")
+	iw.line("CALSCALE", "GREGORIAN")
+	iw.line("METHOD", "PUBLISH")
+	if len(forecasts) == 1 && forecasts[0].Location.Name != "" {
+		iw.line("X-WR-CALNAME", icsEscaper.Replace("Weather "+forecasts[0].Location.Name))
+	}
+	for _, f := range forecasts {
+		for _, day := range f.Days {
+			summary := daySummary(day, f.Units)
+			if len(forecasts) > 1 {
+				summary = f.Label() + ": " + summary
+			}
+			uid := fmt.Sprintf("%s-%.2f-%.2f", day.Date.Format(icsDateLayout), f.Location.Latitude, f.Location.Longitude)
+			if f.Model != "" {
+				uid += "-" + f.Model
+			}
+			iw.line("BEGIN", "VEVENT")
+			iw.line("UID", uid+"@weather-app")
+			iw.line("DTSTAMP", stamp.UTC().Format("20060102T150405Z"))
+			iw.line("DTSTART;VALUE=DATE", day.Date.Format(icsDateLayout))
+			iw.line("DTEND;VALUE=DATE", day.Date.AddDate(0, 0, 1).Format(icsDateLayout))
+			iw.line("SUMMARY", icsEscaper.Replace(summary))
+			iw.line("DESCRIPTION", icsEscaper.Replace(dayDetails(day, f.Units)))
+			if f.Location.Name != "" {
+				iw.line("LOCATION", icsEscaper.Replace(placeTitle(f.Location)))
+			}
+			iw.line("TRANSP", "TRANSPARENT")
+			iw.line("END", "VEVENT")
+		}
+	}
+	iw.line("END", "VCALENDAR")
+	return iw.err
+}
+
+func placeTitle(l forecast.Location) string {
+	if l.Country == "" {
+		return l.Name
+	}
+	return l.Name + ", " + l.Country
+}
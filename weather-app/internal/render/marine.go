@@ -0,0 +1,35 @@
+package render
+
+import (
+	"fmt"
+	"io"
+
+	"weather-app/internal/forecast"
+	"weather-app/internal/i18n"
+)
+
+// seaText describes one wave train, e.g. "1.2 m  6 s from 270°", leaving
+// out the values the forecast lacks.
+func seaText(height, period, direction *float64, loc i18n.Locale) string {
+	if height == nil {
+		return "-"
+	}
+	text := loc.Number(*height, 1) + " m"
+	if period != nil {
+		text += fmt.Sprintf(" %4s s", loc.Number(*period, 1))
+	}
+	if direction != nil {
+		text += fmt.Sprintf(" from %3.0f°", *direction)
+	}
+	return text
+}
+
+// marineTable prints one line per day with the wind waves and the swell,
+// which is what surfers and sailors look at first.
+func marineTable(w io.Writer, f forecast.Forecast, loc i18n.Locale) {
+	for _, day := range f.Sea {
+		fmt.Fprintf(w, "%s | Waves: %s | Swell: %s\n", loc.Date(day.Date.Time),
+			seaText(day.WaveHeight, day.WavePeriod, day.WaveDirection, loc),
+			seaText(day.SwellHeight, day.SwellPeriod, day.SwellDirection, loc))
+	}
+}
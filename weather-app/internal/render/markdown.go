@@ -0,0 +1,239 @@
+package render
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"weather-app/internal/forecast"
+	"weather-app/internal/i18n"
+	"weather-app/internal/units"
+)
+
+// Markdown writes f as a GitHub-flavored Markdown table under a heading
+// with the location, preceded by a list of its alerts.
+func Markdown(w io.Writer, f forecast.Forecast, opts Options) error {
+	return MarkdownComparison(w, []forecast.Forecast{f}, opts)
+}
+
+// MarkdownComparison writes the rows of several forecasts in one table,
+// each row starting with the location, and the model when one was chosen.
+func MarkdownComparison(w io.Writer, forecasts []forecast.Forecast, opts Options) error {
+	if len(forecasts) == 0 {
+		return nil
+	}
+	var prefix []string
+	if len(forecasts) > 1 {
+		prefix = []string{"Location"}
+	} else if label := forecasts[0].Label(); label != "" {
+		fmt.Fprintf(w, "### %s\n\n", escapeMarkdown(label))
+	}
+
+	var alerts []forecast.Alert
+	for _, f := range forecasts {
+		alerts = append(alerts, f.Alerts...)
+	}
+
+	if len(alerts) > 0 {
+		markdownAlerts(w, alerts)
+	}
+	if head, rows := tabulate(forecasts, prefix, opts.Locale); head != nil {
+		markdownTable(w, head, rows)
+	}
+	return nil
+}
+
+// tabulate lays out the current conditions, marine, hourly or daily rows of
+// forecasts for people to read, with only the columns that have values.
+func tabulate(forecasts []forecast.Forecast, prefix []string, loc i18n.Locale) (head []string, rows [][]string) {
+	var days []forecast.Day
+	var hours []forecast.Hour
+	var sea []forecast.SeaDay
+	var currents []forecast.Current
+	for _, f := range forecasts {
+		days = append(days, f.Days...)
+		hours = append(hours, f.Hours...)
+		sea = append(sea, f.Sea...)
+		if f.Current != nil {
+			currents = append(currents, *f.Current)
+		}
+	}
+
+	u := forecasts[0].Units
+	switch {
+	case len(currents) > 0:
+		columns := readableCurrentColumns(u, loc)
+		head = header(prefix, columns)
+		for _, f := range forecasts {
+			if f.Current != nil {
+				rows = append(rows, records(rowPrefix(prefix, f), columns, []forecast.Current{*f.Current})...)
+			}
+		}
+	case len(sea) > 0:
+		columns := present(readableSeaColumns(loc), sea)
+		head = header(prefix, columns)
+		for _, f := range forecasts {
+			rows = append(rows, records(rowPrefix(prefix, f), columns, f.Sea)...)
+		}
+	case len(hours) > 0:
+		columns := present(readableHourColumns(u, loc), hours)
+		head = header(prefix, columns)
+		for _, f := range forecasts {
+			rows = append(rows, records(rowPrefix(prefix, f), columns, f.Hours)...)
+		}
+	case len(days) > 0:
+		columns := present(readableDayColumns(u, loc), days)
+		head = header(prefix, columns)
+		for _, f := range forecasts {
+			rows = append(rows, records(rowPrefix(prefix, f), columns, f.Days)...)
+		}
+	}
+	return head, rows
+}
+
+func markdownTable(w io.Writer, head []string, rows [][]string) {
+	line := func(cells []string) {
+		escaped := make([]string, len(cells))
+		for i, c := range cells {
+			escaped[i] = escapeMarkdown(c)
+		}
+		fmt.Fprintf(w, "| %s |\n", strings.Join(escaped, " | "))
+	}
+	line(head)
+	fmt.Fprintf(w, "|%s\n", strings.Repeat(" --- |", len(head)))
+	for _, row := range rows {
+		line(row)
+	}
+}
+
+// escapeMarkdown keeps pipes in text from ending a table cell.
+func escapeMarkdown(s string) string {
+	return strings.ReplaceAll(s, "|", `\|`)
+}
+
+func markdownAlerts(w io.Writer, alerts []forecast.Alert) {
+	for _, a := range alerts {
+		line := "- **" + escapeMarkdown(a.Event) + "**"
+		if a.Severity != "" {
+			line += " (" + a.Severity + ")"
+		}
+		if a.Headline != "" {
+			line += ": " + escapeMarkdown(a.Headline)
+		}
+		fmt.Fprintln(w, line)
+	}
+	fmt.Fprintln(w)
+}
+
+// The readable columns format values with their locale for the Markdown and
+// HTML tables; units go in the column names.
+
+// withUnit names a column with its unit, e.g. "Wind (km/h)".
+func withUnit(name, unit string) string {
+	if unit == "" {
+		return name
+	}
+	return name + " (" + unit + ")"
+}
+
+func formatted(v *float64, format func(float64) string) (string, bool) {
+	if v == nil {
+		return "", false
+	}
+	return format(*v), true
+}
+
+func readableDayColumns(u forecast.Units, loc i18n.Locale) []column[forecast.Day] {
+	num := func(decimals int) func(float64) string {
+		return func(v float64) string { return loc.Number(v, decimals) }
+	}
+	percent := func(v float64) string { return loc.Number(v, 0) + "%" }
+	dist := func(v float64) string { return distance(v, u, loc) }
+	degrees := func(v float64) string { return loc.Number(v, 0) + "°" }
+	temp := units.Degrees(u.Temperature)
+	return []column[forecast.Day]{
+		{"Date", func(d forecast.Day) (string, bool) { return loc.Date(d.Date.Time), true }},
+		{withUnit("High", temp), func(d forecast.Day) (string, bool) { return loc.Number(d.TempMax, 0), true }},
+		{withUnit("Low", temp), func(d forecast.Day) (string, bool) { return loc.Number(d.TempMin, 0), true }},
+		{withUnit("Feels", temp), func(d forecast.Day) (string, bool) {
+			if d.FeelsMax == nil || d.FeelsMin == nil {
+				return "", false
+			}
+			return loc.Number(*d.FeelsMax, 0) + "/" + loc.Number(*d.FeelsMin, 0), true
+		}},
+		{"Conditions", func(d forecast.Day) (string, bool) { return d.Description, d.Description != "" }},
+		{withUnit("Precip", u.Precipitation), func(d forecast.Day) (string, bool) { return formatted(d.Precipitation, num(2)) }},
+		{"Chance", func(d forecast.Day) (string, bool) { return formatted(d.PrecipChance, percent) }},
+		{withUnit("Snow", u.Snow), func(d forecast.Day) (string, bool) { return formatted(d.Snowfall, num(1)) }},
+		{"Humidity", func(d forecast.Day) (string, bool) { return formatted(d.HumidityMean, percent) }},
+		{withUnit("Pressure", "hPa"), func(d forecast.Day) (string, bool) { return formatted(d.PressureMean, num(0)) }},
+		{"Clouds", func(d forecast.Day) (string, bool) { return formatted(d.CloudCover, percent) }},
+		{"Visibility", func(d forecast.Day) (string, bool) { return formatted(d.Visibility, dist) }},
+		{"UV", func(d forecast.Day) (string, bool) { return formatted(d.UVIndex, num(1)) }},
+		{withUnit("Wind", u.WindSpeed), func(d forecast.Day) (string, bool) { return formatted(d.WindSpeedMax, num(1)) }},
+		{withUnit("Gusts", u.WindSpeed), func(d forecast.Day) (string, bool) { return formatted(d.WindGustsMax, num(1)) }},
+		{"From", func(d forecast.Day) (string, bool) { return formatted(d.WindDirection, degrees) }},
+		{"Sunrise", func(d forecast.Day) (string, bool) {
+			if d.Sunrise == nil {
+				return "", false
+			}
+			return loc.Time(*d.Sunrise), true
+		}},
+		{"Sunset", func(d forecast.Day) (string, bool) {
+			if d.Sunset == nil {
+				return "", false
+			}
+			return loc.Time(*d.Sunset), true
+		}},
+		{"Daylight", func(d forecast.Day) (string, bool) { return formatted(d.Daylight, hoursMinutes) }},
+	}
+}
+
+func readableHourColumns(u forecast.Units, loc i18n.Locale) []column[forecast.Hour] {
+	num := func(decimals int) func(float64) string {
+		return func(v float64) string { return loc.Number(v, decimals) }
+	}
+	percent := func(v float64) string { return loc.Number(v, 0) + "%" }
+	dist := func(v float64) string { return distance(v, u, loc) }
+	degrees := func(v float64) string { return loc.Number(v, 0) + "°" }
+	temp := units.Degrees(u.Temperature)
+	return []column[forecast.Hour]{
+		{"Time", func(h forecast.Hour) (string, bool) { return loc.Day(h.Time) + " " + loc.Time(h.Time), true }},
+		{withUnit("Temp", temp), func(h forecast.Hour) (string, bool) { return loc.Number(h.Temperature, 0), true }},
+		{"Precip", func(h forecast.Hour) (string, bool) { return formatted(h.PrecipProbability, percent) }},
+		{withUnit("Wind", u.WindSpeed), func(h forecast.Hour) (string, bool) { return formatted(h.WindSpeed, num(1)) }},
+		{"From", func(h forecast.Hour) (string, bool) { return formatted(h.WindDirection, degrees) }},
+		{withUnit("Snow depth", u.Snow), func(h forecast.Hour) (string, bool) { return formatted(h.SnowDepth, num(1)) }},
+		{"Humidity", func(h forecast.Hour) (string, bool) { return formatted(h.Humidity, percent) }},
+		{withUnit("Dew point", temp), func(h forecast.Hour) (string, bool) { return formatted(h.DewPoint, num(0)) }},
+		{withUnit("Pressure", "hPa"), func(h forecast.Hour) (string, bool) { return formatted(h.Pressure, num(0)) }},
+		{"Clouds", func(h forecast.Hour) (string, bool) { return formatted(h.CloudCover, percent) }},
+		{"Visibility", func(h forecast.Hour) (string, bool) { return formatted(h.Visibility, dist) }},
+	}
+}
+
+func readableCurrentColumns(u forecast.Units, loc i18n.Locale) []column[forecast.Current] {
+	return []column[forecast.Current]{
+		{"Time", func(c forecast.Current) (string, bool) { return loc.Time(c.Time), true }},
+		{withUnit("Temp", units.Degrees(u.Temperature)), func(c forecast.Current) (string, bool) { return loc.Number(c.Temperature, 1), true }},
+		{"Conditions", func(c forecast.Current) (string, bool) { return c.Description, true }},
+		{withUnit("Wind", u.WindSpeed), func(c forecast.Current) (string, bool) { return loc.Number(c.WindSpeed, 1), true }},
+		{"From", func(c forecast.Current) (string, bool) { return loc.Number(c.WindDirection, 0) + "°", true }},
+	}
+}
+
+func readableSeaColumns(loc i18n.Locale) []column[forecast.SeaDay] {
+	num := func(decimals int) func(float64) string {
+		return func(v float64) string { return loc.Number(v, decimals) }
+	}
+	degrees := func(v float64) string { return loc.Number(v, 0) + "°" }
+	return []column[forecast.SeaDay]{
+		{"Date", func(d forecast.SeaDay) (string, bool) { return loc.Date(d.Date.Time), true }},
+		{withUnit("Waves", "m"), func(d forecast.SeaDay) (string, bool) { return formatted(d.WaveHeight, num(1)) }},
+		{withUnit("Period", "s"), func(d forecast.SeaDay) (string, bool) { return formatted(d.WavePeriod, num(1)) }},
+		{"From", func(d forecast.SeaDay) (string, bool) { return formatted(d.WaveDirection, degrees) }},
+		{withUnit("Swell", "m"), func(d forecast.SeaDay) (string, bool) { return formatted(d.SwellHeight, num(1)) }},
+		{withUnit("Swell period", "s"), func(d forecast.SeaDay) (string, bool) { return formatted(d.SwellPeriod, num(1)) }},
+		{"Swell from", func(d forecast.SeaDay) (string, bool) { return formatted(d.SwellDirection, degrees) }},
+	}
+}
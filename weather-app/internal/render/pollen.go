@@ -0,0 +1,34 @@
+package render
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"weather-app/internal/forecast"
+	"weather-app/internal/i18n"
+)
+
+// pollenColors highlights the levels worth planning around.
+var pollenColors = map[string]int{
+	forecast.PollenModerate: yellow,
+	forecast.PollenHigh:     208,
+	forecast.PollenVeryHigh: red,
+}
+
+// pollenTable prints one line per day with the count and level of each
+// kind of pollen, e.g. "Birch: 120 high".
+func pollenTable(w io.Writer, f forecast.Forecast, s styler, loc i18n.Locale) {
+	for _, day := range f.Pollen {
+		var parts []string
+		for _, c := range day.Counts() {
+			text := fmt.Sprintf("%s: %s %s", c.Kind, loc.Number(c.Count, 0), c.Level)
+			if color, ok := pollenColors[c.Level]; ok {
+				text = s.paint(text, color)
+			}
+			parts = append(parts, text)
+		}
+		fmt.Fprintf(w, "%s | %s\n", loc.Date(day.Date.Time), strings.Join(parts, " | "))
+	}
+	fmt.Fprintln(w, "Highest hourly count of the day, in grains/m³")
+}
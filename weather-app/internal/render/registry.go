@@ -0,0 +1,101 @@
+package render
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"slices"
+	"strings"
+	"sync"
+
+	"weather-app/internal/forecast"
+)
+
+// Renderer turns a forecast into an output format of its own. Formats
+// beyond Formats are added by registering one.
+type Renderer interface {
+	Render(f forecast.Forecast) ([]byte, error)
+}
+
+// RendererFunc adapts a function to Renderer.
+type RendererFunc func(forecast.Forecast) ([]byte, error)
+
+func (fn RendererFunc) Render(f forecast.Forecast) ([]byte, error) {
+	return fn(f)
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]Renderer{}
+)
+
+// Register makes r available as the output format name. It panics when
+// name is a built-in format or already registered.
+func Register(name string, r Renderer) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	if slices.Contains(Formats, name) {
+		panic("render: " + name + " is a built-in format")
+	}
+	if _, ok := registry[name]; ok {
+		panic("render: " + name + " registered twice")
+	}
+	registry[name] = r
+}
+
+func lookup(name string) (Renderer, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	r, ok := registry[name]
+	return r, ok
+}
+
+// Registered lists the names of the registered formats, sorted.
+func Registered() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// Command is a Renderer that runs an external program: the forecast goes
+// to its standard input as JSON, the same document -o json writes, and
+// what it prints is the output.
+type Command struct {
+	Path string
+	Args []string
+}
+
+// ParseCommand splits a command line at spaces into a Command.
+func ParseCommand(line string) (Command, error) {
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return Command{}, errors.New("empty render command")
+	}
+	return Command{Path: fields[0], Args: fields[1:]}, nil
+}
+
+func (c Command) Render(f forecast.Forecast) ([]byte, error) {
+	in, err := json.Marshal(f)
+	if err != nil {
+		return nil, err
+	}
+	cmd := exec.Command(c.Path, c.Args...)
+	cmd.Stdin = bytes.NewReader(in)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("%s: %w: %s", c.Path, err, msg)
+		}
+		return nil, fmt.Errorf("%s: %w", c.Path, err)
+	}
+	return out, nil
+}
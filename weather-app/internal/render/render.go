@@ -0,0 +1,155 @@
+// Package render turns forecasts into terminal tables and machine-readable
+// documents.
+package render
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"weather-app/internal/forecast"
+	"weather-app/internal/i18n"
+)
+
+type Options struct {
+	// Color enables ANSI colors in table output.
+	Color bool
+	// Spark replaces the star bars of the daily table with sparkline blocks.
+	Spark bool
+	// Icons adds weather icons to table output: "emoji", "ascii" or "" for
+	// none.
+	Icons string
+	// Graph draws a vertical chart of daily highs and lows instead of the
+	// daily table.
+	Graph bool
+	// Timestamp is the creation time written to ics output. Zero means now.
+	Timestamp time.Time
+	// Locale formats dates, times and numbers in table output.
+	Locale i18n.Locale
+	// UVAdvice adds the WHO exposure category and protection advice to
+	// the UV index in table output.
+	UVAdvice bool
+}
+
+func JSON(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// Formats lists the supported output formats.
+var Formats = []string{"table", "json", "yaml", "csv", "markdown", "html", "ics", "waybar", "i3status"}
+
+// Supported reports whether format is one of Formats or registered.
+func Supported(format string) bool {
+	for _, f := range Formats {
+		if f == format {
+			return true
+		}
+	}
+	_, ok := lookup(format)
+	return ok
+}
+
+// Render writes f in the given format.
+func Render(w io.Writer, format string, f forecast.Forecast, opts Options) error {
+	switch format {
+	case "json":
+		return JSON(w, f)
+	case "yaml":
+		return YAML(w, f)
+	case "csv":
+		return CSV(w, f)
+	case "markdown":
+		return Markdown(w, f, opts)
+	case "html":
+		return HTML(w, f, opts)
+	case "ics":
+		return ICS(w, f, opts)
+	case "waybar":
+		return Waybar(w, f, opts)
+	case "i3status":
+		return I3Status(w, f, opts)
+	case "table":
+		s := styler{color: opts.Color}
+		if len(f.Alerts) > 0 {
+			alertList(w, f.Alerts, s)
+		}
+		if f.Current != nil {
+			current(w, f, s, opts.Icons, opts.Locale)
+		} else if len(f.Sea) > 0 {
+			marineTable(w, f, opts.Locale)
+		} else if len(f.Pollen) > 0 {
+			pollenTable(w, f, s, opts.Locale)
+		} else if len(f.Agri) > 0 {
+			agriTable(w, f, opts.Locale)
+		} else if len(f.Hours) > 0 {
+			hourlyTable(w, f, s, opts.Locale)
+		} else if opts.Graph {
+			graph(w, f, s, opts.Locale)
+		} else {
+			dailyTable(w, f, s, opts.Spark, opts.Icons, opts.UVAdvice, opts.Locale)
+		}
+		if e, ok := elevation(f, opts.Locale); ok && f.Current == nil && len(f.Sea) == 0 && len(f.Pollen) == 0 && len(f.Agri) == 0 {
+			fmt.Fprintf(w, "Elevation: %s\n", e)
+		}
+		if f.Summary != nil {
+			summary(w, *f.Summary, f.Units, opts.Locale)
+		}
+		return nil
+	default:
+		r, ok := lookup(format)
+		if !ok {
+			return fmt.Errorf("unknown output format %q", format)
+		}
+		return write(w, r, f)
+	}
+}
+
+// write renders f with r to w.
+func write(w io.Writer, r Renderer, f forecast.Forecast) error {
+	out, err := r.Render(f)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(out)
+	return err
+}
+
+// Comparison writes several forecasts side by side.
+func Comparison(w io.Writer, format string, forecasts []forecast.Forecast, opts Options) error {
+	switch format {
+	case "json":
+		return JSON(w, forecasts)
+	case "yaml":
+		return YAML(w, forecasts)
+	case "csv":
+		return CSVComparison(w, forecasts)
+	case "markdown":
+		return MarkdownComparison(w, forecasts, opts)
+	case "html":
+		return HTMLComparison(w, forecasts, opts)
+	case "ics":
+		return ICSComparison(w, forecasts, opts)
+	case "waybar":
+		return waybar(w, forecasts, opts)
+	case "i3status":
+		return i3status(w, forecasts, opts)
+	case "table":
+		comparisonTable(w, forecasts, styler{color: opts.Color}, opts.Locale)
+		return nil
+	default:
+		// Registered formats render each forecast in turn.
+		r, ok := lookup(format)
+		if !ok {
+			return fmt.Errorf("unknown output format %q", format)
+		}
+		for _, f := range forecasts {
+			if err := write(w, r, f); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
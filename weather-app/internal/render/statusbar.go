@@ -0,0 +1,143 @@
+package render
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"weather-app/internal/forecast"
+	"weather-app/internal/units"
+	"weather-app/internal/wmo"
+)
+
+// status is what a status bar module shows: a short text, the kind of
+// weather for styling and a longer tooltip.
+type status struct {
+	text    string
+	short   string
+	kind    string
+	tooltip []string
+}
+
+// statusOf sums up the current conditions, the first hour or today's
+// forecast, whichever f has, as e.g. "⛅ 18°/10°C".
+func statusOf(f forecast.Forecast, opts Options) status {
+	degrees := units.Degrees(f.Units.Temperature)
+	icons := opts.Icons
+	if icons == "" {
+		icons = "emoji"
+	}
+	withIcon := func(code *int, text string) string {
+		if code == nil {
+			return text
+		}
+		return wmo.Icon(*code, icons) + " " + text
+	}
+	s := status{kind: "unknown", tooltip: []string{placeName(f.Location)}}
+	switch {
+	case f.Current != nil:
+		c := f.Current
+		s.short = fmt.Sprintf("%.0f%s", c.Temperature, degrees)
+		s.text = withIcon(&c.WeatherCode, s.short)
+		s.kind = wmo.Kind(c.WeatherCode)
+		s.tooltip = append(s.tooltip, fmt.Sprintf("%s, wind %.0f %s", c.Description, c.WindSpeed, f.Units.WindSpeed))
+	case len(f.Hours) > 0:
+		h := f.Hours[0]
+		s.short = fmt.Sprintf("%.0f%s", h.Temperature, degrees)
+		s.text = s.short
+		for _, h := range f.Hours[:min(len(f.Hours), 12)] {
+			s.tooltip = append(s.tooltip, fmt.Sprintf("%s  %.0f%s", opts.Locale.Time(h.Time), h.Temperature, degrees))
+		}
+	case len(f.Days) > 0:
+		day := f.Days[0]
+		s.short = fmt.Sprintf("%.0f°/%.0f%s", day.TempMax, day.TempMin, degrees)
+		s.text = withIcon(day.WeatherCode, s.short)
+		if day.WeatherCode != nil {
+			s.kind = wmo.Kind(*day.WeatherCode)
+		}
+		for _, day := range f.Days {
+			line := fmt.Sprintf("%s  %.0f°/%.0f%s", opts.Locale.Weekday(day.Date.Time), day.TempMax, day.TempMin, degrees)
+			if day.Description != "" {
+				line += "  " + day.Description
+			}
+			if day.PrecipChance != nil {
+				line += fmt.Sprintf("  %.0f%%", *day.PrecipChance)
+			}
+			s.tooltip = append(s.tooltip, line)
+		}
+	}
+	return s
+}
+
+// waybarModule is the JSON a waybar custom module with "return-type": "json"
+// reads: the class can be styled in its CSS, e.g. #custom-weather.rain.
+type waybarModule struct {
+	Text    string   `json:"text"`
+	Tooltip string   `json:"tooltip"`
+	Class   []string `json:"class"`
+	Alt     string   `json:"alt"`
+}
+
+// Waybar writes f as a waybar custom module, on a single line.
+func Waybar(w io.Writer, f forecast.Forecast, opts Options) error {
+	return waybar(w, []forecast.Forecast{f}, opts)
+}
+
+// waybar writes one module for several places, as a bar has room for one:
+// the texts are joined and each is named.
+func waybar(w io.Writer, forecasts []forecast.Forecast, opts Options) error {
+	var texts, tooltip, class []string
+	for _, f := range forecasts {
+		s := statusOf(f, opts)
+		if len(forecasts) > 1 {
+			s.text = placeName(f.Location) + " " + s.text
+		}
+		texts = append(texts, s.text)
+		if len(tooltip) > 0 {
+			tooltip = append(tooltip, "")
+		}
+		tooltip = append(tooltip, s.tooltip...)
+		for _, a := range f.Alerts {
+			tooltip = append(tooltip, "⚠ "+a.Event)
+		}
+		if !slices.Contains(class, s.kind) {
+			class = append(class, s.kind)
+		}
+		if len(f.Alerts) > 0 && !slices.Contains(class, "alert") {
+			class = append(class, "alert")
+		}
+	}
+	// Waybar reads tooltips as Pango markup.
+	escaped := strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(strings.Join(tooltip, "\n"))
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	return enc.Encode(waybarModule{Text: strings.Join(texts, " | "), Tooltip: escaped, Class: class, Alt: class[0]})
+}
+
+// I3Status writes f as an i3blocks block: the full text, then the short
+// text i3bar falls back to when space runs out, then a color for alerts.
+func I3Status(w io.Writer, f forecast.Forecast, opts Options) error {
+	return i3status(w, []forecast.Forecast{f}, opts)
+}
+
+func i3status(w io.Writer, forecasts []forecast.Forecast, opts Options) error {
+	var full, short []string
+	alert := false
+	for _, f := range forecasts {
+		s := statusOf(f, opts)
+		if len(forecasts) > 1 {
+			s.text = placeName(f.Location) + " " + s.text
+		}
+		full = append(full, s.text)
+		short = append(short, s.short)
+		alert = alert || len(f.Alerts) > 0
+	}
+	fmt.Fprintln(w, strings.Join(full, " | "))
+	fmt.Fprintln(w, strings.Join(short, " | "))
+	if alert {
+		fmt.Fprintln(w, "#FF5555")
+	}
+	return nil
+}
@@ -0,0 +1,595 @@
+package render
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"weather-app/internal/forecast"
+	"weather-app/internal/i18n"
+	"weather-app/internal/units"
+	"weather-app/internal/wmo"
+)
+
+// barWidth is the number of cells in the daily range bar.
+const barWidth = 10
+
+// rangeBar draws a day's low to high as stars on the lo to hi scale of the
+// whole forecast, so the bars of different days line up. The coldest low
+// starts at the first cell and the warmest high ends at the last.
+func rangeBar(low, high, lo, hi float64) string {
+	from := barCell(low, lo, hi)
+	to := max(barCell(high, lo, hi), from)
+	return strings.Repeat(" ", from) + strings.Repeat("*", to-from+1) + strings.Repeat(" ", barWidth-1-to)
+}
+
+// barCell is the cell of v on the lo to hi scale.
+func barCell(v, lo, hi float64) int {
+	if hi <= lo {
+		return barWidth / 2
+	}
+	c := int(math.Round((v - lo) / (hi - lo) * (barWidth - 1)))
+	return max(0, min(c, barWidth-1))
+}
+
+// spreadBar is rangeBar with dashes on either side of the stars, out to the
+// ensemble's 10th percentile of the low and 90th percentile of the high.
+func spreadBar(day forecast.Day, lo, hi float64) string {
+	bar := []byte(strings.Repeat(" ", barWidth))
+	from := barCell(min(*day.TempMinP10, day.TempMin), lo, hi)
+	to := barCell(max(*day.TempMaxP90, day.TempMax), lo, hi)
+	for i := from; i <= to; i++ {
+		bar[i] = '-'
+	}
+	from = barCell(day.TempMin, lo, hi)
+	to = max(barCell(day.TempMax, lo, hi), from)
+	for i := from; i <= to; i++ {
+		bar[i] = '*'
+	}
+	return string(bar)
+}
+
+// hasSpread reports whether day has the ensemble range spreadBar draws.
+func hasSpread(day forecast.Day) bool {
+	return day.TempMinP10 != nil && day.TempMaxP90 != nil
+}
+
+// spreadText describes the likely range of the high and the low, e.g.
+// "Likely: 17-20/9-12 °C".
+func spreadText(day forecast.Day, unit string) string {
+	if day.TempMaxP10 == nil || day.TempMaxP90 == nil || day.TempMinP10 == nil || day.TempMinP90 == nil {
+		return ""
+	}
+	return fmt.Sprintf("Likely: %.0f-%.0f/%.0f-%.0f %s",
+		*day.TempMaxP10, *day.TempMaxP90, *day.TempMinP10, *day.TempMinP90, units.Degrees(unit))
+}
+
+// notAvailable stands in for a value that other days of the forecast have
+// but a day lacks, e.g. past the range of the model.
+const notAvailable = "n/a"
+
+// dayFields records which optional values any day of a forecast has.
+type dayFields struct {
+	feels, sunrise, sunset, daylight, precip, snow, humidity, pressure, clouds, uv, wind bool
+}
+
+func fieldsOf(days []forecast.Day) dayFields {
+	var has dayFields
+	for _, day := range days {
+		has.feels = has.feels || day.FeelsMax != nil || day.FeelsMin != nil
+		has.sunrise = has.sunrise || day.Sunrise != nil
+		has.sunset = has.sunset || day.Sunset != nil
+		has.daylight = has.daylight || day.Daylight != nil || day.Sunshine != nil
+		has.precip = has.precip || day.Precipitation != nil || day.PrecipChance != nil || day.PrecipHours != nil
+		has.snow = has.snow || day.Snowfall != nil
+		has.humidity = has.humidity || day.HumidityMean != nil || day.DewPointMean != nil
+		has.pressure = has.pressure || day.PressureMean != nil
+		has.clouds = has.clouds || day.CloudCover != nil || day.Visibility != nil
+		has.uv = has.uv || day.UVIndex != nil
+		has.wind = has.wind || day.WindSpeedMax != nil
+	}
+	return has
+}
+
+func dailyTable(w io.Writer, f forecast.Forecast, s styler, spark bool, icons string, uvAdvice bool, loc i18n.Locale) {
+	if spark {
+		sparklines(w, f, s)
+	}
+	has := fieldsOf(f.Days)
+	lo, hi := tempRange(f.Days)
+	for _, day := range f.Days {
+		if hasSpread(day) {
+			lo, hi = min(lo, *day.TempMinP10), max(hi, *day.TempMaxP90)
+		}
+	}
+
+	for i, day := range f.Days {
+		if day.Observed && i == 0 {
+			fmt.Fprintln(w, section("Observed"))
+		} else if !day.Observed && i > 0 && f.Days[i-1].Observed {
+			fmt.Fprintln(w, section("Forecast"))
+		}
+		temp := day.TempMax
+
+		bar := rangeBar(day.TempMin, temp, lo, hi)
+		if spark {
+			bar = sparkChar(temp, lo, hi)
+		} else if hasSpread(day) {
+			bar = spreadBar(day, lo, hi)
+		}
+
+		output := fmt.Sprintf("%s %s | %s",
+			s.temp(bar, temp, f.Units.Temperature),
+			s.temp(fmt.Sprintf("%02d/%02d %s", int(temp), int(day.TempMin), units.Degrees(f.Units.Temperature)), temp, f.Units.Temperature),
+			loc.Date(day.Date.Time))
+
+		if spread := spreadText(day, f.Units.Temperature); spread != "" {
+			output += " | " + spread
+		}
+
+		if feels := feelsText(day, f.Units.Temperature); feels != "" {
+			output += " | " + feels
+		} else if has.feels {
+			output += " | Feels: " + notAvailable
+		}
+
+		if day.WeatherCode != nil {
+			output += " | " + condition(*day.WeatherCode, day.Description, icons)
+		} else if day.Description != "" {
+			output += " | " + day.Description
+		}
+
+		if day.Sunrise != nil {
+			output += " | Sunrise: " + loc.Time(*day.Sunrise)
+		} else if has.sunrise {
+			output += " | Sunrise: " + notAvailable
+		}
+
+		if day.Sunset != nil {
+			output += " | Sunset: " + loc.Time(*day.Sunset)
+		} else if has.sunset {
+			output += " | Sunset: " + notAvailable
+		}
+
+		if daylight := daylightText(day); daylight != "" {
+			output += " | " + daylight
+		} else if has.daylight {
+			output += " | Daylight: " + notAvailable
+		}
+
+		if day.Precipitation != nil {
+			precip := fmt.Sprintf("Precip: %s %s", loc.Number(*day.Precipitation, 2), f.Units.Precipitation)
+			if likelihood := precipLikelihood(day); likelihood != "" {
+				precip += " (" + likelihood + ")"
+			}
+			output += " | " + s.precip(precip)
+		} else if likelihood := precipLikelihood(day); likelihood != "" {
+			output += " | " + s.precip("Precip: "+likelihood)
+		} else if has.precip {
+			output += " | Precip: " + notAvailable
+		}
+
+		if day.Snowfall != nil {
+			output += " | " + s.precip(fmt.Sprintf("Snow: %s %s", loc.Number(*day.Snowfall, 1), f.Units.Snow))
+		} else if has.snow {
+			output += " | Snow: " + notAvailable
+		}
+
+		if humidity := humidityText(day, f.Units.Temperature); humidity != "" {
+			output += " | " + humidity
+		} else if has.humidity {
+			output += " | Humidity: " + notAvailable
+		}
+
+		if day.PressureMean != nil {
+			output += fmt.Sprintf(" | Pressure: %.0f hPa", *day.PressureMean)
+			if arrow, ok := trendArrows[day.PressureTrend]; ok {
+				output += " " + arrow
+			}
+		} else if has.pressure {
+			output += " | Pressure: " + notAvailable
+		}
+
+		if clouds := cloudsText(day.CloudCover, day.Visibility, f.Units, loc); clouds != "" {
+			output += " | " + clouds
+		} else if has.clouds {
+			output += " | Clouds: " + notAvailable
+		}
+
+		if day.UVIndex != nil {
+			text := "UV Index: " + loc.Number(*day.UVIndex, 1)
+			if uvAdvice {
+				c := UVCategoryOf(*day.UVIndex)
+				text += fmt.Sprintf(" %s (%s)", c.Name, c.Advice)
+			}
+			output += " | " + s.uv(text, *day.UVIndex, uvAdvice)
+		} else if has.uv {
+			output += " | UV Index: " + notAvailable
+		}
+
+		if day.WindSpeedMax != nil {
+			output += fmt.Sprintf(" | Wind: %s %s", loc.Number(*day.WindSpeedMax, 1), f.Units.WindSpeed)
+			if day.WindGustsMax != nil {
+				output += " (gusts " + loc.Number(*day.WindGustsMax, 1) + ")"
+			}
+			if day.WindDirection != nil {
+				output += " from " + windFrom(*day.WindDirection)
+			}
+		} else if has.wind {
+			output += " | Wind: " + notAvailable
+		}
+
+		if day.Score != nil {
+			text := fmt.Sprintf("%s: %s/10", activityName(f.Activity), loc.Number(*day.Score, 1))
+			output += " | " + s.score(text, *day.Score)
+		}
+
+		if len(day.Wear) > 0 {
+			output += " | Wear: " + strings.Join(day.Wear, ", ")
+		}
+
+		if len(day.Changes) > 0 {
+			output += " | " + s.paint("Changed: "+changesText(day.Changes, f.Units, loc), yellow)
+		}
+
+		if day.Warning != "" {
+			output += " | " + s.warning(day.Warning)
+		}
+
+		fmt.Fprintln(w, output)
+	}
+
+	if f.ChangedSince != nil && len(f.Days) > 0 {
+		at := f.ChangedSince.In(f.Days[0].Date.Location())
+		fmt.Fprintf(w, "Changes since the forecast fetched %s %s\n", loc.Date(at), loc.Time(at))
+	}
+}
+
+// summary writes the -summary block under the daily table.
+func summary(w io.Writer, s forecast.Summary, u forecast.Units, loc i18n.Locale) {
+	degrees := units.Degrees(u.Temperature)
+	fmt.Fprintf(w, "\n%s\n", section(fmt.Sprintf("Summary of %d days", s.Days)))
+	fmt.Fprintf(w, "Warmest: %s, %d %s | Coldest: %s, %d %s\n",
+		loc.Date(s.Warmest.Time), int(s.WarmestHigh), degrees, loc.Date(s.Coldest.Time), int(s.ColdestLow), degrees)
+	output := fmt.Sprintf("Average: %s/%s %s", loc.Number(s.MeanHigh, 1), loc.Number(s.MeanLow, 1), degrees)
+	if s.Precipitation != nil {
+		decimals := 1
+		if u.Precipitation == units.Inches {
+			decimals = 2
+		}
+		output += fmt.Sprintf(" | Precipitation: %s %s | Rainy days: %d", loc.Number(*s.Precipitation, decimals), u.Precipitation, *s.RainyDays)
+	}
+	fmt.Fprintln(w, output)
+}
+
+// changeNames label the values a Change can be of.
+var changeNames = map[string]string{
+	forecast.ChangeHigh:          "high",
+	forecast.ChangeLow:           "low",
+	forecast.ChangePrecipitation: "precipitation",
+	forecast.ChangePrecipChance:  "rain chance",
+	forecast.ChangeWind:          "wind",
+}
+
+// changesText lists how the values of a day moved, e.g. "high 24→27°C,
+// rain chance 10%→60%".
+func changesText(changes []forecast.Change, u forecast.Units, loc i18n.Locale) string {
+	decimals := 1
+	if u.Precipitation == units.Inches {
+		decimals = 2
+	}
+	texts := make([]string, len(changes))
+	for i, c := range changes {
+		var from, to, unit string
+		switch c.Of {
+		case forecast.ChangeHigh, forecast.ChangeLow:
+			// Truncated, as the table shows the high and low.
+			from, to, unit = strconv.Itoa(int(c.From)), strconv.Itoa(int(c.To)), units.Degrees(u.Temperature)
+		case forecast.ChangePrecipChance:
+			from, to, unit = loc.Number(c.From, 0)+"%", loc.Number(c.To, 0), "%"
+		case forecast.ChangePrecipitation:
+			from, to, unit = loc.Number(c.From, decimals), loc.Number(c.To, decimals), " "+u.Precipitation
+		case forecast.ChangeWind:
+			from, to, unit = loc.Number(c.From, 0), loc.Number(c.To, 0), " "+u.WindSpeed
+		}
+		texts[i] = changeNames[c.Of] + " " + from + "→" + to + unit
+	}
+	return strings.Join(texts, ", ")
+}
+
+// activityName capitalises the name of a -score activity for its column,
+// e.g. "Beach"; bbq is written "BBQ".
+func activityName(name string) string {
+	if name == "" {
+		return "Score"
+	}
+	if name == "bbq" {
+		return "BBQ"
+	}
+	return strings.ToUpper(name[:1]) + name[1:]
+}
+
+// section is a rule the width of the range bar followed by title, which
+// sets the days of -past-days apart from the forecast.
+func section(title string) string {
+	return strings.Repeat("-", barWidth) + " " + title
+}
+
+// hourFields records which optional values any hour of a forecast has.
+type hourFields struct {
+	precip, wind, snow, humidity, dewPoint, pressure, clouds bool
+}
+
+func hourFieldsOf(hours []forecast.Hour) hourFields {
+	var has hourFields
+	for _, hour := range hours {
+		has.precip = has.precip || hour.PrecipProbability != nil
+		has.wind = has.wind || hour.WindSpeed != nil && hour.WindDirection != nil
+		has.snow = has.snow || hour.SnowDepth != nil
+		has.humidity = has.humidity || hour.Humidity != nil
+		has.dewPoint = has.dewPoint || hour.DewPoint != nil
+		has.pressure = has.pressure || hour.Pressure != nil
+		has.clouds = has.clouds || hour.CloudCover != nil || hour.Visibility != nil
+	}
+	return has
+}
+
+func hourlyTable(w io.Writer, f forecast.Forecast, s styler, loc i18n.Locale) {
+	has := hourFieldsOf(f.Hours)
+
+	// Day names and 12-hour times vary in length, so the times are padded to
+	// the longest to keep the columns aligned.
+	times := make([]string, len(f.Hours))
+	width := 0
+	for i, hour := range f.Hours {
+		times[i] = loc.Day(hour.Time) + " " + loc.Time(hour.Time)
+		width = max(width, len([]rune(times[i])))
+	}
+
+	// Each day ends with a bar chart of its precipitation per hour, all on
+	// the scale of the wettest hour.
+	hi := maxPrecip(f.Hours)
+	decimals := 1
+	if f.Units.Precipitation == units.Inches {
+		decimals = 2
+	}
+	start := 0
+	for i, hour := range f.Hours {
+		temp := s.temp(fmt.Sprintf("%3d %s", int(hour.Temperature), units.Degrees(f.Units.Temperature)), hour.Temperature, f.Units.Temperature)
+		output := fmt.Sprintf("%-*s | %s", width, times[i], temp)
+
+		if hour.PrecipProbability != nil {
+			output += " | " + s.precip(fmt.Sprintf("Precip: %3.0f%%", *hour.PrecipProbability))
+		} else if has.precip {
+			output += " | Precip: " + notAvailable
+		}
+
+		if hour.WindSpeed != nil && hour.WindDirection != nil {
+			output += fmt.Sprintf(" | Wind: %5s %s from %-3s %3.0f° %s", loc.Number(*hour.WindSpeed, 1), f.Units.WindSpeed,
+				forecast.Compass(*hour.WindDirection), *hour.WindDirection, forecast.WindArrow(*hour.WindDirection))
+		} else if has.wind {
+			output += " | Wind: " + notAvailable
+		}
+
+		if hour.SnowDepth != nil {
+			output += fmt.Sprintf(" | Snow depth: %s %s", loc.Number(*hour.SnowDepth, 1), f.Units.Snow)
+		} else if has.snow {
+			output += " | Snow depth: " + notAvailable
+		}
+
+		if hour.Humidity != nil {
+			output += fmt.Sprintf(" | Humidity: %3.0f%%", *hour.Humidity)
+		} else if has.humidity {
+			output += " | Humidity: " + notAvailable
+		}
+
+		if hour.DewPoint != nil {
+			output += fmt.Sprintf(" | Dew point: %3d %s", int(math.Round(*hour.DewPoint)), units.Degrees(f.Units.Temperature))
+		} else if has.dewPoint {
+			output += " | Dew point: " + notAvailable
+		}
+
+		if hour.Pressure != nil {
+			output += fmt.Sprintf(" | Pressure: %4.0f hPa", *hour.Pressure)
+		} else if has.pressure {
+			output += " | Pressure: " + notAvailable
+		}
+
+		if clouds := cloudsText(hour.CloudCover, hour.Visibility, f.Units, loc); clouds != "" {
+			output += " | " + clouds
+		} else if has.clouds {
+			output += " | Clouds: " + notAvailable
+		}
+
+		fmt.Fprintln(w, output)
+
+		if i+1 < len(f.Hours) && sameDay(f.Hours[i+1].Time, hour.Time) {
+			continue
+		}
+		if bars, dayMax, ok := precipBars(f.Hours[start:i+1], hi); ok {
+			fmt.Fprintf(w, "%-*s | 00 %s 23 | Max: %s %s/h\n", width, "", s.precip(bars), loc.Number(dayMax, decimals), f.Units.Precipitation)
+		}
+		start = i + 1
+	}
+}
+
+// sameDay reports whether a and b fall on the same calendar day.
+func sameDay(a, b time.Time) bool {
+	ay, am, ad := a.Date()
+	by, bm, bd := b.Date()
+	return ay == by && am == bm && ad == bd
+}
+
+// windFrom formats a wind direction as its compass point, degrees and an
+// arrow pointing downwind, e.g. "WSW 240° ↗".
+func windFrom(degrees float64) string {
+	return fmt.Sprintf("%s %.0f° %s", forecast.Compass(degrees), degrees, forecast.WindArrow(degrees))
+}
+
+// hoursMinutes formats a duration in seconds as e.g. "14h22m".
+func hoursMinutes(seconds float64) string {
+	minutes := int(math.Round(seconds / 60))
+	return fmt.Sprintf("%dh%02dm", minutes/60, minutes%60)
+}
+
+// feelsText formats the apparent temperature, e.g. "Feels: 16/8 °C". Only
+// the values that were returned are shown.
+func feelsText(day forecast.Day, unit string) string {
+	switch {
+	case day.FeelsMax != nil && day.FeelsMin != nil:
+		return fmt.Sprintf("Feels: %d/%d %s", int(*day.FeelsMax), int(*day.FeelsMin), units.Degrees(unit))
+	case day.FeelsMax != nil:
+		return fmt.Sprintf("Feels: %d %s", int(*day.FeelsMax), units.Degrees(unit))
+	case day.FeelsMin != nil:
+		return fmt.Sprintf("Feels: %d %s", int(*day.FeelsMin), units.Degrees(unit))
+	}
+	return ""
+}
+
+// trendArrows show the pressure trend of a day.
+var trendArrows = map[string]string{
+	forecast.Rising:  "↑ rising",
+	forecast.Falling: "↓ falling",
+	forecast.Steady:  "→ steady",
+}
+
+// humidityText formats the daily mean and range of relative humidity and
+// dew point, e.g. "Humidity: 72% (55-90%), Dew point: 11 °C (9-13)".
+func humidityText(day forecast.Day, unit string) string {
+	var parts []string
+	if day.HumidityMean != nil && day.HumidityMin != nil && day.HumidityMax != nil {
+		parts = append(parts, fmt.Sprintf("Humidity: %.0f%% (%.0f-%.0f%%)", *day.HumidityMean, *day.HumidityMin, *day.HumidityMax))
+	}
+	if day.DewPointMean != nil && day.DewPointMin != nil && day.DewPointMax != nil {
+		parts = append(parts, fmt.Sprintf("Dew point: %.0f %s (%.0f-%.0f)", *day.DewPointMean, units.Degrees(unit), *day.DewPointMin, *day.DewPointMax))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// cloudsText formats cloud cover and visibility, e.g. "Clouds: 65%,
+// Visibility: 24 km". Either part may be missing.
+func cloudsText(cover, visibility *float64, u forecast.Units, loc i18n.Locale) string {
+	var parts []string
+	if cover != nil {
+		parts = append(parts, fmt.Sprintf("Clouds: %.0f%%", *cover))
+	}
+	if visibility != nil {
+		parts = append(parts, "Visibility: "+distance(*visibility, u, loc))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// daylightText formats daylight and sunshine duration, e.g.
+// "Daylight: 14h22m, Sun: 9h05m".
+func daylightText(day forecast.Day) string {
+	var parts []string
+	if day.Daylight != nil {
+		parts = append(parts, "Daylight: "+hoursMinutes(*day.Daylight))
+	}
+	if day.Sunshine != nil {
+		parts = append(parts, "Sun: "+hoursMinutes(*day.Sunshine))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// precipLikelihood formats the chance and duration of precipitation, e.g.
+// "70% / 3h". Either part may be missing.
+func precipLikelihood(day forecast.Day) string {
+	var parts []string
+	if day.PrecipChance != nil {
+		parts = append(parts, fmt.Sprintf("%.0f%%", *day.PrecipChance))
+	}
+	if day.PrecipHours != nil {
+		parts = append(parts, fmt.Sprintf("%.0fh", *day.PrecipHours))
+	}
+	return strings.Join(parts, " / ")
+}
+
+// condition formats a weather description, prefixed by its icon when icons
+// are enabled.
+func condition(code int, description, icons string) string {
+	if icon := wmo.Icon(code, icons); icon != "" {
+		return icon + " " + description
+	}
+	return description
+}
+
+func current(w io.Writer, f forecast.Forecast, s styler, icons string, loc i18n.Locale) {
+	c := f.Current
+	fmt.Fprintf(w, "%s at %s\n", placeName(f.Location), loc.Time(c.Time))
+	fmt.Fprintf(w, "  %s\n", condition(c.WeatherCode, c.Description, icons))
+	fmt.Fprintf(w, "  Temperature: %s\n", s.temp(fmt.Sprintf("%s %s", loc.Number(c.Temperature, 1), units.Degrees(f.Units.Temperature)), c.Temperature, f.Units.Temperature))
+	fmt.Fprintf(w, "  Wind: %s %s from %s\n", loc.Number(c.WindSpeed, 1), f.Units.WindSpeed, windFrom(c.WindDirection))
+	if e, ok := elevation(f, loc); ok {
+		fmt.Fprintf(w, "  Elevation: %s\n", e)
+	}
+}
+
+// elevation formats the height the forecast is for, in feet when
+// precipitation is in inches.
+func elevation(f forecast.Forecast, loc i18n.Locale) (string, bool) {
+	if f.Location.Elevation == nil {
+		return "", false
+	}
+	v, unit := *f.Location.Elevation, units.Metres
+	if f.Units.Precipitation == units.Inches {
+		v, unit = units.Length(v, units.Metres, units.Feet), units.Feet
+	}
+	return loc.Number(v, 0) + " " + unit, true
+}
+
+// distance formats a distance in metres as kilometres, or as miles when
+// precipitation is in inches, with a decimal below 10.
+func distance(metres float64, u forecast.Units, loc i18n.Locale) string {
+	unit := units.Kilometres
+	if u.Precipitation == units.Inches {
+		unit = units.Miles
+	}
+	v := units.Length(metres, units.Metres, unit)
+	decimals := 0
+	if v < 10 {
+		decimals = 1
+	}
+	return loc.Number(v, decimals) + " " + unit
+}
+
+func comparisonTable(w io.Writer, forecasts []forecast.Forecast, s styler, loc i18n.Locale) {
+	if len(forecasts) == 0 {
+		return
+	}
+
+	widths := make([]int, len(forecasts))
+	header := fmt.Sprintf("%-10s", "Date")
+	for i, f := range forecasts {
+		widths[i] = max(len(f.Label()), 14)
+		header += fmt.Sprintf(" | %-*s", widths[i], f.Label())
+	}
+	header = strings.TrimRight(header, " ")
+	fmt.Fprintln(w, header)
+	fmt.Fprintln(w, strings.Repeat("-", len([]rune(header))))
+
+	byDate := make([]map[string]forecast.Day, len(forecasts))
+	for i, f := range forecasts {
+		byDate[i] = map[string]forecast.Day{}
+		for _, day := range f.Days {
+			byDate[i][day.Date.String()] = day
+		}
+	}
+
+	for _, day := range forecasts[0].Days {
+		date := day.Date.String()
+		row := fmt.Sprintf("%-10s", loc.Date(day.Date.Time))
+		for i, f := range forecasts {
+			cell := fmt.Sprintf("%-*s", widths[i], notAvailable)
+			if d, ok := byDate[i][date]; ok {
+				cell = fmt.Sprintf("%-*s", widths[i], fmt.Sprintf("%3.0f / %3.0f %s", d.TempMax, d.TempMin, units.Degrees(f.Units.Temperature)))
+				cell = s.temp(cell, d.TempMax, f.Units.Temperature)
+			}
+			row += " | " + cell
+		}
+		fmt.Fprintln(w, strings.TrimRight(row, " "))
+	}
+}
@@ -0,0 +1,102 @@
+package render
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"weather-app/internal/forecast"
+	"weather-app/internal/units"
+)
+
+func TestHourlyTableNotAvailable(t *testing.T) {
+	ptr := func(v float64) *float64 { return &v }
+	start := time.Date(2024, 6, 3, 12, 0, 0, 0, time.UTC)
+	f := forecast.Forecast{
+		Location: forecast.Location{Name: "The Hague", Country: "Netherlands"},
+		Units:    units.Metric.Units(),
+		Hours: []forecast.Hour{
+			{Time: start, Temperature: 18, PrecipProbability: ptr(40), Visibility: ptr(24000)},
+			{Time: start.Add(time.Hour), Temperature: 17},
+		},
+	}
+	var buf bytes.Buffer
+	if err := Render(&buf, "table", f, Options{}); err != nil {
+		t.Fatal(err)
+	}
+	var rows []string
+	for _, line := range strings.Split(buf.String(), "\n") {
+		if strings.Contains(line, "°C") {
+			rows = append(rows, line)
+		}
+	}
+	if len(rows) != 2 {
+		t.Fatalf("got rows %q, want 2", rows)
+	}
+	for _, want := range []string{"Precip: n/a", "Clouds: n/a"} {
+		if strings.Contains(rows[0], want) || !strings.Contains(rows[1], want) {
+			t.Errorf("rows %q: want %q on the second only", rows, want)
+		}
+	}
+	for _, absent := range []string{"Snow depth", "Humidity", "Pressure"} {
+		if strings.Contains(buf.String(), absent) {
+			t.Errorf("%s shown though no hour has it:\n%s", absent, buf.String())
+		}
+	}
+}
+
+func TestDailyTable(t *testing.T) {
+	ptr := func(v float64) *float64 { return &v }
+	metric := forecast.Forecast{
+		Location: forecast.Location{Name: "The Hague", Country: "Netherlands"},
+		Units:    units.Metric.Units(),
+		Days: []forecast.Day{
+			{Date: forecast.Date{Time: time.Date(2024, 6, 3, 0, 0, 0, 0, time.UTC)}, TempMax: 18.2, TempMin: -3, Precipitation: ptr(12.7), WindSpeedMax: ptr(36)},
+			{Date: forecast.Date{Time: time.Date(2024, 6, 4, 0, 0, 0, 0, time.UTC)}, TempMax: 20, TempMin: 10},
+		},
+	}
+	tests := []struct {
+		name   string
+		f      forecast.Forecast
+		want   [2][]string
+		absent []string
+	}{
+		{
+			"metric",
+			metric,
+			[2][]string{{"18/-3 °C", "Precip: 12.70 mm", "Wind: 36.0 km/h"}, {"20/10 °C", "Precip: n/a", "Wind: n/a"}},
+			[]string{"Snow", "Sunrise", "UV Index", "Feels"},
+		},
+		{
+			"imperial",
+			units.Convert(metric, units.Imperial.Units()),
+			[2][]string{{"64/26 °F", "Precip: 0.50 in", "Wind: 22.4 mph"}, {"68/50 °F", "Precip: n/a", "Wind: n/a"}},
+			[]string{"°C", "mm", "km/h"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := Render(&buf, "table", tt.f, Options{}); err != nil {
+				t.Fatal(err)
+			}
+			rows := strings.Split(strings.TrimSpace(buf.String()), "\n")
+			if len(rows) != 2 {
+				t.Fatalf("got rows %q, want 2", rows)
+			}
+			for i, want := range tt.want {
+				for _, w := range want {
+					if !strings.Contains(rows[i], w) {
+						t.Errorf("row %d = %q, want %q in it", i, rows[i], w)
+					}
+				}
+			}
+			for _, absent := range tt.absent {
+				if strings.Contains(buf.String(), absent) {
+					t.Errorf("%s shown:\n%s", absent, buf.String())
+				}
+			}
+		})
+	}
+}
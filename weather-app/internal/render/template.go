@@ -0,0 +1,81 @@
+package render
+
+import (
+	"bytes"
+	"strings"
+	"text/template"
+
+	"weather-app/internal/forecast"
+)
+
+// Template is a Renderer that executes a Go template once for each day of
+// a forecast, or each hour of an hourly one, and once for the whole
+// forecast when it has neither, such as current conditions.
+type Template struct {
+	t *template.Template
+}
+
+// TemplateDay is what a Template sees for each day: the fields of
+// forecast.Day, plus .Unit, the temperature unit such as "C", and the
+// forecast's .Units and .Location.
+type TemplateDay struct {
+	forecast.Day
+	Unit     string
+	Units    forecast.Units
+	Location forecast.Location
+}
+
+// TemplateHour is TemplateDay for an hour.
+type TemplateHour struct {
+	forecast.Hour
+	Unit     string
+	Units    forecast.Units
+	Location forecast.Location
+}
+
+var templateFuncs = template.FuncMap{
+	"lower": strings.ToLower,
+	"upper": strings.ToUpper,
+}
+
+// ParseTemplate parses text, e.g. "{{.Date}} {{.TempMax}}°{{.Unit}}".
+func ParseTemplate(name, text string) (*Template, error) {
+	t, err := template.New(name).Funcs(templateFuncs).Parse(text)
+	if err != nil {
+		return nil, err
+	}
+	return &Template{t: t}, nil
+}
+
+func (t *Template) Render(f forecast.Forecast) ([]byte, error) {
+	var b bytes.Buffer
+	execute := func(data any) error {
+		if err := t.t.Execute(&b, data); err != nil {
+			return err
+		}
+		// A line per day, without a trailing "\n" in the template.
+		if b.Len() > 0 && b.Bytes()[b.Len()-1] != '\n' {
+			b.WriteByte('\n')
+		}
+		return nil
+	}
+	switch {
+	case len(f.Days) > 0:
+		for _, day := range f.Days {
+			if err := execute(TemplateDay{Day: day, Unit: f.Units.Temperature, Units: f.Units, Location: f.Location}); err != nil {
+				return nil, err
+			}
+		}
+	case len(f.Hours) > 0:
+		for _, hour := range f.Hours {
+			if err := execute(TemplateHour{Hour: hour, Unit: f.Units.Temperature, Units: f.Units, Location: f.Location}); err != nil {
+				return nil, err
+			}
+		}
+	default:
+		if err := execute(f); err != nil {
+			return nil, err
+		}
+	}
+	return b.Bytes(), nil
+}
@@ -0,0 +1,33 @@
+package render
+
+import "math"
+
+// UVCategory is a WHO exposure category of the UV index.
+type UVCategory struct {
+	Name   string
+	Advice string
+	color  int
+}
+
+// uvCategories are the WHO categories by the lowest rounded index in each.
+var uvCategories = []struct {
+	from int
+	UVCategory
+}{
+	{11, UVCategory{"Extreme", "SPF 50+, stay indoors midday", 129}},
+	{8, UVCategory{"Very High", "SPF 50+, avoid the sun 11:00-16:00", red}},
+	{6, UVCategory{"High", "SPF 30+, hat and sunglasses, seek shade midday", 208}},
+	{3, UVCategory{"Moderate", "SPF 30+, seek shade midday", yellow}},
+	{0, UVCategory{"Low", "No protection needed", 46}},
+}
+
+// UVCategoryOf returns the category of a UV index.
+func UVCategoryOf(uv float64) UVCategory {
+	n := int(math.Round(uv))
+	for _, c := range uvCategories {
+		if n >= c.from {
+			return c.UVCategory
+		}
+	}
+	return uvCategories[len(uvCategories)-1].UVCategory
+}
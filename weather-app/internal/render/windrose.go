@@ -0,0 +1,96 @@
+package render
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strings"
+
+	"weather-app/internal/forecast"
+	"weather-app/internal/i18n"
+)
+
+// WindRose writes how often the wind comes from each direction.
+func WindRose(w io.Writer, format string, rose forecast.WindRose, opts Options) error {
+	switch format {
+	case "json":
+		return JSON(w, rose)
+	case "yaml":
+		return YAML(w, rose)
+	case "table":
+		windRoseTable(w, rose, opts.Locale)
+		return nil
+	default:
+		return fmt.Errorf("a wind rose cannot be written as %s, use table, json or yaml", format)
+	}
+}
+
+// roseRadius is the length in rows of the spoke of the most common
+// direction.
+const roseRadius = 6
+
+// roseSpokes are the step and line drawn for each sector of a wind rose,
+// clockwise from north. A column is half as wide as a row is high, so
+// spokes step two columns per row.
+var roseSpokes = []struct {
+	dx, dy int
+	line   rune
+}{
+	{0, -1, '│'}, {1, -1, '╱'}, {1, 0, '─'}, {1, 1, '╲'},
+	{0, 1, '│'}, {-1, 1, '╱'}, {-1, 0, '─'}, {-1, -1, '╲'},
+}
+
+func windRoseTable(w io.Writer, rose forecast.WindRose, loc i18n.Locale) {
+	fmt.Fprintf(w, "Wind at %s over %d hours\n\n", placeName(rose.Location), rose.Hours)
+
+	var most float64
+	for _, s := range rose.Sectors {
+		most = max(most, s.Percent)
+	}
+	// Labels sit one step beyond the longest spoke and are at most
+	// "NW 100%" wide.
+	const label = 7
+	cx, cy := label+1+2*roseRadius, roseRadius+1
+	grid := make([][]rune, 2*roseRadius+3)
+	for y := range grid {
+		grid[y] = []rune(strings.Repeat(" ", 2*cx+1))
+	}
+	for i, s := range rose.Sectors {
+		spoke := roseSpokes[i]
+		length := 0
+		if most > 0 {
+			length = int(math.Round(s.Percent / most * roseRadius))
+		}
+		for k := 1; k <= length; k++ {
+			x, y := cx+2*k*spoke.dx, cy+k*spoke.dy
+			grid[y][x] = spoke.line
+			if spoke.dy == 0 {
+				grid[y][x-spoke.dx] = spoke.line
+			}
+		}
+
+		text := []rune(s.Direction + " " + loc.Number(s.Percent, 0) + "%")
+		x, y := cx+2*(roseRadius+1)*spoke.dx, cy+(roseRadius+1)*spoke.dy
+		switch {
+		case spoke.dx == 0:
+			x -= len(text) / 2
+		case spoke.dx < 0:
+			x -= len(text) - 1
+		}
+		copy(grid[y][x:], text)
+	}
+	grid[cy][cx] = '+'
+	for _, row := range grid {
+		fmt.Fprintln(w, strings.TrimRight(string(row), " "))
+	}
+
+	fmt.Fprintln(w)
+	for _, s := range rose.Sectors {
+		output := fmt.Sprintf("%-4s %5s%%", s.Direction, loc.Number(s.Percent, 1))
+		if s.Percent > 0 {
+			output += fmt.Sprintf(" | mean %s %s", loc.Number(s.MeanSpeed, 1), rose.Units.WindSpeed)
+		}
+		fmt.Fprintln(w, output)
+	}
+	fmt.Fprintf(w, "%-4s %5s%%\n", "Calm", loc.Number(rose.Calm, 1))
+}
@@ -0,0 +1,176 @@
+// Package reverse names the place nearest to a pair of coordinates.
+package reverse
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"weather-app/internal/forecast"
+	"weather-app/internal/gazetteer"
+)
+
+// DefaultTimeout bounds a single lookup.
+const DefaultTimeout = 5 * time.Second
+
+// Geocoder finds the place at a latitude and longitude.
+type Geocoder interface {
+	Reverse(ctx context.Context, lat, lon float64) (forecast.Location, error)
+}
+
+var providers = map[string]func(*http.Client) Geocoder{
+	"bigdatacloud": func(c *http.Client) Geocoder { return &BigDataCloud{HTTPClient: c} },
+	"nominatim":    func(c *http.Client) Geocoder { return &Nominatim{HTTPClient: c} },
+	"offline":      func(*http.Client) Geocoder { return Offline{} },
+}
+
+// DefaultProvider is the provider used when none is chosen.
+const DefaultProvider = "bigdatacloud"
+
+// Providers returns the names accepted by New.
+func Providers() []string {
+	names := make([]string, 0, len(providers))
+	for name := range providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// New returns the named provider. A nil client uses one with DefaultTimeout.
+func New(name string, client *http.Client) (Geocoder, error) {
+	newGeocoder, ok := providers[name]
+	if !ok {
+		return nil, fmt.Errorf("Unknown reverse geocoding provider %q, expected one of %s", name, strings.Join(Providers(), ", "))
+	}
+	if client == nil {
+		client = &http.Client{Timeout: DefaultTimeout}
+	}
+	return newGeocoder(client), nil
+}
+
+func getJSON(ctx context.Context, client *http.Client, url string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("reverse geocoding: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("reverse geocoding: %s", resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+// BigDataCloud uses the free client-side endpoint of bigdatacloud.com,
+// which needs no key.
+type BigDataCloud struct {
+	HTTPClient *http.Client
+	// URL overrides the endpoint, mainly for tests.
+	URL string
+}
+
+func (p *BigDataCloud) Reverse(ctx context.Context, lat, lon float64) (forecast.Location, error) {
+	endpoint := p.URL
+	if endpoint == "" {
+		endpoint = "https://api.bigdatacloud.net/data/reverse-geocode-client"
+	}
+	q := url.Values{}
+	q.Set("latitude", strconv.FormatFloat(lat, 'f', 4, 64))
+	q.Set("longitude", strconv.FormatFloat(lon, 'f', 4, 64))
+	q.Set("localityLanguage", "en")
+	var body struct {
+		City        string `json:"city"`
+		Locality    string `json:"locality"`
+		CountryName string `json:"countryName"`
+	}
+	if err := getJSON(ctx, p.HTTPClient, endpoint+"?"+q.Encode(), &body); err != nil {
+		return forecast.Location{}, err
+	}
+	name := body.City
+	if name == "" {
+		name = body.Locality
+	}
+	if name == "" {
+		return forecast.Location{}, fmt.Errorf("bigdatacloud: no place at %.4f, %.4f", lat, lon)
+	}
+	return forecast.Location{Name: name, Country: body.CountryName, Latitude: lat, Longitude: lon}, nil
+}
+
+// Nominatim uses OpenStreetMap's Nominatim, whose usage policy allows at
+// most one request per second.
+type Nominatim struct {
+	HTTPClient *http.Client
+	// URL overrides the endpoint, mainly for tests.
+	URL string
+}
+
+func (p *Nominatim) Reverse(ctx context.Context, lat, lon float64) (forecast.Location, error) {
+	endpoint := p.URL
+	if endpoint == "" {
+		endpoint = "https://nominatim.openstreetmap.org/reverse"
+	}
+	q := url.Values{}
+	q.Set("lat", strconv.FormatFloat(lat, 'f', 4, 64))
+	q.Set("lon", strconv.FormatFloat(lon, 'f', 4, 64))
+	q.Set("format", "jsonv2")
+	q.Set("zoom", "10") // cities rather than streets
+	q.Set("accept-language", "en")
+	var body struct {
+		Error   string `json:"error"`
+		Address struct {
+			City    string `json:"city"`
+			Town    string `json:"town"`
+			Village string `json:"village"`
+			County  string `json:"county"`
+			Country string `json:"country"`
+		} `json:"address"`
+	}
+	if err := getJSON(ctx, p.HTTPClient, endpoint+"?"+q.Encode(), &body); err != nil {
+		return forecast.Location{}, err
+	}
+	if body.Error != "" {
+		return forecast.Location{}, fmt.Errorf("nominatim: %s", body.Error)
+	}
+	a := body.Address
+	name := a.City
+	for _, alt := range []string{a.Town, a.Village, a.County} {
+		if name == "" {
+			name = alt
+		}
+	}
+	if name == "" {
+		return forecast.Location{}, fmt.Errorf("nominatim: no place at %.4f, %.4f", lat, lon)
+	}
+	return forecast.Location{Name: name, Country: a.Country, Latitude: lat, Longitude: lon}, nil
+}
+
+// Offline names the nearest city of the embedded gazetteer, as "near" it
+// when that is further than a town's width away. It needs no network but
+// knows only major cities.
+type Offline struct{}
+
+// offlineRadius is how far from a city, in km, the offline provider still
+// names it: beyond it the place is more likely in the countryside.
+const offlineRadius = 150
+
+func (Offline) Reverse(_ context.Context, lat, lon float64) (forecast.Location, error) {
+	city, km, ok := gazetteer.Nearest(lat, lon)
+	if !ok || km > offlineRadius {
+		return forecast.Location{}, fmt.Errorf("offline: no major city within %d km of %.4f, %.4f", offlineRadius, lat, lon)
+	}
+	name := city.Name
+	if km > 10 {
+		name = fmt.Sprintf("near %s", city.Name)
+	}
+	return forecast.Location{Name: name, Country: city.Country, Latitude: lat, Longitude: lon}, nil
+}
@@ -0,0 +1,307 @@
+// Package selfupdate replaces the running executable with the latest release
+// published on GitHub. Every release carries a checksums.txt in the format
+// of sha256sum and its Ed25519 signature, checksums.txt.sig, made by the
+// release workflow. A binary is only installed if the signature verifies
+// against the public key built into this one and the binary matches its
+// checksum.
+package selfupdate
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultURL is the GitHub API endpoint of the latest release.
+const DefaultURL = "https://api.github.com/repos/gravi1984/saas-hackthon/releases/latest"
+
+// DefaultTimeout bounds the release lookup and both downloads.
+const DefaultTimeout = 5 * time.Minute
+
+// checksums is the name of the release asset listing SHA-256 checksums,
+// and signature that of its base64 Ed25519 signature.
+const (
+	checksums = "checksums.txt"
+	signature = checksums + ".sig"
+)
+
+// publicKey is the base64 Ed25519 key releases are signed with. The release
+// workflow sets it, e.g.
+//
+//	go build -ldflags "-X weather-app/internal/selfupdate.publicKey=$RELEASE_PUBLIC_KEY"
+//
+// Builds without it cannot verify a release and so do not update.
+var publicKey = ""
+
+// DefaultPublicKey returns the release signing key built into this binary.
+func DefaultPublicKey() (ed25519.PublicKey, error) {
+	if publicKey == "" {
+		return nil, errors.New("This build has no release signing key to verify updates with")
+	}
+	key, err := base64.StdEncoding.DecodeString(publicKey)
+	if err != nil || len(key) != ed25519.PublicKeySize {
+		return nil, errors.New("The release signing key built into this binary is invalid")
+	}
+	return key, nil
+}
+
+// maxBinary caps a download, in case an asset is not what it claims to be.
+const maxBinary = 200 << 20
+
+// Release is a published release and its downloadable files.
+type Release struct {
+	Tag    string  `json:"tag_name"`
+	Assets []Asset `json:"assets"`
+}
+
+// Asset is a file attached to a release.
+type Asset struct {
+	Name string `json:"name"`
+	URL  string `json:"browser_download_url"`
+}
+
+// Updater fetches releases. Empty URL uses DefaultURL and a nil Client
+// one with DefaultTimeout. Downloads are verified with PublicKey, or the
+// DefaultPublicKey if it is nil.
+type Updater struct {
+	URL       string
+	Client    *http.Client
+	PublicKey ed25519.PublicKey
+}
+
+// AssetName is the name of the release binary built for goos and goarch,
+// e.g. "weather-app_linux_amd64".
+func AssetName(goos, goarch string) string {
+	name := "weather-app_" + goos + "_" + goarch
+	if goos == "windows" {
+		name += ".exe"
+	}
+	return name
+}
+
+// Latest returns the latest release.
+func (u *Updater) Latest(ctx context.Context) (Release, error) {
+	url := u.URL
+	if url == "" {
+		url = DefaultURL
+	}
+	data, err := u.get(ctx, url, 1<<20)
+	if err != nil {
+		return Release{}, err
+	}
+	var r Release
+	if err := json.Unmarshal(data, &r); err != nil {
+		return Release{}, fmt.Errorf("decoding release: %w", err)
+	}
+	if r.Tag == "" {
+		return Release{}, errors.New("The latest release has no tag")
+	}
+	return r, nil
+}
+
+// Download fetches the binary for goos and goarch from r and checks it
+// against the release's checksums, once their signature is verified.
+func (u *Updater) Download(ctx context.Context, r Release, goos, goarch string) ([]byte, error) {
+	key := u.PublicKey
+	if key == nil {
+		var err error
+		if key, err = DefaultPublicKey(); err != nil {
+			return nil, err
+		}
+	}
+	name := AssetName(goos, goarch)
+	binary, ok := r.asset(name)
+	if !ok {
+		return nil, fmt.Errorf("Release %s has no build for %s/%s", r.Tag, goos, goarch)
+	}
+	sums, ok := r.asset(checksums)
+	if !ok {
+		return nil, fmt.Errorf("Release %s has no %s to verify the download with", r.Tag, checksums)
+	}
+	sig, ok := r.asset(signature)
+	if !ok {
+		return nil, fmt.Errorf("Release %s has no %s to verify %s with", r.Tag, signature, checksums)
+	}
+	list, err := u.get(ctx, sums.URL, 1<<20)
+	if err != nil {
+		return nil, err
+	}
+	signed, err := u.get(ctx, sig.URL, 1<<10)
+	if err != nil {
+		return nil, err
+	}
+	if err := verify(key, list, signed); err != nil {
+		return nil, fmt.Errorf("Release %s: %w", r.Tag, err)
+	}
+	want, err := checksum(list, name)
+	if err != nil {
+		return nil, err
+	}
+	data, err := u.get(ctx, binary.URL, maxBinary)
+	if err != nil {
+		return nil, err
+	}
+	got := sha256.Sum256(data)
+	if hex.EncodeToString(got[:]) != want {
+		return nil, fmt.Errorf("Checksum mismatch for %s, the download is corrupt or was tampered with", name)
+	}
+	return data, nil
+}
+
+func (r Release) asset(name string) (Asset, bool) {
+	for _, a := range r.Assets {
+		if a.Name == name {
+			return a, true
+		}
+	}
+	return Asset{}, false
+}
+
+// verify checks that sig, a base64 Ed25519 signature, signs data with key.
+func verify(key ed25519.PublicKey, data, sig []byte) error {
+	if len(key) != ed25519.PublicKeySize {
+		return errors.New("the key to verify the release signature with is invalid")
+	}
+	raw, err := base64.StdEncoding.DecodeString(string(bytes.TrimSpace(sig)))
+	if err != nil || !ed25519.Verify(key, data, raw) {
+		return fmt.Errorf("%s does not carry a valid signature of the release key", checksums)
+	}
+	return nil
+}
+
+// checksum finds the hex SHA-256 of name in a list of "<sum>  <name>" lines.
+func checksum(list []byte, name string) (string, error) {
+	scanner := bufio.NewScanner(bytes.NewReader(list))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		// sha256sum marks files read in binary mode with a leading '*'.
+		if len(fields) == 2 && strings.TrimPrefix(fields[1], "*") == name {
+			return strings.ToLower(fields[0]), nil
+		}
+	}
+	return "", fmt.Errorf("%s has no checksum for %s", checksums, name)
+}
+
+func (u *Updater) get(ctx context.Context, url string, limit int64) ([]byte, error) {
+	client := u.Client
+	if client == nil {
+		client = &http.Client{Timeout: DefaultTimeout}
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GET %s: %s", url, resp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > limit {
+		return nil, fmt.Errorf("GET %s: response larger than %d bytes", url, limit)
+	}
+	return data, nil
+}
+
+// Released reports whether version is that of a release, vMAJOR.MINOR.PATCH,
+// rather than e.g. "dev" for a build from source.
+func Released(version string) bool {
+	_, ok := parseVersion(version)
+	return ok
+}
+
+// Newer reports whether the release tagged latest is newer than current.
+// Versions compare as vMAJOR.MINOR.PATCH; a current version that is not one,
+// such as "dev" for a build from source, is always older.
+func Newer(latest, current string) bool {
+	l, ok := parseVersion(latest)
+	if !ok {
+		return false
+	}
+	c, ok := parseVersion(current)
+	if !ok {
+		return true
+	}
+	for i := range l {
+		if l[i] != c[i] {
+			return l[i] > c[i]
+		}
+	}
+	return false
+}
+
+func parseVersion(s string) ([3]int, bool) {
+	var v [3]int
+	s = strings.TrimPrefix(s, "v")
+	// Pre-release and build suffixes are ignored.
+	if i := strings.IndexAny(s, "-+"); i >= 0 {
+		s = s[:i]
+	}
+	parts := strings.Split(s, ".")
+	if len(parts) != 3 {
+		return v, false
+	}
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 {
+			return v, false
+		}
+		v[i] = n
+	}
+	return v, true
+}
+
+// Replace swaps the executable at exe for data. The new binary is written
+// next to it and renamed over it, so a failure leaves the old one intact.
+// Windows cannot overwrite a running executable but can rename it, so there
+// the old one is moved aside to exe+".old" first.
+func Replace(exe string, data []byte, goos string) error {
+	info, err := os.Stat(exe)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(exe), "."+filepath.Base(exe)+".new-*")
+	if err != nil {
+		return fmt.Errorf("Cannot write next to %s: %w", exe, err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), info.Mode().Perm()); err != nil {
+		return err
+	}
+	if goos == "windows" {
+		old := exe + ".old"
+		os.Remove(old)
+		if err := os.Rename(exe, old); err != nil {
+			return err
+		}
+	}
+	return os.Rename(tmp.Name(), exe)
+}
@@ -0,0 +1,158 @@
+package selfupdate
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestChecksum(t *testing.T) {
+	list := []byte("ABC123  weather-app_linux_amd64\n" +
+		"def456 *weather-app_windows_amd64.exe\n" +
+		"malformed line with too many fields\n" +
+		"0000  weather-app_linux_amd64.sig\n")
+	tests := []struct {
+		name    string
+		want    string
+		wantErr bool
+	}{
+		{"weather-app_linux_amd64", "abc123", false},
+		{"weather-app_windows_amd64.exe", "def456", false},
+		{"weather-app_darwin_arm64", "", true},
+		{"weather-app_linux", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := checksum(list, tt.name)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("checksum() error = %v, wantErr %t", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("checksum() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseVersion(t *testing.T) {
+	tests := []struct {
+		in   string
+		want [3]int
+		ok   bool
+	}{
+		{"v1.2.3", [3]int{1, 2, 3}, true},
+		{"1.2.3", [3]int{1, 2, 3}, true},
+		{"v1.10.0-rc.1", [3]int{1, 10, 0}, true},
+		{"v2.0.0+build.5", [3]int{2, 0, 0}, true},
+		{"v1.2", [3]int{}, false},
+		{"v1.2.3.4", [3]int{}, false},
+		{"v1.x.3", [3]int{}, false},
+		{"v1.-2.3", [3]int{}, false},
+		{"dev", [3]int{}, false},
+		{"", [3]int{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, ok := parseVersion(tt.in)
+			if ok != tt.ok || (ok && got != tt.want) {
+				t.Errorf("parseVersion(%q) = %v, %t, want %v, %t", tt.in, got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
+
+func TestNewer(t *testing.T) {
+	tests := []struct {
+		latest, current string
+		want            bool
+	}{
+		{"v1.2.4", "v1.2.3", true},
+		{"v1.3.0", "v1.2.9", true},
+		{"v2.0.0", "v1.9.9", true},
+		{"v1.10.0", "v1.9.0", true},
+		{"v1.2.3", "v1.2.3", false},
+		{"v1.2.3", "v1.2.4", false},
+		{"v1.2.3", "v2.0.0", false},
+		{"v1.2.3", "dev", true},
+		{"nightly", "v1.2.3", false},
+		{"nightly", "dev", false},
+	}
+	for _, tt := range tests {
+		if got := Newer(tt.latest, tt.current); got != tt.want {
+			t.Errorf("Newer(%q, %q) = %t, want %t", tt.latest, tt.current, got, tt.want)
+		}
+	}
+}
+
+func TestReleased(t *testing.T) {
+	for in, want := range map[string]bool{"v1.2.3": true, "1.2.3": true, "dev": false, "": false, "v1.2": false} {
+		if got := Released(in); got != want {
+			t.Errorf("Released(%q) = %t, want %t", in, got, want)
+		}
+	}
+}
+
+func TestDownload(t *testing.T) {
+	public, private, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, _, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	binary := []byte("new weather-app")
+	sum := sha256.Sum256(binary)
+	list := []byte(hex.EncodeToString(sum[:]) + "  weather-app_linux_amd64\n")
+	signed := base64.StdEncoding.EncodeToString(ed25519.Sign(private, list))
+	corrupt := sha256.Sum256([]byte("old weather-app"))
+
+	tests := []struct {
+		name    string
+		key     ed25519.PublicKey
+		files   map[string]string
+		wantErr bool
+	}{
+		{"signed", public, map[string]string{"checksums.txt": string(list), "checksums.txt.sig": signed}, false},
+		{"other key", other, map[string]string{"checksums.txt": string(list), "checksums.txt.sig": signed}, true},
+		{"no signature", public, map[string]string{"checksums.txt": string(list)}, true},
+		{"bad signature", public, map[string]string{"checksums.txt": string(list), "checksums.txt.sig": "not base64"}, true},
+		{"tampered checksums", public, map[string]string{"checksums.txt": hex.EncodeToString(corrupt[:]) + "  weather-app_linux_amd64\n", "checksums.txt.sig": signed}, true},
+		{"no key", nil, map[string]string{"checksums.txt": string(list), "checksums.txt.sig": signed}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			files := map[string]string{"weather-app_linux_amd64": string(binary)}
+			for name, body := range tt.files {
+				files[name] = body
+			}
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, ok := files[r.URL.Path[1:]]
+				if !ok {
+					http.NotFound(w, r)
+					return
+				}
+				w.Write([]byte(body))
+			}))
+			defer srv.Close()
+			release := Release{Tag: "v1.2.3"}
+			for name := range files {
+				release.Assets = append(release.Assets, Asset{Name: name, URL: srv.URL + "/" + name})
+			}
+
+			u := &Updater{Client: srv.Client(), PublicKey: tt.key}
+			got, err := u.Download(context.Background(), release, "linux", "amd64")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Download() error = %v, wantErr %t", err, tt.wantErr)
+			}
+			if !tt.wantErr && string(got) != string(binary) {
+				t.Errorf("Download() = %q, want %q", got, binary)
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+// Package telegram is a small Telegram Bot API client: it long-polls for
+// the messages sent to a bot and replies to them.
+package telegram
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// DefaultURL is the Bot API endpoint.
+const DefaultURL = "https://api.telegram.org"
+
+// PollTimeout is how long a getUpdates request waits for new messages, and
+// ClientTimeout the timeout a client needs to outlast one.
+const (
+	PollTimeout   = 50 * time.Second
+	ClientTimeout = PollTimeout + 10*time.Second
+)
+
+// Bot talks to the Bot API with the bot's token. URL defaults to DefaultURL
+// and Client to one with ClientTimeout.
+type Bot struct {
+	Token  string
+	URL    string
+	Client *http.Client
+}
+
+type Update struct {
+	UpdateID int      `json:"update_id"`
+	Message  *Message `json:"message"`
+}
+
+type Message struct {
+	MessageID int    `json:"message_id"`
+	Chat      Chat   `json:"chat"`
+	Text      string `json:"text"`
+}
+
+type Chat struct {
+	ID int64 `json:"id"`
+}
+
+// Updates returns the updates with an ID of at least offset, waiting up to
+// PollTimeout for one to arrive. Asking from the last ID plus one confirms
+// the earlier ones, so they are not sent again.
+func (b *Bot) Updates(ctx context.Context, offset int) ([]Update, error) {
+	var updates []Update
+	params := map[string]any{
+		"offset":          offset,
+		"timeout":         int(PollTimeout / time.Second),
+		"allowed_updates": []string{"message"},
+	}
+	if err := b.call(ctx, "getUpdates", params, &updates); err != nil {
+		return nil, err
+	}
+	return updates, nil
+}
+
+// SendHTML sends text, formatted with Telegram's subset of HTML, to chat.
+func (b *Bot) SendHTML(ctx context.Context, chat int64, text string) error {
+	params := map[string]any{
+		"chat_id":    chat,
+		"text":       text,
+		"parse_mode": "HTML",
+	}
+	return b.call(ctx, "sendMessage", params, nil)
+}
+
+// call posts params to method and decodes the result into result.
+func (b *Bot) call(ctx context.Context, method string, params, result any) error {
+	base, client := b.URL, b.Client
+	if base == "" {
+		base = DefaultURL
+	}
+	if client == nil {
+		client = &http.Client{Timeout: ClientTimeout}
+	}
+	body, err := json.Marshal(params)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, base+"/bot"+b.Token+"/"+method, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		// The URL holds the token, which must not end up in logs.
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return fmt.Errorf("Telegram %s: %w", method, err)
+	}
+	defer resp.Body.Close()
+
+	var reply struct {
+		OK          bool            `json:"ok"`
+		Description string          `json:"description"`
+		Result      json.RawMessage `json:"result"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&reply); err != nil {
+		return fmt.Errorf("Telegram %s returned %s", method, resp.Status)
+	}
+	if !reply.OK {
+		return fmt.Errorf("Telegram %s failed: %s", method, reply.Description)
+	}
+	if result == nil {
+		return nil
+	}
+	return json.Unmarshal(reply.Result, result)
+}
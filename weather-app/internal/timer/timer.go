@@ -0,0 +1,180 @@
+// Package timer schedules a command to run once a day with the platform's
+// own scheduler: a systemd user timer on Linux, a launchd agent on macOS
+// and a scheduled task on Windows.
+package timer
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// Name names the systemd units and the scheduled task.
+const Name = "weather-app-notify"
+
+// launchdLabel is the launchd agent's label, in reverse-DNS style.
+const launchdLabel = "com.weather-app.notify"
+
+// Job is a command run every day at Hour:Minute, local time.
+type Job struct {
+	// Command is the absolute path of the executable and its arguments.
+	Command []string
+	Hour    int
+	Minute  int
+}
+
+// File is a file the scheduler reads the job from.
+type File struct {
+	Path string
+	Data []byte
+}
+
+// Plan installs a job: Files are written first, then Commands are run to
+// have the scheduler pick them up.
+type Plan struct {
+	Files    []File
+	Commands [][]string
+}
+
+// NewPlan returns the plan that installs job for the user with home
+// directory home on goos.
+func NewPlan(goos, home string, job Job) (Plan, error) {
+	switch goos {
+	case "linux":
+		dir := filepath.Join(home, ".config", "systemd", "user")
+		return Plan{
+			Files: []File{
+				{Path: filepath.Join(dir, Name+".service"), Data: []byte(systemdService(job))},
+				{Path: filepath.Join(dir, Name+".timer"), Data: []byte(systemdTimer(job))},
+			},
+			Commands: [][]string{
+				{"systemctl", "--user", "daemon-reload"},
+				{"systemctl", "--user", "enable", "--now", Name + ".timer"},
+			},
+		}, nil
+	case "darwin":
+		path := filepath.Join(home, "Library", "LaunchAgents", launchdLabel+".plist")
+		return Plan{
+			Files:    []File{{Path: path, Data: []byte(launchdPlist(job))}},
+			Commands: [][]string{{"launchctl", "load", "-w", path}},
+		}, nil
+	case "windows":
+		return Plan{
+			Commands: [][]string{{
+				"schtasks", "/Create", "/F", "/SC", "DAILY", "/TN", Name,
+				"/ST", fmt.Sprintf("%02d:%02d", job.Hour, job.Minute),
+				"/TR", windowsCommandLine(job.Command),
+			}},
+		}, nil
+	}
+	return Plan{}, fmt.Errorf("Scheduling is not supported on %s, use cron or the system's scheduler", goos)
+}
+
+// Run writes the files of p and runs its commands.
+func (p Plan) Run(ctx context.Context) error {
+	for _, f := range p.Files {
+		if err := os.MkdirAll(filepath.Dir(f.Path), 0o755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(f.Path, f.Data, 0o644); err != nil {
+			return err
+		}
+	}
+	for _, args := range p.Commands {
+		cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			if msg := strings.TrimSpace(string(out)); msg != "" {
+				return fmt.Errorf("%s: %w: %s", strings.Join(args, " "), err, msg)
+			}
+			return fmt.Errorf("%s: %w", strings.Join(args, " "), err)
+		}
+	}
+	return nil
+}
+
+func systemdService(job Job) string {
+	args := make([]string, len(job.Command))
+	for i, arg := range job.Command {
+		args[i] = systemdQuote(arg)
+	}
+	return fmt.Sprintf(`[Unit]
+Description=Daily weather forecast notification
+
+[Service]
+Type=oneshot
+ExecStart=%s
+`, strings.Join(args, " "))
+}
+
+// systemdTimer fires the service daily. Persistent catches up on a run
+// missed while the machine was off.
+func systemdTimer(job Job) string {
+	return fmt.Sprintf(`[Unit]
+Description=Daily weather forecast notification
+
+[Timer]
+OnCalendar=*-*-* %02d:%02d:00
+Persistent=true
+
+[Install]
+WantedBy=timers.target
+`, job.Hour, job.Minute)
+}
+
+// systemdQuote quotes an ExecStart argument. Specifiers (%) and variables
+// ($) are doubled so they are passed on literally.
+func systemdQuote(arg string) string {
+	arg = strings.NewReplacer("%", "%%", "$", "$$").Replace(arg)
+	if arg != "" && !strings.ContainsAny(arg, " \t\"'\\;") {
+		return arg
+	}
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(arg) + `"`
+}
+
+func launchdPlist(job Job) string {
+	var b strings.Builder
+	b.WriteString(`<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0">
+<dict>
+	<key>Label</key>
+	<string>` + launchdLabel + `</string>
+	<key>ProgramArguments</key>
+	<array>
+`)
+	for _, arg := range job.Command {
+		fmt.Fprintf(&b, "\t\t<string>%s</string>\n", xmlEscape(arg))
+	}
+	fmt.Fprintf(&b, `	</array>
+	<key>StartCalendarInterval</key>
+	<dict>
+		<key>Hour</key>
+		<integer>%d</integer>
+		<key>Minute</key>
+		<integer>%d</integer>
+	</dict>
+</dict>
+</plist>
+`, job.Hour, job.Minute)
+	return b.String()
+}
+
+func xmlEscape(s string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(s)
+}
+
+// windowsCommandLine joins args for schtasks /TR, quoting those with
+// spaces.
+func windowsCommandLine(args []string) string {
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		if arg == "" || strings.ContainsAny(arg, " \t") {
+			arg = `"` + strings.ReplaceAll(arg, `"`, `\"`) + `"`
+		}
+		quoted[i] = arg
+	}
+	return strings.Join(quoted, " ")
+}
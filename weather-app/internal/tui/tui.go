@@ -0,0 +1,218 @@
+// Package tui is an interactive terminal dashboard showing the weekly and
+// hourly forecast for one or more places.
+package tui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"weather-app/internal/forecast"
+	"weather-app/internal/units"
+)
+
+// Source loads forecasts for a place. Both functions are called from a
+// background goroutine.
+type Source struct {
+	Daily  func(ctx context.Context, place forecast.Location) (forecast.Forecast, error)
+	Hourly func(ctx context.Context, place forecast.Location) (forecast.Forecast, error)
+}
+
+// Run shows the dashboard until the user quits or ctx is done.
+func Run(ctx context.Context, places []forecast.Location, src Source) error {
+	m := newModel(ctx, places, src)
+	_, err := tea.NewProgram(m, tea.WithContext(ctx), tea.WithAltScreen()).Run()
+	if errors.Is(err, tea.ErrProgramKilled) && ctx.Err() != nil {
+		return nil
+	}
+	return err
+}
+
+var (
+	titleStyle    = lipgloss.NewStyle().Bold(true)
+	activeStyle   = lipgloss.NewStyle().Bold(true).Reverse(true)
+	mutedStyle    = lipgloss.NewStyle().Faint(true)
+	errorStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("9"))
+	paneStyle     = lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).Padding(0, 1)
+	selectedStyle = lipgloss.NewStyle().Reverse(true)
+)
+
+// placeData is what has been loaded for one place.
+type placeData struct {
+	daily   forecast.Forecast
+	hourly  forecast.Forecast
+	err     error
+	loading bool
+}
+
+type loadedMsg struct {
+	index  int
+	daily  forecast.Forecast
+	hourly forecast.Forecast
+	err    error
+}
+
+type model struct {
+	ctx    context.Context
+	src    Source
+	places []forecast.Location
+	data   []placeData
+	place  int
+	day    int
+}
+
+func newModel(ctx context.Context, places []forecast.Location, src Source) model {
+	return model{
+		ctx:    ctx,
+		src:    src,
+		places: places,
+		data:   make([]placeData, len(places)),
+	}
+}
+
+func (m model) Init() tea.Cmd {
+	return m.load(0)
+}
+
+// load marks place i as loading and returns the command that fetches it.
+func (m *model) load(i int) tea.Cmd {
+	m.data[i].loading = true
+	ctx, place, src := m.ctx, m.places[i], m.src
+	return func() tea.Msg {
+		msg := loadedMsg{index: i}
+		msg.daily, msg.err = src.Daily(ctx, place)
+		if msg.err == nil {
+			msg.hourly, msg.err = src.Hourly(ctx, place)
+		}
+		return msg
+	}
+}
+
+func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case loadedMsg:
+		m.data[msg.index] = placeData{daily: msg.daily, hourly: msg.hourly, err: msg.err}
+		m.day = min(m.day, max(len(msg.daily.Days)-1, 0))
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "q", "esc", "ctrl+c":
+			return m, tea.Quit
+		case "left", "h", "shift+tab":
+			return m.switchPlace(-1)
+		case "right", "l", "tab":
+			return m.switchPlace(1)
+		case "up", "k":
+			m.day = max(m.day-1, 0)
+		case "down", "j":
+			m.day = min(m.day+1, max(len(m.data[m.place].daily.Days)-1, 0))
+		case "r":
+			return m, m.load(m.place)
+		}
+	}
+	return m, nil
+}
+
+// switchPlace moves to the previous or next place, loading it the first
+// time it is shown.
+func (m model) switchPlace(delta int) (tea.Model, tea.Cmd) {
+	m.place = (m.place + delta + len(m.places)) % len(m.places)
+	m.day = 0
+	d := m.data[m.place]
+	if d.loading || d.err != nil || len(d.daily.Days) > 0 {
+		return m, nil
+	}
+	return m, m.load(m.place)
+}
+
+func (m model) View() string {
+	var b strings.Builder
+	b.WriteString(m.tabs())
+	b.WriteString("\n\n")
+
+	d := m.data[m.place]
+	switch {
+	case d.loading:
+		b.WriteString(mutedStyle.Render("Loading forecast..."))
+	case d.err != nil:
+		b.WriteString(errorStyle.Render("Error: " + d.err.Error()))
+	default:
+		b.WriteString(lipgloss.JoinHorizontal(lipgloss.Top,
+			paneStyle.Render(m.weekPane(d.daily)),
+			paneStyle.Render(m.hourPane(d)),
+		))
+	}
+
+	b.WriteString("\n")
+	b.WriteString(mutedStyle.Render("←/→ location  ↑/↓ day  r refresh  q quit"))
+	return b.String()
+}
+
+func placeName(place forecast.Location) string {
+	if place.Name == "" {
+		return fmt.Sprintf("%.2f, %.2f", place.Latitude, place.Longitude)
+	}
+	if place.Country == "" {
+		return place.Name
+	}
+	return place.Name + ", " + place.Country
+}
+
+func (m model) tabs() string {
+	tabs := make([]string, len(m.places))
+	for i, place := range m.places {
+		name := " " + placeName(place) + " "
+		if i == m.place {
+			tabs[i] = activeStyle.Render(name)
+		} else {
+			tabs[i] = mutedStyle.Render(name)
+		}
+	}
+	return strings.Join(tabs, " ")
+}
+
+func (m model) weekPane(f forecast.Forecast) string {
+	lines := []string{titleStyle.Render("Week")}
+	for i, day := range f.Days {
+		line := fmt.Sprintf("%s  %5.1f / %5.1f %s", day.Date.Format("Mon 01-02"), day.TempMax, day.TempMin, units.Degrees(f.Units.Temperature))
+		if day.Precipitation != nil {
+			line += fmt.Sprintf("  %5.1f %s", *day.Precipitation, f.Units.Precipitation)
+		}
+		if day.Description != "" {
+			line += "  " + day.Description
+		}
+		if i == m.day {
+			line = selectedStyle.Render(line)
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (m model) hourPane(d placeData) string {
+	if m.day >= len(d.daily.Days) {
+		return titleStyle.Render("Hourly")
+	}
+	date := d.daily.Days[m.day].Date.String()
+	lines := []string{titleStyle.Render("Hourly " + date)}
+	for _, hour := range d.hourly.Hours {
+		if hour.Time.Format("2006-01-02") != date {
+			continue
+		}
+		line := fmt.Sprintf("%s  %5.1f %s", hour.Time.Format("15:04"), hour.Temperature, units.Degrees(d.hourly.Units.Temperature))
+		if hour.PrecipProbability != nil {
+			line += fmt.Sprintf("  %3.0f%%", *hour.PrecipProbability)
+		}
+		if hour.WindSpeed != nil {
+			line += fmt.Sprintf("  %5.1f %s", *hour.WindSpeed, d.hourly.Units.WindSpeed)
+		}
+		lines = append(lines, line)
+	}
+	if len(lines) == 1 {
+		lines = append(lines, mutedStyle.Render("No hourly data for this day"))
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,262 @@
+// Package units converts forecasts between the unit systems offered on the
+// command line. Forecasts are fetched in metric units and converted locally,
+// so every output format agrees on the same numbers.
+package units
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"weather-app/internal/forecast"
+)
+
+// Unit labels as they appear in forecast.Units.
+const (
+	Celsius    = "C"
+	Fahrenheit = "F"
+	Kelvin     = "K"
+
+	KilometresPerHour = "km/h"
+	MetresPerSecond   = "m/s"
+	MilesPerHour      = "mph"
+	Knots             = "kn"
+
+	Millimetres = "mm"
+	Centimetres = "cm"
+	Inches      = "in"
+
+	Metres     = "m"
+	Kilometres = "km"
+	Miles      = "mi"
+	Feet       = "ft"
+)
+
+// System is a bundle of units chosen with -units.
+type System string
+
+const (
+	Metric   System = "metric"
+	Imperial System = "imperial"
+	SI       System = "si"
+)
+
+// Systems lists the accepted -units values.
+var Systems = []string{string(Metric), string(Imperial), string(SI)}
+
+func Parse(s string) (System, error) {
+	switch System(strings.ToLower(s)) {
+	case Metric, "":
+		return Metric, nil
+	case Imperial:
+		return Imperial, nil
+	case SI:
+		return SI, nil
+	}
+	return "", fmt.Errorf("Unknown unit system %q, expected one of %s", s, strings.Join(Systems, ", "))
+}
+
+// Units returns the labels for every quantity in the system.
+func (s System) Units() forecast.Units {
+	switch s {
+	case Imperial:
+		return forecast.Units{Temperature: Fahrenheit, Precipitation: Inches, WindSpeed: MilesPerHour, Snow: Inches}
+	case SI:
+		return forecast.Units{Temperature: Kelvin, Precipitation: Millimetres, WindSpeed: MetresPerSecond, Snow: Centimetres}
+	}
+	return forecast.Units{Temperature: Celsius, Precipitation: Millimetres, WindSpeed: KilometresPerHour, Snow: Centimetres}
+}
+
+// VisibilityUnit is the distance unit the system uses for visibility.
+func (s System) VisibilityUnit() string {
+	switch s {
+	case Imperial:
+		return Miles
+	case SI:
+		return Metres
+	}
+	return Kilometres
+}
+
+// Temperature converts v from one temperature unit to another.
+func Temperature(v float64, from, to string) float64 {
+	if from == to {
+		return v
+	}
+	c := v
+	switch from {
+	case Fahrenheit:
+		c = (v - 32) * 5 / 9
+	case Kelvin:
+		c = v - 273.15
+	}
+	switch to {
+	case Fahrenheit:
+		return c*9/5 + 32
+	case Kelvin:
+		return c + 273.15
+	}
+	return c
+}
+
+var metresPerSecond = map[string]float64{
+	KilometresPerHour: 1 / 3.6,
+	MetresPerSecond:   1,
+	MilesPerHour:      0.44704,
+	Knots:             1852.0 / 3600,
+}
+
+// Speed converts v from one speed unit to another.
+func Speed(v float64, from, to string) float64 {
+	if from == to {
+		return v
+	}
+	return v * metresPerSecond[from] / metresPerSecond[to]
+}
+
+var metres = map[string]float64{
+	Millimetres: 0.001,
+	Centimetres: 0.01,
+	Inches:      0.0254,
+	Metres:      1,
+	Kilometres:  1000,
+	Miles:       1609.344,
+	Feet:        0.3048,
+}
+
+// Length converts precipitation amounts or distances such as visibility.
+func Length(v float64, from, to string) float64 {
+	if from == to {
+		return v
+	}
+	return v * metres[from] / metres[to]
+}
+
+// round keeps converted values at the precision Open-Meteo reports, so
+// 18.2 °C becomes 64.8 °F rather than 64.76000000000001.
+func round(v float64, decimals int) float64 {
+	p := math.Pow(10, float64(decimals))
+	return math.Round(v*p) / p
+}
+
+func lengthDecimals(unit string) int {
+	if unit == Inches || unit == Miles {
+		return 2
+	}
+	return 1
+}
+
+// Convert returns f with every value expressed in the units of to. Empty
+// labels in to keep the unit f already has.
+func Convert(f forecast.Forecast, to forecast.Units) forecast.Forecast {
+	from := f.Units
+	if to.Temperature == "" {
+		to.Temperature = from.Temperature
+	}
+	if to.Precipitation == "" {
+		to.Precipitation = from.Precipitation
+	}
+	if to.WindSpeed == "" {
+		to.WindSpeed = from.WindSpeed
+	}
+	if to.Snow == "" {
+		to.Snow = from.Snow
+	}
+	if to == from {
+		return f
+	}
+
+	temp := func(v float64) float64 {
+		return round(Temperature(v, from.Temperature, to.Temperature), 1)
+	}
+	optionalTemp := func(v *float64) *float64 {
+		if v == nil {
+			return nil
+		}
+		t := temp(*v)
+		return &t
+	}
+	speed := func(v *float64) *float64 {
+		if v == nil {
+			return nil
+		}
+		s := round(Speed(*v, from.WindSpeed, to.WindSpeed), 1)
+		return &s
+	}
+	precip := func(v *float64) *float64 {
+		if v == nil {
+			return nil
+		}
+		p := round(Length(*v, from.Precipitation, to.Precipitation), lengthDecimals(to.Precipitation))
+		return &p
+	}
+	snow := func(v *float64) *float64 {
+		if v == nil {
+			return nil
+		}
+		s := round(Length(*v, from.Snow, to.Snow), lengthDecimals(to.Snow))
+		return &s
+	}
+
+	out := f
+	out.Units = to
+	if f.Current != nil {
+		c := *f.Current
+		c.Temperature = temp(c.Temperature)
+		c.WindSpeed = *speed(&c.WindSpeed)
+		out.Current = &c
+	}
+	if f.Days != nil {
+		out.Days = make([]forecast.Day, len(f.Days))
+		for i, d := range f.Days {
+			d.TempMax = temp(d.TempMax)
+			d.TempMin = temp(d.TempMin)
+			d.TempMaxP10 = optionalTemp(d.TempMaxP10)
+			d.TempMaxP90 = optionalTemp(d.TempMaxP90)
+			d.TempMinP10 = optionalTemp(d.TempMinP10)
+			d.TempMinP90 = optionalTemp(d.TempMinP90)
+			d.FeelsMax = optionalTemp(d.FeelsMax)
+			d.FeelsMin = optionalTemp(d.FeelsMin)
+			d.DewPointMean = optionalTemp(d.DewPointMean)
+			d.DewPointMin = optionalTemp(d.DewPointMin)
+			d.DewPointMax = optionalTemp(d.DewPointMax)
+			d.Precipitation = precip(d.Precipitation)
+			d.Snowfall = snow(d.Snowfall)
+			d.WindSpeedMax = speed(d.WindSpeedMax)
+			d.WindGustsMax = speed(d.WindGustsMax)
+			out.Days[i] = d
+		}
+	}
+	if f.Agri != nil {
+		// Growing degree days are differences, not temperatures, so they
+		// are computed after converting.
+		out.Agri = make([]forecast.AgriDay, len(f.Agri))
+		for i, d := range f.Agri {
+			d.TempMax = temp(d.TempMax)
+			d.TempMin = temp(d.TempMin)
+			d.SoilTemperature = optionalTemp(d.SoilTemperature)
+			out.Agri[i] = d
+		}
+	}
+	if f.Hours != nil {
+		out.Hours = make([]forecast.Hour, len(f.Hours))
+		for i, h := range f.Hours {
+			h.Temperature = temp(h.Temperature)
+			h.Precipitation = precip(h.Precipitation)
+			h.WindSpeed = speed(h.WindSpeed)
+			h.SnowDepth = snow(h.SnowDepth)
+			h.DewPoint = optionalTemp(h.DewPoint)
+			out.Hours[i] = h
+		}
+	}
+	return out
+}
+
+// Degrees formats a temperature unit label for display: "°C" and "°F", but
+// plain "K" for Kelvin.
+func Degrees(unit string) string {
+	if unit == Kelvin {
+		return unit
+	}
+	return "°" + unit
+}
@@ -0,0 +1,123 @@
+package units
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"weather-app/internal/forecast"
+)
+
+func near(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func TestTemperature(t *testing.T) {
+	tests := []struct {
+		v        float64
+		from, to string
+		want     float64
+	}{
+		{0, Celsius, Fahrenheit, 32},
+		{100, Celsius, Fahrenheit, 212},
+		{-40, Fahrenheit, Celsius, -40},
+		{0, Celsius, Kelvin, 273.15},
+		{300, Kelvin, Fahrenheit, 80.33},
+		{21.5, Celsius, Celsius, 21.5},
+	}
+	for _, tt := range tests {
+		if got := Temperature(tt.v, tt.from, tt.to); math.Abs(got-tt.want) > 1e-6 {
+			t.Errorf("Temperature(%g, %s, %s) = %g, want %g", tt.v, tt.from, tt.to, got, tt.want)
+		}
+	}
+}
+
+func TestSpeed(t *testing.T) {
+	tests := []struct {
+		v        float64
+		from, to string
+		want     float64
+	}{
+		{36, KilometresPerHour, MetresPerSecond, 10},
+		{10, MetresPerSecond, KilometresPerHour, 36},
+		{1.609344, KilometresPerHour, MilesPerHour, 1},
+		{1.852, KilometresPerHour, Knots, 1},
+	}
+	for _, tt := range tests {
+		if got := Speed(tt.v, tt.from, tt.to); !near(got, tt.want) {
+			t.Errorf("Speed(%g, %s, %s) = %g, want %g", tt.v, tt.from, tt.to, got, tt.want)
+		}
+	}
+}
+
+func TestLength(t *testing.T) {
+	if got := Length(25.4, Millimetres, Inches); !near(got, 1) {
+		t.Errorf("25.4 mm = %g in, want 1", got)
+	}
+	if got := Length(1, Miles, Kilometres); !near(got, 1.609344) {
+		t.Errorf("1 mi = %g km, want 1.609344", got)
+	}
+	if got := Length(2500, Metres, Kilometres); !near(got, 2.5) {
+		t.Errorf("2500 m = %g km, want 2.5", got)
+	}
+}
+
+func TestParse(t *testing.T) {
+	for _, s := range []string{"metric", "Imperial", "si", ""} {
+		if _, err := Parse(s); err != nil {
+			t.Errorf("Parse(%q): %v", s, err)
+		}
+	}
+	if _, err := Parse("nautical"); err == nil {
+		t.Error("Parse accepted an unknown system")
+	}
+}
+
+func TestConvert(t *testing.T) {
+	precip, wind := 12.7, 36.0
+	f := forecast.Forecast{
+		Units: Metric.Units(),
+		Days: []forecast.Day{{
+			Date:          forecast.Date{Time: time.Date(2024, 6, 3, 0, 0, 0, 0, time.UTC)},
+			TempMax:       18.2,
+			TempMin:       -3,
+			Precipitation: &precip,
+			WindSpeedMax:  &wind,
+		}},
+		Hours:   []forecast.Hour{{Temperature: 10, WindSpeed: &wind}},
+		Current: &forecast.Current{Temperature: 20, WindSpeed: 18},
+	}
+
+	got := Convert(f, Imperial.Units())
+	if got.Units != Imperial.Units() {
+		t.Errorf("units = %+v", got.Units)
+	}
+	day := got.Days[0]
+	if day.TempMax != 64.8 || day.TempMin != 26.6 {
+		t.Errorf("temperatures = %g / %g, want 64.8 / 26.6", day.TempMax, day.TempMin)
+	}
+	if *day.Precipitation != 0.5 {
+		t.Errorf("precipitation = %g in, want 0.5", *day.Precipitation)
+	}
+	if *day.WindSpeedMax != 22.4 {
+		t.Errorf("wind = %g mph, want 22.4", *day.WindSpeedMax)
+	}
+	if got.Hours[0].Temperature != 50 || got.Current.Temperature != 68 || got.Current.WindSpeed != 11.2 {
+		t.Errorf("hour/current not converted: %+v %+v", got.Hours[0], *got.Current)
+	}
+
+	// The input must not be modified through shared pointers.
+	if precip != 12.7 || wind != 36 || f.Current.Temperature != 20 {
+		t.Error("Convert modified its input")
+	}
+
+	si := Convert(f, SI.Units())
+	if math.Abs(si.Days[0].TempMax-291.35) > 0.1 || *si.Days[0].WindSpeedMax != 10 {
+		t.Errorf("SI day = %+v", si.Days[0])
+	}
+
+	partial := Convert(f, forecast.Units{WindSpeed: Knots})
+	if partial.Units.Temperature != Celsius || partial.Days[0].TempMax != 18.2 {
+		t.Errorf("empty labels should keep the unit, got %+v", partial.Units)
+	}
+}
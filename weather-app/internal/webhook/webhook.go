@@ -0,0 +1,101 @@
+// Package webhook posts forecast summaries to Slack and Discord incoming
+// webhooks.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"weather-app/internal/forecast"
+	"weather-app/internal/units"
+)
+
+// Formats lists the supported payload formats.
+var Formats = []string{"slack", "discord"}
+
+// Supported reports whether format is one of Formats.
+func Supported(format string) bool {
+	for _, f := range Formats {
+		if f == format {
+			return true
+		}
+	}
+	return false
+}
+
+// DefaultTimeout bounds a single post.
+const DefaultTimeout = 10 * time.Second
+
+// summary is a monospaced block with one line per day, e.g.
+// "Mon 06-03   21/ 12 °C  Partly cloudy  40% rain".
+func summary(f forecast.Forecast) string {
+	var b strings.Builder
+	degrees := units.Degrees(f.Units.Temperature)
+	for _, day := range f.Days {
+		fmt.Fprintf(&b, "%s  %3.0f/%3.0f %s", day.Date.Format("Mon 01-02"), day.TempMax, day.TempMin, degrees)
+		if day.Description != "" {
+			fmt.Fprintf(&b, "  %s", day.Description)
+		}
+		if day.PrecipChance != nil {
+			fmt.Fprintf(&b, "  %.0f%% rain", *day.PrecipChance)
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+func title(place forecast.Location) string {
+	switch {
+	case place.Name == "":
+		return fmt.Sprintf("Weather at %.2f, %.2f", place.Latitude, place.Longitude)
+	case place.Country == "":
+		return "Weather in " + place.Name
+	}
+	return "Weather in " + place.Name + ", " + place.Country
+}
+
+// Payload encodes the daily forecast f as a message for the given format.
+func Payload(format string, f forecast.Forecast) ([]byte, error) {
+	block := "```\n" + summary(f) + "```"
+	switch format {
+	case "slack":
+		return json.Marshal(map[string]string{
+			"text": "*" + title(f.Location) + "*\n" + block,
+		})
+	case "discord":
+		return json.Marshal(map[string]string{
+			"username": "weather-app",
+			"content":  "**" + title(f.Location) + "**\n" + block,
+		})
+	}
+	return nil, fmt.Errorf("Unknown webhook format %q, expected one of %s", format, strings.Join(Formats, ", "))
+}
+
+// Post sends payload to the webhook at url. A nil client uses one with
+// DefaultTimeout.
+func Post(ctx context.Context, client *http.Client, url string, payload []byte) error {
+	if client == nil {
+		client = &http.Client{Timeout: DefaultTimeout}
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("Webhook returned %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+// Package wmo describes WMO weather interpretation codes as returned by
+// Open-Meteo.
+package wmo
+
+import "fmt"
+
+// Condition is the human readable form of a weather code.
+type Condition struct {
+	Description string
+	Emoji       string
+	// ASCII is a three character icon for terminals without emoji fonts.
+	ASCII string
+}
+
+// conditions covers every code Open-Meteo documents for its weathercode
+// variables.
+var conditions = map[int]Condition{
+	0:  {"Clear sky", "☀️", "-O-"},
+	1:  {"Mainly clear", "🌤", "-O~"},
+	2:  {"Partly cloudy", "⛅", "O~~"},
+	3:  {"Overcast", "☁️", "~~~"},
+	45: {"Fog", "🌫", "==="},
+	48: {"Depositing rime fog", "🌫", "=*="},
+	51: {"Light drizzle", "🌦", ",  "},
+	53: {"Moderate drizzle", "🌦", ", ,"},
+	55: {"Dense drizzle", "🌧", ",,,"},
+	56: {"Light freezing drizzle", "🌧", ",* "},
+	57: {"Dense freezing drizzle", "🌧", ",*,"},
+	61: {"Slight rain", "🌧", "/  "},
+	63: {"Moderate rain", "🌧", "/ /"},
+	65: {"Heavy rain", "🌧", "///"},
+	66: {"Light freezing rain", "🌧", "/* "},
+	67: {"Heavy freezing rain", "🌧", "/*/"},
+	71: {"Slight snow fall", "🌨", "*  "},
+	73: {"Moderate snow fall", "🌨", "* *"},
+	75: {"Heavy snow fall", "❄️", "***"},
+	77: {"Snow grains", "🌨", "..."},
+	80: {"Slight rain showers", "🌦", "'/ "},
+	81: {"Moderate rain showers", "🌦", "'/'"},
+	82: {"Violent rain showers", "🌧", "'//"},
+	85: {"Slight snow showers", "🌨", "'* "},
+	86: {"Heavy snow showers", "🌨", "'**"},
+	95: {"Thunderstorm", "⛈", "/!/"},
+	96: {"Thunderstorm with slight hail", "⛈", "/!o"},
+	99: {"Thunderstorm with heavy hail", "⛈", "!oo"},
+}
+
+// Lookup returns the condition for code and whether the code is known.
+// Unknown codes get a generic description and question mark icons.
+func Lookup(code int) (Condition, bool) {
+	if c, ok := conditions[code]; ok {
+		return c, true
+	}
+	return Condition{
+		Description: fmt.Sprintf("Unknown weather code %d", code),
+		Emoji:       "❔",
+		ASCII:       " ? ",
+	}, false
+}
+
+// Description returns a human readable description of code.
+func Description(code int) string {
+	c, _ := Lookup(code)
+	return c.Description
+}
+
+// Icon returns the icon for code in the given style, "emoji" or "ascii".
+// Any other style yields an empty string.
+func Icon(code int, style string) string {
+	c, _ := Lookup(code)
+	switch style {
+	case "emoji":
+		return c.Emoji
+	case "ascii":
+		return c.ASCII
+	}
+	return ""
+}
+
+// Kind groups a code into a broad kind of weather: "clear", "cloudy",
+// "fog", "rain", "snow" or "storm", or "unknown". Drizzle and freezing rain
+// count as rain.
+func Kind(code int) string {
+	switch {
+	case code == 0 || code == 1:
+		return "clear"
+	case code == 2 || code == 3:
+		return "cloudy"
+	case code == 45 || code == 48:
+		return "fog"
+	case code >= 51 && code <= 67, code >= 80 && code <= 82:
+		return "rain"
+	case code >= 71 && code <= 77, code == 85 || code == 86:
+		return "snow"
+	case code >= 95 && code <= 99:
+		return "storm"
+	}
+	return "unknown"
+}
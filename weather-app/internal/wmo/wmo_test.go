@@ -0,0 +1,73 @@
+package wmo
+
+import (
+	"strings"
+	"testing"
+)
+
+// documented lists the weather codes from the Open-Meteo API documentation.
+var documented = []int{
+	0, 1, 2, 3, 45, 48, 51, 53, 55, 56, 57, 61, 63, 65, 66, 67,
+	71, 73, 75, 77, 80, 81, 82, 85, 86, 95, 96, 99,
+}
+
+func TestLookupCoversDocumentedCodes(t *testing.T) {
+	for _, code := range documented {
+		c, ok := Lookup(code)
+		if !ok {
+			t.Errorf("code %d is missing", code)
+			continue
+		}
+		if c.Description == "" || c.Emoji == "" {
+			t.Errorf("code %d: incomplete condition %+v", code, c)
+		}
+		if n := len([]rune(c.ASCII)); n != 3 {
+			t.Errorf("code %d: ASCII icon %q is %d wide, want 3", code, c.ASCII, n)
+		}
+	}
+	if len(conditions) != len(documented) {
+		t.Errorf("table has %d codes, want %d", len(conditions), len(documented))
+	}
+}
+
+func TestDescription(t *testing.T) {
+	tests := map[int]string{
+		2:  "Partly cloudy",
+		95: "Thunderstorm",
+		42: "Unknown weather code 42",
+	}
+	for code, want := range tests {
+		if got := Description(code); got != want {
+			t.Errorf("Description(%d) = %q, want %q", code, got, want)
+		}
+	}
+}
+
+func TestIcon(t *testing.T) {
+	if got := Icon(0, "emoji"); got != "☀️" {
+		t.Errorf("emoji icon = %q", got)
+	}
+	if got := Icon(65, "ascii"); got != "///" {
+		t.Errorf("ascii icon = %q", got)
+	}
+	if got := Icon(65, ""); got != "" {
+		t.Errorf("no style gave %q", got)
+	}
+	if got := Icon(42, "ascii"); strings.TrimSpace(got) != "?" {
+		t.Errorf("unknown code icon = %q", got)
+	}
+}
+
+func TestKindCoversDocumentedCodes(t *testing.T) {
+	for _, code := range documented {
+		if Kind(code) == "unknown" {
+			t.Errorf("code %d has no kind", code)
+		}
+	}
+	tests := map[int]string{0: "clear", 3: "cloudy", 56: "rain", 81: "rain", 86: "snow", 99: "storm", 42: "unknown"}
+	for code, want := range tests {
+		if got := Kind(code); got != want {
+			t.Errorf("Kind(%d) = %q, want %q", code, got, want)
+		}
+	}
+}
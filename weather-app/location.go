@@ -0,0 +1,110 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"weather-app/internal/provider"
+)
+
+func validateCoordinates(lat, lon float64) error {
+	if lat < -90 || lat > 90 {
+		return fmt.Errorf("-lat must be between -90 and 90, got %g", lat)
+	}
+	if lon < -180 || lon > 180 {
+		return fmt.Errorf("-lon must be between -180 and 180, got %g", lon)
+	}
+	return nil
+}
+
+func isInteractive() bool {
+	return isTerminal(os.Stdin)
+}
+
+func isTerminal(f *os.File) bool {
+	stat, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return stat.Mode()&os.ModeCharDevice != 0
+}
+
+func printCityChoices(matches []provider.Place) {
+	for i, m := range matches {
+		region := m.Region
+		if region == "" {
+			region = "-"
+		}
+		fmt.Fprintf(os.Stderr, "%2d) %s, %s, %s (population %d, %.2f, %.2f)\n",
+			i+1, m.Name, region, m.Country, m.Population, m.Latitude, m.Longitude)
+	}
+}
+
+// splitPlace splits a place written as "city, country" or "city, region,
+// country", e.g. "Springfield, Illinois, United States". A bare city has
+// no country.
+func splitPlace(s string) (city, region, country string, err error) {
+	parts := strings.Split(s, ",")
+	for i := range parts {
+		parts[i] = strings.TrimSpace(parts[i])
+		if parts[i] == "" {
+			return "", "", "", fmt.Errorf("Expected \"city, country\" or \"city, region, country\", got %q", s)
+		}
+	}
+	switch len(parts) {
+	case 1:
+		return parts[0], "", "", nil
+	case 2:
+		return parts[0], "", parts[1], nil
+	case 3:
+		return parts[0], parts[1], parts[2], nil
+	}
+	return "", "", "", fmt.Errorf("Expected \"city, country\" or \"city, region, country\", got %q", s)
+}
+
+// inRegion returns the matches in region, by its name or the start of it,
+// ignoring case.
+func inRegion(matches []provider.Place, region string) []provider.Place {
+	var in []provider.Place
+	for _, m := range matches {
+		if len(m.Region) >= len(region) && strings.EqualFold(m.Region[:len(region)], region) {
+			in = append(in, m)
+		}
+	}
+	return in
+}
+
+// chooseCity picks one of several geocoding matches: the 1-based pick when
+// given, otherwise by asking on the terminal. Without a terminal the first
+// match is used, as before.
+func chooseCity(matches []provider.Place, pick int) (provider.Place, error) {
+	if pick != 0 {
+		if pick < 1 || pick > len(matches) {
+			return provider.Place{}, fmt.Errorf("-pick must be between 1 and %d", len(matches))
+		}
+		return matches[pick-1], nil
+	}
+
+	if len(matches) == 1 || !isInteractive() {
+		return matches[0], nil
+	}
+
+	fmt.Fprintf(os.Stderr, "Found %d matching cities:\n", len(matches))
+	printCityChoices(matches)
+
+	reader := bufio.NewReader(os.Stdin)
+	for {
+		fmt.Fprintf(os.Stderr, "Choose a city [1-%d]: ", len(matches))
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return provider.Place{}, fmt.Errorf("no city selected: %w", err)
+		}
+		n, err := strconv.Atoi(strings.TrimSpace(line))
+		if err == nil && n >= 1 && n <= len(matches) {
+			return matches[n-1], nil
+		}
+	}
+}
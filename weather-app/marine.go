@@ -0,0 +1,90 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+
+	"weather-app/internal/forecast"
+	"weather-app/internal/provider"
+)
+
+// maxMarineDays is how far ahead the Open-Meteo marine model forecasts.
+const maxMarineDays = 8
+
+func fetchMarine(ctx context.Context, p provider.Provider, place forecast.Location, days int) (forecast.Forecast, error) {
+	marine, ok := p.(provider.MarineProvider)
+	if !ok {
+		return forecast.Forecast{}, fmt.Errorf("%s does not forecast waves, try -provider open-meteo", p.Name())
+	}
+	f, err := marine.MarineForecast(ctx, place, days)
+	if err != nil {
+		return f, err
+	}
+	if len(f.Sea) == 0 {
+		return f, fmt.Errorf("No marine forecast for %s, waves are only forecast at sea and on the coast; try -lat and -lon just offshore", place.Name)
+	}
+	return f, nil
+}
+
+func runMarine(ctx context.Context, args []string) {
+	fs := flag.NewFlagSet("marine", flag.ExitOnError)
+	var loc locationFlags
+	var client clientFlags
+	loc.register(fs)
+	client.register(fs)
+	days := fs.Int("days", defaultDays, fmt.Sprintf("Number of forecast days (1-%d) - Optional", maxMarineDays))
+	var out outputFlags
+	out.register(fs)
+
+	fs.Usage = func() {
+		fmt.Println("Wave and swell forecast for a coastal city or a point at sea: the daily")
+		fmt.Println("maximum height (m) and period (s) and the dominant direction they come from.")
+		fmt.Println("Usage:")
+		fmt.Println("  weather-app marine [flags]")
+		fmt.Println()
+		printLocationUsage()
+		fmt.Println()
+		fmt.Println("Optional Flags:")
+		fmt.Printf("  -days           Number of forecast days (default %d, max %d)\n", defaultDays, maxMarineDays)
+		printOutputUsage()
+		printClientUsage()
+	}
+
+	parseLocation(fs, &loc, args)
+
+	if err := out.validate(); err != nil {
+		fatalUsage(err)
+	}
+	client.language = out.lang
+	if out.format == "ics" || out.format == "waybar" || out.format == "i3status" {
+		fatalUsage("marine forecasts can only be shown as table, json or csv")
+	}
+	if *days < 1 || *days > maxMarineDays {
+		fatalUsage(fmt.Sprintf("-days must be between 1 and %d", maxMarineDays))
+	}
+
+	if err := client.setup(slog.LevelWarn); err != nil {
+		fatal(err)
+	}
+
+	p, err := client.newProvider()
+	if err != nil {
+		fatal(err)
+	}
+
+	place, err := loc.resolve(ctx, p)
+	if err != nil {
+		fatal(err)
+	}
+
+	f, err := fetchMarine(ctx, p, place, *days)
+	if err != nil {
+		fatal(err)
+	}
+
+	if err := out.render(f); err != nil {
+		fatal(err)
+	}
+}
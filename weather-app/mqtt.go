@@ -0,0 +1,135 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"weather-app/internal/forecast"
+	"weather-app/internal/mqtt"
+	"weather-app/internal/units"
+)
+
+// discoveryPrefix is where Home Assistant looks for MQTT discovery configs
+// unless it was configured otherwise.
+const discoveryPrefix = "homeassistant"
+
+// publishMQTT publishes each forecast as retained JSON under topic, e.g.
+// home/weather/forecast and home/weather/current. With discovery, Home
+// Assistant sensor configs for their values are published first.
+func publishMQTT(ctx context.Context, broker, topic string, forecasts map[string]forecast.Forecast, discovery bool) error {
+	topic = strings.TrimSuffix(topic, "/")
+	var msgs []mqtt.Message
+	for _, name := range []string{"forecast", "current"} {
+		f, ok := forecasts[name]
+		if !ok {
+			continue
+		}
+		if discovery {
+			configs, err := discoveryMessages(topic, name, f)
+			if err != nil {
+				return err
+			}
+			msgs = append(msgs, configs...)
+		}
+		payload, err := json.Marshal(f)
+		if err != nil {
+			return err
+		}
+		msgs = append(msgs, mqtt.Message{Topic: topic + "/" + name, Payload: payload, Retain: true})
+	}
+	return mqtt.Publish(ctx, broker, msgs)
+}
+
+// haSensor is a Home Assistant MQTT sensor reading one value of the JSON
+// published to a topic.
+type haSensor struct {
+	key, name, template, unit, deviceClass string
+	present                                func(forecast.Forecast) bool
+}
+
+func today(f forecast.Forecast) (forecast.Day, bool) {
+	if len(f.Days) == 0 {
+		return forecast.Day{}, false
+	}
+	return f.Days[0], true
+}
+
+// haSensors are the sensors of the forecast and current topics. Only those
+// present in the published forecast are announced, so no entity is stuck
+// without a value.
+func haSensors(topic string, u forecast.Units) []haSensor {
+	degrees := units.Degrees(u.Temperature)
+	hasDay := func(value func(forecast.Day) bool) func(forecast.Forecast) bool {
+		return func(f forecast.Forecast) bool {
+			day, ok := today(f)
+			return ok && value(day)
+		}
+	}
+	always := func(forecast.Day) bool { return true }
+	switch topic {
+	case "forecast":
+		return []haSensor{
+			{"temp_max", "High today", "{{ value_json.days[0].temp_max }}", degrees, "temperature", hasDay(always)},
+			{"temp_min", "Low today", "{{ value_json.days[0].temp_min }}", degrees, "temperature", hasDay(always)},
+			{"precipitation", "Precipitation today", "{{ value_json.days[0].precipitation }}", u.Precipitation, "precipitation",
+				hasDay(func(d forecast.Day) bool { return d.Precipitation != nil })},
+			{"precipitation_probability", "Chance of precipitation today", "{{ value_json.days[0].precipitation_probability_max }}", "%", "",
+				hasDay(func(d forecast.Day) bool { return d.PrecipChance != nil })},
+			{"uv_index", "UV index today", "{{ value_json.days[0].uv_index }}", "", "",
+				hasDay(func(d forecast.Day) bool { return d.UVIndex != nil })},
+		}
+	case "current":
+		hasCurrent := func(f forecast.Forecast) bool { return f.Current != nil }
+		return []haSensor{
+			{"temperature", "Temperature", "{{ value_json.current.temperature }}", degrees, "temperature", hasCurrent},
+			{"wind_speed", "Wind speed", "{{ value_json.current.wind_speed }}", u.WindSpeed, "wind_speed", hasCurrent},
+		}
+	}
+	return nil
+}
+
+// discoveryMessages are the retained Home Assistant discovery configs of
+// the sensors reading the JSON published to topic/name. The sensors of one
+// topic prefix are grouped under one device named after the location.
+func discoveryMessages(topic, name string, f forecast.Forecast) ([]mqtt.Message, error) {
+	id := strings.NewReplacer("/", "_", "#", "", "+", "", " ", "_").Replace(topic)
+	device := map[string]any{
+		"identifiers":  []string{"weather_app_" + id},
+		"name":         "Weather",
+		"manufacturer": "weather-app",
+	}
+	if f.Location.Name != "" {
+		device["name"] = "Weather in " + f.Location.Name
+	}
+
+	var msgs []mqtt.Message
+	for _, s := range haSensors(name, f.Units) {
+		if !s.present(f) {
+			continue
+		}
+		config := map[string]any{
+			"name":           s.name,
+			"unique_id":      "weather_app_" + id + "_" + s.key,
+			"state_topic":    topic + "/" + name,
+			"value_template": s.template,
+			"device":         device,
+		}
+		if s.unit != "" {
+			config["unit_of_measurement"] = s.unit
+		}
+		if s.deviceClass != "" {
+			config["device_class"] = s.deviceClass
+		}
+		payload, err := json.Marshal(config)
+		if err != nil {
+			return nil, err
+		}
+		msgs = append(msgs, mqtt.Message{
+			Topic:   discoveryPrefix + "/sensor/weather_app_" + id + "/" + s.key + "/config",
+			Payload: payload,
+			Retain:  true,
+		})
+	}
+	return msgs, nil
+}
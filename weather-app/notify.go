@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"weather-app/internal/forecast"
+	"weather-app/internal/notify"
+	"weather-app/internal/units"
+)
+
+// todaySummary is the notification text for the first day of f, e.g.
+// "Partly cloudy, high 21 °C, low 12 °C, 40% chance of rain".
+func todaySummary(f forecast.Forecast) string {
+	day := f.Days[0]
+	degrees := units.Degrees(f.Units.Temperature)
+	parts := []string{
+		fmt.Sprintf("high %.0f %s", day.TempMax, degrees),
+		fmt.Sprintf("low %.0f %s", day.TempMin, degrees),
+	}
+	if day.Description != "" {
+		parts = append([]string{day.Description}, parts...)
+	}
+	if day.PrecipChance != nil {
+		parts = append(parts, fmt.Sprintf("%.0f%% chance of rain", *day.PrecipChance))
+	}
+	return strings.Join(parts, ", ")
+}
+
+func runNotify(ctx context.Context, args []string) {
+	fs := flag.NewFlagSet("notify", flag.ExitOnError)
+	var loc locationFlags
+	var client clientFlags
+	loc.register(fs)
+	client.register(fs)
+	opts := forecastOptions{Precipitation: true, Days: 1}
+	fs.BoolVar(&opts.Fahrenheit, "f", false, "Use fahrenheit - Optional")
+	fs.StringVar(&opts.Units, "units", string(units.Metric), "Unit system: "+strings.Join(units.Systems, ", ")+" - Optional")
+	printOnly := fs.Bool("print", false, "Print the notification instead of sending it - Optional")
+
+	fs.Usage = func() {
+		fmt.Println("Show today's high, low and conditions as a desktop notification.")
+		fmt.Println("Uses notify-send on Linux, osascript on macOS and a toast on Windows.")
+		fmt.Println("Usage:")
+		fmt.Println("  weather-app notify [flags]")
+		fmt.Println()
+		printLocationUsage()
+		fmt.Println()
+		fmt.Println("Optional Flags:")
+		fmt.Println("  -units          Unit system: metric, imperial or si (default metric)")
+		fmt.Println("  -f              Use fahrenheit whatever the unit system")
+		fmt.Println("  -print          Print the notification instead of sending it")
+		printClientUsage()
+		fmt.Println()
+		fmt.Println("  From cron on Linux, notify-send needs DBUS_SESSION_BUS_ADDRESS of the desktop")
+		fmt.Println("  session, e.g. DBUS_SESSION_BUS_ADDRESS=unix:path=/run/user/1000/bus.")
+	}
+
+	parseLocation(fs, &loc, args)
+
+	if err := opts.validate(); err != nil {
+		fatalUsage(err)
+	}
+
+	if err := client.setup(slog.LevelWarn); err != nil {
+		fatal(err)
+	}
+
+	p, err := client.newProvider()
+	if err != nil {
+		fatal(err)
+	}
+
+	place, err := loc.resolve(ctx, p)
+	if err != nil {
+		fatal(err)
+	}
+
+	f, err := fetchDaily(ctx, p, place, opts)
+	if err != nil {
+		fatal(err)
+	}
+	if len(f.Days) == 0 {
+		fatal("No forecast for today")
+	}
+
+	title := "Weather in " + placeLabel(place.Name, place.Country)
+	if place.Name == "" {
+		title = "Weather today"
+	}
+	body := todaySummary(f)
+	if *printOnly {
+		fmt.Println(title)
+		fmt.Println(body)
+		return
+	}
+	if err := notify.Send(ctx, title, body); err != nil {
+		fatal(err)
+	}
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"weather-app/internal/units"
+)
+
+func runNow(ctx context.Context, args []string) {
+	fs := flag.NewFlagSet("now", flag.ExitOnError)
+	var loc locationFlags
+	var client clientFlags
+	loc.register(fs)
+	client.register(fs)
+	var opts forecastOptions
+	fs.BoolVar(&opts.Fahrenheit, "f", false, "Use fahrenheit - Optional")
+	fs.StringVar(&opts.Units, "units", string(units.Metric), "Unit system: "+strings.Join(units.Systems, ", ")+" - Optional")
+	fs.StringVar(&opts.WindUnit, "wind-unit", "", "Wind speed unit: kmh, ms, mph or kn, overriding -units - Optional")
+	fs.StringVar(&opts.Timezone, "tz", "", "Show times in this timezone, e.g. Europe/Amsterdam, or local - Optional")
+	var out outputFlags
+	out.register(fs)
+
+	fs.Usage = func() {
+		fmt.Println("Current weather conditions for a city.")
+		fmt.Println("Usage:")
+		fmt.Println("  weather-app now [flags]")
+		fmt.Println()
+		printLocationUsage()
+		fmt.Println()
+		fmt.Println("Optional Flags:")
+		fmt.Println("  -units          Unit system: metric, imperial or si (default metric)")
+		fmt.Println("  -f              Use fahrenheit whatever the unit system")
+		fmt.Println("  -wind-unit      Wind speed unit: kmh, ms, mph or kn, overriding -units")
+		fmt.Println("  -tz             Show times in this IANA timezone (e.g. Europe/Amsterdam) or local")
+		printOutputUsage()
+		printClientUsage()
+	}
+
+	parseLocation(fs, &loc, args)
+
+	if err := out.validate(); err != nil {
+		fatalUsage(err)
+	}
+	client.language = out.lang
+
+	if err := opts.validate(); err != nil {
+		fatalUsage(err)
+	}
+
+	if err := client.setup(slog.LevelWarn); err != nil {
+		fatal(err)
+	}
+
+	p, err := client.newProvider()
+	if err != nil {
+		fatal(err)
+	}
+
+	place, err := loc.resolve(ctx, p)
+	if err != nil {
+		fatal(err)
+	}
+
+	f, err := fetchCurrent(ctx, p, place, opts)
+	if err != nil {
+		fatal(err)
+	}
+
+	if err := out.render(f); err != nil {
+		fatal(err)
+	}
+}
@@ -0,0 +1,347 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"weather-app/internal/activity"
+	"weather-app/internal/clothing"
+	"weather-app/internal/forecast"
+	"weather-app/internal/forecastlog"
+	"weather-app/internal/provider"
+	"weather-app/internal/units"
+)
+
+// windUnits maps the accepted -wind-unit spellings to Open-Meteo's
+// wind_speed_unit values.
+var windUnits = map[string]string{
+	"kmh":   "kmh",
+	"km/h":  "kmh",
+	"ms":    "ms",
+	"m/s":   "ms",
+	"mph":   "mph",
+	"kn":    "kn",
+	"knots": "kn",
+}
+
+// Open-Meteo forecasts at most 16 days ahead and defaults to a week. It
+// keeps up to 92 past days on the forecast endpoint.
+const (
+	defaultDays = 7
+	maxDays     = 16
+	maxPastDays = 92
+)
+
+// Elevations outside the lowest and highest land on Earth are typos.
+const (
+	minElevation = -500
+	maxElevation = 9000
+)
+
+// modelNames maps the -model shorthands to Open-Meteo model names. Other
+// names are passed on as given, for the models without a shorthand.
+var modelNames = map[string]string{
+	"best_match": "best_match",
+	"gfs":        "gfs_seamless",
+	"icon":       "icon_seamless",
+	"ecmwf":      "ecmwf_ifs025",
+}
+
+var windUnitLabels = map[string]string{
+	"kmh": "km/h",
+	"ms":  "m/s",
+	"mph": "mph",
+	"kn":  "kn",
+}
+
+// forecastOptions decide which variables are requested from the API and in
+// which units.
+type forecastOptions struct {
+	Precipitation bool
+	UVIndex       bool
+	UVAdvice      bool
+	Sunrise       bool
+	Sunset        bool
+	Daylight      bool
+	Fahrenheit    bool
+	Wind          bool
+	Feels         bool
+	Snow          bool
+	Humidity      bool
+	Pressure      bool
+	Clouds        bool
+	Ensemble      bool
+	// Units is the unit system; Fahrenheit and WindUnit override parts of it.
+	Units    string
+	WindUnit string
+	Days     int
+	PastDays int
+	// Model is the comma-separated -model value; validate expands it into
+	// Models, the Open-Meteo model names.
+	Model  string
+	Models []string
+	// Timezone is an IANA zone or "local" to show times in instead of the
+	// location's own.
+	Timezone string
+	// Elevation overrides the height in metres of the grid cell the
+	// forecast is downscaled to.
+	Elevation *float64
+	// WarnBelow and WarnAbove mark days whose low or high crosses them, in
+	// the temperature unit shown.
+	WarnBelow *float64
+	WarnAbove *float64
+	// Activity names the -score activity, and Score is its profile once
+	// the config file is read.
+	Activity string
+	Score    *activity.Profile
+	// WhatToWear recommends clothes by the rules of Wear.
+	WhatToWear bool
+	Wear       []clothing.Rule
+	// Log records every fetched daily forecast when set.
+	Log *forecastlog.DB
+	// Diff compares each daily forecast with the one Log last recorded for
+	// the place.
+	Diff bool
+}
+
+func (o *forecastOptions) register(fs *flag.FlagSet) {
+	fs.BoolVar(&o.Precipitation, "p", false, "Get precipitation - Optional")
+	fs.BoolVar(&o.UVIndex, "uv", false, "Get UV index - Optional")
+	fs.BoolVar(&o.UVAdvice, "uv-advice", false, "Get UV index with its WHO exposure category and sun protection advice - Optional")
+	fs.BoolVar(&o.Sunrise, "sunrise", false, "Get sunrise time - Optional")
+	fs.BoolVar(&o.Sunset, "sunset", false, "Get sunset time - Optional")
+	fs.BoolVar(&o.Daylight, "daylight", false, "Get daylight and sunshine duration - Optional")
+	fs.BoolVar(&o.Fahrenheit, "f", false, "Use fahrenheit - Optional")
+	fs.StringVar(&o.Units, "units", string(units.Metric), "Unit system: "+strings.Join(units.Systems, ", ")+" - Optional")
+	fs.BoolVar(&o.Wind, "wind", false, "Get wind speed, gusts and direction - Optional")
+	fs.StringVar(&o.WindUnit, "wind-unit", "", "Wind speed unit: kmh, ms, mph or kn, overriding -units - Optional")
+	fs.BoolVar(&o.Feels, "feels", false, "Get the apparent (\"feels like\") temperature - Optional")
+	fs.BoolVar(&o.Snow, "snow", false, "Get snowfall, and snow depth with -hourly - Optional")
+	fs.BoolVar(&o.Humidity, "humidity", false, "Get relative humidity and dew point - Optional")
+	fs.BoolVar(&o.Pressure, "pressure", false, "Get surface pressure and today's pressure trend - Optional")
+	fs.BoolVar(&o.Clouds, "clouds", false, "Get cloud cover and visibility - Optional")
+	fs.BoolVar(&o.Ensemble, "ensemble", false, "Get the likely range of the highs and lows from an ensemble forecast - Optional")
+	fs.IntVar(&o.Days, "days", defaultDays, "Number of forecast days (1-16) - Optional")
+	fs.IntVar(&o.PastDays, "past-days", 0, "Also show this many recent days before today (0-92) - Optional")
+	fs.StringVar(&o.Timezone, "tz", "", "Show times in this timezone, e.g. Europe/Amsterdam, or local - Optional")
+	fs.Func("elevation", "Forecast for this elevation in metres instead of the grid cell's - Optional", func(s string) error {
+		v, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return fmt.Errorf("expected metres, e.g. 1500")
+		}
+		o.Elevation = &v
+		return nil
+	})
+	fs.Func("warn-below", "Warn of days with a low below this temperature, e.g. 0 for frost - Optional", temperatureFlag(&o.WarnBelow))
+	fs.Func("warn-above", "Warn of days with a high above this temperature, e.g. 35 for heat - Optional", temperatureFlag(&o.WarnAbove))
+	fs.StringVar(&o.Activity, "score", "", "Score each day 0-10 for an activity: "+strings.Join(activity.Names(), ", ")+" or one of the config file - Optional")
+	fs.BoolVar(&o.WhatToWear, "what-to-wear", false, "Recommend clothes for each day, e.g. light jacket, umbrella - Optional")
+	fs.StringVar(&o.Model, "model", "", "Weather model: gfs, icon, ecmwf or best_match, comma-separated to compare - Optional")
+}
+
+// temperatureFlag parses a threshold of -warn-below or -warn-above into *v.
+func temperatureFlag(v **float64) func(string) error {
+	return func(s string) error {
+		t, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return fmt.Errorf("expected a temperature, e.g. -5")
+		}
+		*v = &t
+		return nil
+	}
+}
+
+// loadTimezone looks up an IANA zone name; "local" is the system zone.
+func loadTimezone(name string) (*time.Location, error) {
+	if strings.EqualFold(name, "local") {
+		return time.Local, nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("Unknown timezone %q, expected an IANA name such as Europe/Amsterdam, or local", name)
+	}
+	return loc, nil
+}
+
+func (o *forecastOptions) validate() error {
+	system, err := units.Parse(o.Units)
+	if err != nil {
+		return err
+	}
+	o.Units = string(system)
+	if o.WindUnit != "" {
+		unit, ok := windUnits[o.WindUnit]
+		if !ok {
+			return fmt.Errorf("Unknown wind unit %q, expected kmh, ms, mph or kn", o.WindUnit)
+		}
+		o.WindUnit = unit
+	}
+	if o.UVAdvice {
+		o.UVIndex = true
+	}
+	if o.Days == 0 {
+		o.Days = defaultDays
+	}
+	if o.Days < 1 || o.Days > maxDays {
+		return fmt.Errorf("-days must be between 1 and %d", maxDays)
+	}
+	if o.PastDays < 0 || o.PastDays > maxPastDays {
+		return fmt.Errorf("-past-days must be between 0 and %d", maxPastDays)
+	}
+	if o.Timezone != "" {
+		if _, err := loadTimezone(o.Timezone); err != nil {
+			return err
+		}
+	}
+	if o.Elevation != nil && (*o.Elevation < minElevation || *o.Elevation > maxElevation) {
+		return fmt.Errorf("-elevation must be between %d and %d metres", minElevation, maxElevation)
+	}
+	if o.WarnBelow != nil && o.WarnAbove != nil && *o.WarnBelow >= *o.WarnAbove {
+		return fmt.Errorf("-warn-below must be lower than -warn-above")
+	}
+	o.Models = nil
+	for _, name := range strings.Split(o.Model, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		if api, ok := modelNames[name]; ok {
+			name = api
+		}
+		if slices.Contains(o.Models, name) {
+			return fmt.Errorf("Model %q is given twice", name)
+		}
+		o.Models = append(o.Models, name)
+	}
+	return nil
+}
+
+// byModel returns a copy of o for each model in Models, to fetch their
+// forecasts separately.
+func (o forecastOptions) byModel() []forecastOptions {
+	opts := make([]forecastOptions, len(o.Models))
+	for i, model := range o.Models {
+		opts[i] = o
+		opts[i].Model, opts[i].Models = model, []string{model}
+	}
+	return opts
+}
+
+// units returns the units forecasts are converted to for display.
+func (o forecastOptions) units() forecast.Units {
+	u := units.System(o.Units).Units()
+	if o.Fahrenheit {
+		u.Temperature = units.Fahrenheit
+	}
+	if label, ok := windUnitLabels[o.WindUnit]; ok {
+		u.WindSpeed = label
+	}
+	return u
+}
+
+// providerOptions returns the variables to fetch from the provider.
+func (o forecastOptions) providerOptions() provider.Options {
+	opts := provider.Options{
+		Precipitation: o.Precipitation,
+		UVIndex:       o.UVIndex,
+		Sunrise:       o.Sunrise,
+		Sunset:        o.Sunset,
+		Daylight:      o.Daylight,
+		Wind:          o.Wind,
+		Feels:         o.Feels,
+		Snow:          o.Snow,
+		Humidity:      o.Humidity,
+		Pressure:      o.Pressure,
+		Clouds:        o.Clouds,
+		Ensemble:      o.Ensemble,
+		Days:          o.Days,
+		PastDays:      o.PastDays,
+		Elevation:     o.Elevation,
+	}
+	if len(o.Models) == 1 {
+		opts.Model = o.Models[0]
+	}
+	return opts
+}
+
+// convert turns a freshly fetched metric forecast into the requested units.
+func (o forecastOptions) convert(f forecast.Forecast, err error) (forecast.Forecast, error) {
+	if err != nil {
+		return forecast.Forecast{}, err
+	}
+	f = units.Convert(f, o.units())
+	if o.Timezone != "" {
+		loc, err := loadTimezone(o.Timezone)
+		if err != nil {
+			return forecast.Forecast{}, err
+		}
+		f = f.In(loc)
+	}
+	return f, nil
+}
+
+func fetchDaily(ctx context.Context, p provider.Provider, place forecast.Location, opts forecastOptions) (forecast.Forecast, error) {
+	f, err := p.DailyForecast(ctx, place, opts.providerOptions())
+	var earlier forecast.Forecast
+	var earlierAt time.Time
+	var found bool
+	// A cached forecast was logged when it was fetched.
+	if err == nil && opts.Log != nil && !f.Cached {
+		// Forecasts of a chosen model are logged apart, so accuracy can
+		// tell the models apart.
+		name := p.Name()
+		if f.Model != "" {
+			name += "/" + f.Model
+		}
+		if opts.Diff {
+			if earlier, earlierAt, found, err = opts.Log.Latest(ctx, name, f.Location); err != nil {
+				return forecast.Forecast{}, fmt.Errorf("reading the earlier forecast: %w", err)
+			}
+			if !found {
+				slog.Warn("no earlier forecast in the log to compare with", "place", f.Label())
+			}
+		}
+		// Providers return metric values, which is what the log keeps.
+		if err := opts.Log.Add(ctx, name, f, time.Now()); err != nil {
+			return forecast.Forecast{}, fmt.Errorf("logging forecast: %w", err)
+		}
+	}
+	// Profiles and rules are metric, so days are scored and dressed before
+	// converting.
+	if err == nil && opts.Score != nil {
+		activity.Mark(f.Days, *opts.Score)
+		f.Activity = opts.Activity
+	}
+	if err == nil && opts.WhatToWear {
+		clothing.Mark(f.Days, opts.Wear)
+	}
+	if f, err = opts.convert(f, err); err != nil {
+		return f, err
+	}
+	if found {
+		if earlier, err = opts.convert(earlier, nil); err != nil {
+			return earlier, err
+		}
+		markChanges(&f, earlier, earlierAt)
+	}
+	forecast.MarkWarnings(f.Days, opts.WarnBelow, opts.WarnAbove)
+	return f, nil
+}
+
+func fetchHourly(ctx context.Context, p provider.Provider, place forecast.Location, opts forecastOptions, hours int) (forecast.Forecast, error) {
+	return opts.convert(p.HourlyForecast(ctx, place, opts.providerOptions(), hours))
+}
+
+func fetchCurrent(ctx context.Context, p provider.Provider, place forecast.Location, opts forecastOptions) (forecast.Forecast, error) {
+	current, ok := p.(provider.CurrentProvider)
+	if !ok {
+		return forecast.Forecast{}, fmt.Errorf("%s does not report current conditions", p.Name())
+	}
+	return opts.convert(current.CurrentForecast(ctx, place))
+}
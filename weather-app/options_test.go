@@ -0,0 +1,39 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"weather-app/internal/provider"
+	"weather-app/pkg/openmeteo"
+)
+
+func TestDailyRequestVariables(t *testing.T) {
+	tr := fakeTransport(t, forecastFile("daily.json"))
+	p := &provider.OpenMeteo{Client: openmeteo.NewClient(openmeteo.WithHTTPClient(tr.Client()))}
+	opts := forecastOptions{Precipitation: true, Fahrenheit: true, WindUnit: "mph", Wind: true, Days: 10}
+	if _, err := fetchDaily(context.Background(), p, hague, opts); err != nil {
+		t.Fatal(err)
+	}
+
+	requests := tr.Requests()
+	if len(requests) != 1 {
+		t.Fatalf("got %d requests, want 1", len(requests))
+	}
+	q := requests[0].URL.Query()
+	// Units are converted locally, so the request always stays metric.
+	want := map[string]string{
+		"daily":            "temperature_2m_max,temperature_2m_min,weathercode,precipitation_sum,precipitation_probability_max,precipitation_hours,windspeed_10m_max,windgusts_10m_max,winddirection_10m_dominant",
+		"temperature_unit": "",
+		"wind_speed_unit":  "",
+		"forecast_days":    "10",
+		"latitude":         "52.08",
+		"longitude":        "4.3",
+		"timezone":         "auto",
+	}
+	for key, value := range want {
+		if got := q.Get(key); got != value {
+			t.Errorf("%s = %q, want %q", key, got, value)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+// Package iso3166 resolves countries given as English names, ISO 3166-1
+// alpha-2 codes or alpha-3 codes from an embedded table.
+package iso3166
+
+import (
+	_ "embed"
+	"encoding/csv"
+	"strings"
+	"sync"
+)
+
+//go:embed countries.csv
+var countriesCSV string
+
+type Country struct {
+	Alpha2 string
+	Alpha3 string
+	// Name is the short English name the geocoding API uses.
+	Name string
+}
+
+var (
+	loadOnce sync.Once
+	// byKey maps every code, name and alias, upper-cased, to its country.
+	byKey map[string]Country
+)
+
+// load parses the embedded table. It is part of the binary, so a malformed
+// file is a programming error.
+func load() {
+	records, err := csv.NewReader(strings.NewReader(countriesCSV)).ReadAll()
+	if err != nil {
+		panic("iso3166: " + err.Error())
+	}
+	byKey = make(map[string]Country, 4*len(records))
+	for _, r := range records[1:] {
+		c := Country{Alpha2: r[0], Alpha3: r[1], Name: r[2]}
+		keys := []string{c.Alpha2, c.Alpha3, c.Name}
+		if r[3] != "" {
+			keys = append(keys, strings.Split(r[3], ";")...)
+		}
+		for _, key := range keys {
+			byKey[strings.ToUpper(key)] = c
+		}
+	}
+}
+
+// Lookup finds a country by its alpha-2 code, alpha-3 code, English name
+// or a common other name such as "Holland", ignoring case.
+func Lookup(country string) (Country, bool) {
+	loadOnce.Do(load)
+	c, ok := byKey[strings.ToUpper(strings.TrimSpace(country))]
+	return c, ok
+}
+
+// Code returns the alpha-2 code of country, or "" when it is not known.
+func Code(country string) string {
+	c, _ := Lookup(country)
+	return c.Alpha2
+}
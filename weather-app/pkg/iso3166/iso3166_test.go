@@ -0,0 +1,54 @@
+package iso3166
+
+import "testing"
+
+func TestLookup(t *testing.T) {
+	germany := Country{Alpha2: "DE", Alpha3: "DEU", Name: "Germany"}
+	uk := Country{Alpha2: "GB", Alpha3: "GBR", Name: "United Kingdom"}
+	tests := []struct {
+		in   string
+		want Country
+		ok   bool
+	}{
+		{"DE", germany, true},
+		{"DEU", germany, true},
+		{"Germany", germany, true},
+		{"de", germany, true},
+		{"deu", germany, true},
+		{"gErMaNy", germany, true},
+		{"  DE ", germany, true},
+		{"GB", uk, true},
+		{"gbr", uk, true},
+		{"uk", uk, true},
+		{"Scotland", uk, true},
+		{"XX", Country{}, false},
+		{"XXX", Country{}, false},
+		{"Atlantis", Country{}, false},
+		{"", Country{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, ok := Lookup(tt.in)
+			if got != tt.want || ok != tt.ok {
+				t.Errorf("Lookup(%q) = %+v, %t, want %+v, %t", tt.in, got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
+
+func TestCode(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"FR", "FR"},
+		{"fra", "FR"},
+		{"France", "FR"},
+		{"Holland", "NL"},
+		{"nowhere", ""},
+	}
+	for _, tt := range tests {
+		if got := Code(tt.in); got != tt.want {
+			t.Errorf("Code(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package nws
+
+import (
+	"context"
+	"net/url"
+	"time"
+)
+
+// Alert is an active watch, warning or advisory.
+type Alert struct {
+	ID          string     `json:"id"`
+	Event       string     `json:"event"`
+	Severity    string     `json:"severity"`
+	Urgency     string     `json:"urgency"`
+	Headline    string     `json:"headline"`
+	Description string     `json:"description"`
+	Instruction string     `json:"instruction"`
+	AreaDesc    string     `json:"areaDesc"`
+	Effective   time.Time  `json:"effective"`
+	Onset       *time.Time `json:"onset"`
+	Expires     time.Time  `json:"expires"`
+	Ends        *time.Time `json:"ends"`
+}
+
+// Alerts returns the alerts currently in effect for a location.
+func (c *Client) Alerts(ctx context.Context, lat, lon float64) ([]Alert, error) {
+	var resp struct {
+		Features []struct {
+			Properties Alert `json:"properties"`
+		} `json:"features"`
+	}
+	query := url.Values{"point": {point(lat, lon)}}
+	if err := c.getJSON(ctx, c.baseURL+"/alerts/active?"+query.Encode(), &resp); err != nil {
+		return nil, err
+	}
+	alerts := make([]Alert, len(resp.Features))
+	for i, f := range resp.Features {
+		alerts[i] = f.Properties
+	}
+	return alerts, nil
+}
@@ -0,0 +1,117 @@
+// Package nws is a small client for the US National Weather Service API at
+// api.weather.gov.
+package nws
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+const (
+	DefaultBaseURL = "https://api.weather.gov"
+	// DefaultUserAgent identifies the application, which the API requires.
+	DefaultUserAgent = "weather-app (github.com/gravi1984/saas-hackthon)"
+
+	DefaultTimeout = 10 * time.Second
+)
+
+type Client struct {
+	httpClient *http.Client
+	baseURL    string
+	userAgent  string
+	timeout    time.Duration
+	logger     *slog.Logger
+}
+
+type Option func(*Client)
+
+// WithHTTPClient sets the HTTP client used for all requests. The client's
+// own timeout applies; WithTimeout is ignored.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithTimeout sets the overall timeout of each request made by the default
+// HTTP client.
+func WithTimeout(d time.Duration) Option {
+	return func(c *Client) {
+		c.timeout = d
+	}
+}
+
+// WithLogger logs requests with their latency to logger. Nothing is logged
+// by default.
+func WithLogger(logger *slog.Logger) Option {
+	return func(c *Client) {
+		c.logger = logger
+	}
+}
+
+// WithBaseURL overrides the API root, e.g. for a mirror.
+func WithBaseURL(u string) Option {
+	return func(c *Client) {
+		c.baseURL = u
+	}
+}
+
+// WithUserAgent sets the User-Agent sent with every request.
+func WithUserAgent(ua string) Option {
+	return func(c *Client) {
+		c.userAgent = ua
+	}
+}
+
+func NewClient(opts ...Option) *Client {
+	c := &Client{
+		baseURL:   DefaultBaseURL,
+		userAgent: DefaultUserAgent,
+		timeout:   DefaultTimeout,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.httpClient == nil {
+		c.httpClient = &http.Client{Timeout: c.timeout}
+	}
+	if c.logger == nil {
+		c.logger = slog.New(slog.DiscardHandler)
+	}
+	return c
+}
+
+func (c *Client) getJSON(ctx context.Context, requestURL string, v any) error {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL, nil)
+	if err != nil {
+		return err
+	}
+	request.Header.Set("User-Agent", c.userAgent)
+	request.Header.Set("Accept", "application/geo+json")
+
+	start := time.Now()
+	response, err := c.httpClient.Do(request)
+	if err != nil {
+		c.logger.Info("request failed", "url", requestURL, "duration", time.Since(start), "error", err)
+		return err
+	}
+	defer response.Body.Close()
+
+	data, err := io.ReadAll(response.Body)
+	if err != nil {
+		return err
+	}
+	c.logger.Info("request", "url", requestURL, "status", response.StatusCode, "duration", time.Since(start), "bytes", len(data))
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return newAPIError(response.StatusCode, data)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("decoding response from %s: %w", requestURL, err)
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+package nws
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+var (
+	// ErrUnsupportedLocation is returned for points outside the area the
+	// National Weather Service forecasts for.
+	ErrUnsupportedLocation = errors.New("location is not covered by the National Weather Service")
+)
+
+// APIError is a response with a non-2xx status. Detail is the explanation
+// from the API's problem document, when it sent one.
+type APIError struct {
+	StatusCode int
+	Detail     string
+}
+
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("NWS API returned %d %s", e.StatusCode, http.StatusText(e.StatusCode))
+	if e.Detail != "" {
+		msg += ": " + e.Detail
+	}
+	return msg
+}
+
+// newAPIError builds an APIError from a response. The API reports problems
+// as application/problem+json with a title and a detail.
+func newAPIError(status int, body []byte) *APIError {
+	var payload struct {
+		Title  string `json:"title"`
+		Detail string `json:"detail"`
+	}
+	json.Unmarshal(body, &payload)
+	detail := payload.Detail
+	if detail == "" {
+		detail = payload.Title
+	}
+	return &APIError{StatusCode: status, Detail: detail}
+}
@@ -0,0 +1,96 @@
+package nws
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// point formats coordinates the way the API expects them: it redirects
+// requests with more than four decimals.
+func point(lat, lon float64) string {
+	return fmt.Sprintf("%.4f,%.4f", lat, lon)
+}
+
+// Point is the forecast office grid cell covering a location, with the
+// URLs of its forecasts.
+type Point struct {
+	GridID           string `json:"gridId"`
+	GridX            int    `json:"gridX"`
+	GridY            int    `json:"gridY"`
+	Forecast         string `json:"forecast"`
+	ForecastHourly   string `json:"forecastHourly"`
+	ForecastGridData string `json:"forecastGridData"`
+	TimeZone         string `json:"timeZone"`
+	City             string `json:"-"`
+	State            string `json:"-"`
+}
+
+// Points looks up the grid cell for a location. Locations outside the US
+// fail with ErrUnsupportedLocation.
+func (c *Client) Points(ctx context.Context, lat, lon float64) (*Point, error) {
+	var resp struct {
+		Properties struct {
+			Point
+			RelativeLocation struct {
+				Properties struct {
+					City  string `json:"city"`
+					State string `json:"state"`
+				} `json:"properties"`
+			} `json:"relativeLocation"`
+		} `json:"properties"`
+	}
+	err := c.getJSON(ctx, c.baseURL+"/points/"+point(lat, lon), &resp)
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%w (%s)", ErrUnsupportedLocation, point(lat, lon))
+	}
+	if err != nil {
+		return nil, err
+	}
+	p := resp.Properties.Point
+	p.City = resp.Properties.RelativeLocation.Properties.City
+	p.State = resp.Properties.RelativeLocation.Properties.State
+	return &p, nil
+}
+
+// Quantity is a measured value with its WMO unit code, e.g.
+// "wmoUnit:percent". Value is nil when the API has no data.
+type Quantity struct {
+	UnitCode string   `json:"unitCode"`
+	Value    *float64 `json:"value"`
+}
+
+// Period is one entry of a forecast: a day or night for the 7-day forecast
+// and an hour for the hourly one.
+type Period struct {
+	Number                     int       `json:"number"`
+	Name                       string    `json:"name"`
+	StartTime                  time.Time `json:"startTime"`
+	EndTime                    time.Time `json:"endTime"`
+	IsDaytime                  bool      `json:"isDaytime"`
+	Temperature                float64   `json:"temperature"`
+	TemperatureUnit            string    `json:"temperatureUnit"`
+	ProbabilityOfPrecipitation Quantity  `json:"probabilityOfPrecipitation"`
+	RelativeHumidity           Quantity  `json:"relativeHumidity"`
+	Dewpoint                   Quantity  `json:"dewpoint"`  // degC
+	WindSpeed                  string    `json:"windSpeed"` // e.g. "5 to 10 mph"
+	WindDirection              string    `json:"windDirection"`
+	ShortForecast              string    `json:"shortForecast"`
+	DetailedForecast           string    `json:"detailedForecast"`
+}
+
+// Forecast fetches the periods of a forecast URL taken from a Point.
+func (c *Client) Forecast(ctx context.Context, forecastURL string) ([]Period, error) {
+	var resp struct {
+		Properties struct {
+			Periods []Period `json:"periods"`
+		} `json:"properties"`
+	}
+	if err := c.getJSON(ctx, forecastURL, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Properties.Periods, nil
+}
@@ -0,0 +1,363 @@
+// Package openmeteo is a small client for the Open-Meteo forecast, archive,
+// marine, ensemble, air quality and geocoding APIs.
+package openmeteo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const (
+	DefaultForecastURL   = "https://api.open-meteo.com/v1/forecast"
+	DefaultGeocodingURL  = "https://geocoding-api.open-meteo.com/v1/search"
+	DefaultArchiveURL    = "https://archive-api.open-meteo.com/v1/archive"
+	DefaultMarineURL     = "https://marine-api.open-meteo.com/v1/marine"
+	DefaultEnsembleURL   = "https://ensemble-api.open-meteo.com/v1/ensemble"
+	DefaultAirQualityURL = "https://air-quality-api.open-meteo.com/v1/air-quality"
+
+	DefaultTimeout = 10 * time.Second
+)
+
+type Client struct {
+	httpClient    *http.Client
+	cache         Cache
+	geoCache      Cache
+	gazetteer     Gazetteer
+	forecastURL   string
+	geocodingURL  string
+	archiveURL    string
+	marineURL     string
+	ensembleURL   string
+	airQualityURL string
+	language      string
+	apiKey        string
+	timeout       time.Duration
+	retries       int
+	retryWait     time.Duration
+	limiter       *limiter
+	logger        *slog.Logger
+}
+
+// Cache stores raw forecast responses keyed by request URL. Entries are
+// read and written as streams, so a large response is never held whole.
+// Failing to store an entry is not treated as a request error.
+type Cache interface {
+	// Open returns the entry for key, if there is a fresh one.
+	Open(key string) (io.ReadCloser, bool)
+	// Create starts a new entry for key, which replaces the old one only
+	// when committed.
+	Create(key string) (CacheEntry, error)
+}
+
+// CacheEntry is a cache entry being written.
+type CacheEntry interface {
+	io.Writer
+	Commit() error
+	Abort()
+}
+
+type Option func(*Client)
+
+// WithHTTPClient sets the HTTP client used for all requests. The client's
+// own timeout applies; WithTimeout is ignored.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithTimeout sets the overall timeout of each request made by the default
+// HTTP client.
+func WithTimeout(d time.Duration) Option {
+	return func(c *Client) {
+		c.timeout = d
+	}
+}
+
+// WithLogger logs requests with their latency, retries and cache hits to
+// logger. Nothing is logged by default.
+func WithLogger(logger *slog.Logger) Option {
+	return func(c *Client) {
+		c.logger = logger
+	}
+}
+
+// WithCache enables response caching for forecast requests.
+func WithCache(cache Cache) Option {
+	return func(c *Client) {
+		c.cache = cache
+	}
+}
+
+// WithGeocodingCache enables caching of geocoding searches, usually with a
+// much longer lifetime than forecasts.
+func WithGeocodingCache(cache Cache) Option {
+	return func(c *Client) {
+		c.geoCache = cache
+	}
+}
+
+// WithForecastURL overrides the forecast endpoint.
+func WithForecastURL(u string) Option {
+	return func(c *Client) {
+		c.forecastURL = u
+	}
+}
+
+// WithGeocodingURL overrides the geocoding search endpoint.
+func WithGeocodingURL(u string) Option {
+	return func(c *Client) {
+		c.geocodingURL = u
+	}
+}
+
+// WithArchiveURL overrides the historical weather endpoint.
+func WithArchiveURL(u string) Option {
+	return func(c *Client) {
+		c.archiveURL = u
+	}
+}
+
+// WithMarineURL overrides the marine forecast endpoint.
+func WithMarineURL(u string) Option {
+	return func(c *Client) {
+		c.marineURL = u
+	}
+}
+
+// WithAirQualityURL overrides the air quality and pollen endpoint.
+func WithAirQualityURL(u string) Option {
+	return func(c *Client) {
+		c.airQualityURL = u
+	}
+}
+
+// WithEnsembleURL overrides the ensemble forecast endpoint.
+func WithEnsembleURL(u string) Option {
+	return func(c *Client) {
+		c.ensembleURL = u
+	}
+}
+
+// customerURLs are the endpoints of the commercial plans, which take an
+// API key.
+var customerURLs = map[string]string{
+	DefaultForecastURL:   "https://customer-api.open-meteo.com/v1/forecast",
+	DefaultGeocodingURL:  "https://customer-geocoding-api.open-meteo.com/v1/search",
+	DefaultArchiveURL:    "https://customer-archive-api.open-meteo.com/v1/archive",
+	DefaultMarineURL:     "https://customer-marine-api.open-meteo.com/v1/marine",
+	DefaultEnsembleURL:   "https://customer-ensemble-api.open-meteo.com/v1/ensemble",
+	DefaultAirQualityURL: "https://customer-air-quality-api.open-meteo.com/v1/air-quality",
+}
+
+// WithAPIKey sends key with every request, and sends the requests to the
+// customer endpoints of the commercial plans. Endpoints overridden with
+// the other options are kept. The key is left out of cache keys and logs.
+func WithAPIKey(key string) Option {
+	return func(c *Client) {
+		c.apiKey = key
+	}
+}
+
+func NewClient(opts ...Option) *Client {
+	c := &Client{
+		timeout:       DefaultTimeout,
+		retries:       DefaultRetries,
+		retryWait:     DefaultRetryWait,
+		forecastURL:   DefaultForecastURL,
+		geocodingURL:  DefaultGeocodingURL,
+		archiveURL:    DefaultArchiveURL,
+		marineURL:     DefaultMarineURL,
+		ensembleURL:   DefaultEnsembleURL,
+		airQualityURL: DefaultAirQualityURL,
+		limiter:       newLimiter(DefaultMaxRPS),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.apiKey != "" {
+		for _, u := range []*string{&c.forecastURL, &c.geocodingURL, &c.archiveURL, &c.marineURL, &c.ensembleURL, &c.airQualityURL} {
+			if customer, ok := customerURLs[*u]; ok {
+				*u = customer
+			}
+		}
+	}
+	if c.httpClient == nil {
+		c.httpClient = &http.Client{Timeout: c.timeout}
+	}
+	if c.logger == nil {
+		c.logger = slog.New(slog.DiscardHandler)
+	}
+	return c
+}
+
+// maxErrorBody caps how much of a failed response is read for its reason.
+const maxErrorBody = 64 << 10
+
+// get fetches requestURL and returns the body of the response for the caller
+// to read and close. Network errors and 429/5xx responses are retried with
+// exponential backoff, or after the wait the API asks for with Retry-After.
+// Other non-2xx responses, and waits longer than maxRetryAfter, fail right
+// away with an *APIError.
+func (c *Client) get(ctx context.Context, requestURL string) (io.ReadCloser, error) {
+	for attempt := 0; ; attempt++ {
+		response, err := c.do(ctx, requestURL)
+		var (
+			status int
+			header http.Header
+			data   []byte
+		)
+		if err == nil {
+			if response.StatusCode >= 200 && response.StatusCode < 300 {
+				return response.Body, nil
+			}
+			status, header = response.StatusCode, response.Header
+			data, _ = io.ReadAll(io.LimitReader(response.Body, maxErrorBody))
+			response.Body.Close()
+			if !retryable(status) {
+				return nil, newAPIError(status, data, 0)
+			}
+		}
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		after := retryAfter(header, time.Now())
+		if attempt >= c.retries || after > maxRetryAfter {
+			if err != nil {
+				return nil, fmt.Errorf("%w: %w", ErrAPIUnavailable, err)
+			}
+			return nil, newAPIError(status, data, after)
+		}
+		wait := c.backoff(attempt)
+		if after > 0 {
+			wait = after
+		}
+		c.logger.Info("retrying request", "url", requestURL, "attempt", attempt+1, "wait", wait)
+		if err := sleep(ctx, wait); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (c *Client) do(ctx context.Context, requestURL string) (*http.Response, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	if c.apiKey != "" {
+		query := request.URL.Query()
+		query.Set("apikey", c.apiKey)
+		request.URL.RawQuery = query.Encode()
+	}
+	if err := c.limiter.wait(ctx); err != nil {
+		return nil, err
+	}
+	start := time.Now()
+	response, err := c.httpClient.Do(request)
+	if err != nil {
+		// The error repeats the URL, which must not give the key away.
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			urlErr.URL = requestURL
+		}
+		c.logger.Info("request failed", "url", requestURL, "duration", time.Since(start), "error", err)
+		return nil, err
+	}
+	c.logger.Info("request", "url", requestURL, "status", response.StatusCode, "duration", time.Since(start))
+	return response, nil
+}
+
+// fetchJSON decodes the response to requestURL into v as it arrives, rather
+// than holding the whole body first: hourly and archive responses over many
+// days run to megabytes. The raw body is copied to raw unless it is nil.
+func (c *Client) fetchJSON(ctx context.Context, endpoint, requestURL string, v any, raw io.Writer) error {
+	body, err := c.get(ctx, requestURL)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	counter := &countingReader{r: body}
+	var r io.Reader = counter
+	if raw != nil {
+		r = io.TeeReader(counter, raw)
+	}
+	if err := json.NewDecoder(r).Decode(v); err != nil {
+		return fmt.Errorf("decoding response from %s: %w", endpoint, err)
+	}
+	// Draining what follows the value lets the connection be reused.
+	io.Copy(io.Discard, r)
+	c.logger.Debug("response decoded", "url", requestURL, "bytes", counter.n)
+	return nil
+}
+
+// countingReader counts the bytes read through it.
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	r.n += int64(n)
+	return n, err
+}
+
+func (c *Client) getJSON(ctx context.Context, endpoint string, query url.Values, v any) error {
+	return c.fetchJSON(ctx, endpoint, endpoint+"?"+query.Encode(), v, nil)
+}
+
+// getCachedJSON is getJSON through cache. valid, unless nil, checks v once
+// it is decoded; a response it rejects is neither returned nor cached, so
+// that it is fetched again next time. cached reports whether v came from
+// the cache.
+func (c *Client) getCachedJSON(ctx context.Context, cache Cache, endpoint string, query url.Values, v any, valid func() error) (cached bool, err error) {
+	if valid == nil {
+		valid = func() error { return nil }
+	}
+	if cache == nil {
+		if err := c.getJSON(ctx, endpoint, query, v); err != nil {
+			return false, err
+		}
+		return false, valid()
+	}
+
+	requestURL := endpoint + "?" + query.Encode()
+	if r, ok := cache.Open(requestURL); ok {
+		err := json.NewDecoder(r).Decode(v)
+		r.Close()
+		if err == nil && valid() == nil {
+			c.logger.Debug("cache hit", "url", requestURL)
+			return true, nil
+		}
+	}
+	c.logger.Debug("cache miss", "url", requestURL)
+
+	entry, err := cache.Create(requestURL)
+	if err != nil {
+		c.logger.Debug("not caching", "url", requestURL, "error", err)
+		if err := c.getJSON(ctx, endpoint, query, v); err != nil {
+			return false, err
+		}
+		return false, valid()
+	}
+	if err := c.fetchJSON(ctx, endpoint, requestURL, v, entry); err != nil {
+		entry.Abort()
+		return false, err
+	}
+	if err := valid(); err != nil {
+		entry.Abort()
+		return false, err
+	}
+	if err := entry.Commit(); err != nil {
+		c.logger.Debug("not caching", "url", requestURL, "error", err)
+	}
+	return false, nil
+}
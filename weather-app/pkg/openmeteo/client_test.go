@@ -0,0 +1,155 @@
+package openmeteo_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"weather-app/pkg/openmeteo"
+	"weather-app/pkg/openmeteo/openmeteotest"
+)
+
+// mapCache is an in-memory openmeteo.Cache.
+type mapCache map[string][]byte
+
+func (c mapCache) Open(key string) (io.ReadCloser, bool) {
+	data, ok := c[key]
+	return io.NopCloser(bytes.NewReader(data)), ok
+}
+
+func (c mapCache) Create(key string) (openmeteo.CacheEntry, error) {
+	return &mapEntry{cache: c, key: key}, nil
+}
+
+type mapEntry struct {
+	bytes.Buffer
+	cache mapCache
+	key   string
+}
+
+func (e *mapEntry) Commit() error {
+	e.cache[e.key] = e.Bytes()
+	return nil
+}
+
+func (e *mapEntry) Abort() {}
+
+func TestInvalidResponseNotCached(t *testing.T) {
+	tr := openmeteotest.NewTransport()
+	tr.Handle(openmeteo.DefaultForecastURL, http.StatusOK, []byte(`{"daily":{"time":["2024-06-03"],"temperature_max_2m":[18]}}`))
+	cache := mapCache{}
+	c := openmeteo.NewClient(openmeteo.WithHTTPClient(tr.Client()), openmeteo.WithCache(cache))
+	req := openmeteo.ForecastRequest{Daily: []string{"temperature_2m_max"}}
+
+	for range 2 {
+		if _, err := c.Forecast(context.Background(), req); !errors.Is(err, openmeteo.ErrSchemaChanged) {
+			t.Fatalf("got %v, want ErrSchemaChanged", err)
+		}
+	}
+	if len(cache) != 0 {
+		t.Errorf("cached %d invalid responses", len(cache))
+	}
+	if n := len(tr.Requests()); n != 2 {
+		t.Errorf("made %d requests, want 2", n)
+	}
+}
+
+func TestResponseStreamedToCache(t *testing.T) {
+	body := []byte(`{"daily":{"time":["2024-06-03"],"temperature_2m_max":[18]}}`)
+	tr := openmeteotest.NewTransport()
+	tr.Handle(openmeteo.DefaultForecastURL, http.StatusOK, body)
+	cache := mapCache{}
+	c := openmeteo.NewClient(openmeteo.WithHTTPClient(tr.Client()), openmeteo.WithCache(cache))
+	req := openmeteo.ForecastRequest{Daily: []string{"temperature_2m_max"}}
+
+	for i := range 2 {
+		resp, err := c.Forecast(context.Background(), req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := *resp.Daily.TemperatureMax[0]; got != 18 {
+			t.Errorf("got a high of %g, want 18", got)
+		}
+		if want := i > 0; resp.Cached != want {
+			t.Errorf("request %d: Cached = %t, want %t", i+1, resp.Cached, want)
+		}
+	}
+	if n := len(tr.Requests()); n != 1 {
+		t.Errorf("made %d requests, want 1", n)
+	}
+	for _, data := range cache {
+		if !bytes.Equal(data, body) {
+			t.Errorf("cached %s, want the response as sent", data)
+		}
+	}
+}
+
+func TestCorruptCacheEntryRefetched(t *testing.T) {
+	tr := openmeteotest.NewTransport()
+	tr.Handle(openmeteo.DefaultForecastURL, http.StatusOK, []byte(`{"daily":{"time":["2024-06-03"],"temperature_2m_max":[18]}}`))
+	cache := mapCache{}
+	c := openmeteo.NewClient(openmeteo.WithHTTPClient(tr.Client()), openmeteo.WithCache(cache))
+	req := openmeteo.ForecastRequest{Daily: []string{"temperature_2m_max"}}
+	if _, err := c.Forecast(context.Background(), req); err != nil {
+		t.Fatal(err)
+	}
+	for key := range cache {
+		cache[key] = []byte(`{"daily":{"time":["2024-06`)
+	}
+
+	resp, err := c.Forecast(context.Background(), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := *resp.Daily.TemperatureMax[0]; got != 18 {
+		t.Errorf("got a high of %g, want 18", got)
+	}
+	if n := len(tr.Requests()); n != 2 {
+		t.Errorf("made %d requests, want the corrupt entry fetched again", n)
+	}
+}
+
+func TestAPIErrors(t *testing.T) {
+	tr := openmeteotest.NewTransport()
+	tr.Handle(openmeteo.DefaultForecastURL, http.StatusBadRequest, []byte(`{"error":true,"reason":"Cannot initialize WeatherVariable from invalid String value foo"}`))
+	tr.Handle(openmeteo.DefaultArchiveURL, http.StatusServiceUnavailable, []byte(`{"error":true,"reason":"Too busy"}`))
+	c := openmeteo.NewClient(openmeteo.WithHTTPClient(tr.Client()), openmeteo.WithRetries(1, 0))
+
+	_, err := c.Forecast(context.Background(), openmeteo.ForecastRequest{Daily: []string{"foo"}})
+	var apiErr *openmeteo.APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadRequest || !strings.Contains(apiErr.Reason, "invalid String value foo") {
+		t.Errorf("got %v, want a 400 APIError with the reason from the body", err)
+	}
+	if errors.Is(err, openmeteo.ErrAPIUnavailable) {
+		t.Error("a 400 response should not count as the API being unavailable")
+	}
+
+	_, err = c.Archive(context.Background(), openmeteo.ForecastRequest{})
+	if !errors.Is(err, openmeteo.ErrAPIUnavailable) {
+		t.Errorf("got %v, want ErrAPIUnavailable", err)
+	}
+	if n := len(tr.Requests()); n != 3 {
+		t.Errorf("got %d requests, want 3 (one forecast, two archive attempts)", n)
+	}
+}
+
+func TestSchemaChanged(t *testing.T) {
+	tr := openmeteotest.NewTransport()
+	if err := tr.HandleFile(openmeteo.DefaultForecastURL, "testdata/daily_renamed.json"); err != nil {
+		t.Fatal(err)
+	}
+	c := openmeteo.NewClient(openmeteo.WithHTTPClient(tr.Client()))
+
+	_, err := c.Forecast(context.Background(), openmeteo.ForecastRequest{Daily: []string{"temperature_2m_max", "temperature_2m_min", "weathercode"}})
+	var schemaErr *openmeteo.SchemaError
+	if !errors.As(err, &schemaErr) {
+		t.Fatalf("Forecast error = %v, want a SchemaError", err)
+	}
+	if schemaErr.Field != "daily.temperature_2m_max" {
+		t.Errorf("SchemaError.Field = %q, want daily.temperature_2m_max", schemaErr.Field)
+	}
+}
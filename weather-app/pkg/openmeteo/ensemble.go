@@ -0,0 +1,61 @@
+package openmeteo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// EnsembleResponse holds the daily values of every member of an ensemble
+// forecast.
+type EnsembleResponse struct {
+	Latitude         float64       `json:"latitude"`
+	Longitude        float64       `json:"longitude"`
+	Timezone         string        `json:"timezone"`
+	UTCOffsetSeconds int           `json:"utc_offset_seconds"`
+	Daily            *EnsembleData `json:"daily"`
+}
+
+// EnsembleData maps each requested variable to one series per member, the
+// control run first. The API names the members "temperature_2m_max",
+// "temperature_2m_max_member01" and so on.
+type EnsembleData struct {
+	Time    []string
+	Members map[string][][]*float64
+}
+
+func (d *EnsembleData) UnmarshalJSON(data []byte) error {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	d.Members = map[string][][]*float64{}
+	for _, key := range slices.Sorted(maps.Keys(raw)) {
+		if key == "time" {
+			if err := json.Unmarshal(raw[key], &d.Time); err != nil {
+				return err
+			}
+			continue
+		}
+		var series []*float64
+		if err := json.Unmarshal(raw[key], &series); err != nil {
+			return fmt.Errorf("decoding %s: %w", key, err)
+		}
+		name, _, _ := strings.Cut(key, "_member")
+		d.Members[name] = append(d.Members[name], series)
+	}
+	return nil
+}
+
+// Ensemble fetches the daily variables of every member of the ensemble
+// models in req.
+func (c *Client) Ensemble(ctx context.Context, req ForecastRequest) (*EnsembleResponse, error) {
+	var resp EnsembleResponse
+	if _, err := c.getCachedJSON(ctx, c.cache, c.ensembleURL, req.query(), &resp, nil); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
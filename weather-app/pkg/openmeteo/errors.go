@@ -0,0 +1,110 @@
+package openmeteo
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrCityNotFound is returned when geocoding finds no match.
+	ErrCityNotFound = errors.New("city not found")
+	// ErrAPIUnavailable is returned when the API cannot be reached or keeps
+	// failing after all retries.
+	ErrAPIUnavailable = errors.New("Open-Meteo API unavailable")
+)
+
+// NotFoundError is a city geocoding found no match for in its country.
+// Suggestions are similar places that do exist, as "city, country", and
+// Elsewhere the search results in other countries, best match first.
+type NotFoundError struct {
+	Name        string
+	Country     string
+	Suggestions []string
+	Elsewhere   []GeocodingResult
+}
+
+func (e *NotFoundError) Error() string {
+	msg := fmt.Sprintf("Could not find a proper location match for %s of country %s", e.Name, e.Country)
+	if len(e.Suggestions) > 0 {
+		msg += "; did you mean " + orList(e.Suggestions) + "?"
+	}
+	return msg
+}
+
+func (e *NotFoundError) Unwrap() error {
+	return ErrCityNotFound
+}
+
+// orList quotes items as "'a', 'b' or 'c'".
+func orList(items []string) string {
+	quoted := make([]string, len(items))
+	for i, item := range items {
+		quoted[i] = "'" + item + "'"
+	}
+	if len(quoted) == 1 {
+		return quoted[0]
+	}
+	return strings.Join(quoted[:len(quoted)-1], ", ") + " or " + quoted[len(quoted)-1]
+}
+
+// APIError is a response with a non-2xx status. Reason is the explanation
+// from the API's error body, when it sent one, and RetryAfter the wait it
+// asked for before trying again.
+type APIError struct {
+	StatusCode int
+	Reason     string
+	RetryAfter time.Duration
+}
+
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("Open-Meteo API returned %d %s", e.StatusCode, http.StatusText(e.StatusCode))
+	if e.Reason != "" {
+		msg += ": " + e.Reason
+	}
+	if e.RetryAfter > 0 {
+		msg += fmt.Sprintf(" (retry after %s)", e.RetryAfter)
+	}
+	return msg
+}
+
+// Unwrap makes server-side failures match ErrAPIUnavailable. Client errors
+// such as an invalid variable name do not.
+func (e *APIError) Unwrap() error {
+	if retryable(e.StatusCode) {
+		return ErrAPIUnavailable
+	}
+	return nil
+}
+
+// newAPIError builds an APIError from a response. Open-Meteo reports
+// problems as {"error": true, "reason": "..."}.
+func newAPIError(status int, body []byte, retryAfter time.Duration) *APIError {
+	var payload struct {
+		Reason string `json:"reason"`
+	}
+	json.Unmarshal(body, &payload)
+	return &APIError{StatusCode: status, Reason: payload.Reason, RetryAfter: retryAfter}
+}
+
+// ErrSchemaChanged is matched by a SchemaError.
+var ErrSchemaChanged = errors.New("Open-Meteo API schema changed")
+
+// SchemaError is a response that decoded but does not have the shape the
+// client expects, most likely because the API renamed or changed a field.
+// Field is the JSON path of the field, e.g. "daily.temperature_2m_max".
+type SchemaError struct {
+	Field   string
+	Problem string
+}
+
+func (e *SchemaError) Error() string {
+	return fmt.Sprintf("%s: %s %s", ErrSchemaChanged, e.Field, e.Problem)
+}
+
+func (e *SchemaError) Unwrap() error {
+	return ErrSchemaChanged
+}
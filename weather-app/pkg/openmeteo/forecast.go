@@ -0,0 +1,226 @@
+package openmeteo
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	dateLayout     = "2006-01-02"
+	dateTimeLayout = "2006-01-02T15:04"
+)
+
+// ForecastRequest describes a call to the forecast, archive, marine,
+// ensemble or air quality endpoint.
+// Daily and Hourly list the Open-Meteo variable names to request. StartDate
+// and EndDate are YYYY-MM-DD and are required for archive requests. Models
+// names the weather models to use; empty leaves the choice to the API.
+type ForecastRequest struct {
+	Latitude        float64
+	Longitude       float64
+	Daily           []string
+	Hourly          []string
+	ForecastHours   int
+	ForecastDays    int
+	PastDays        int
+	CurrentWeather  bool
+	StartDate       string
+	EndDate         string
+	TemperatureUnit string
+	WindSpeedUnit   string
+	Timezone        string
+	Models          []string
+	// Elevation in metres replaces the elevation of the grid cell the
+	// values are downscaled to, when set.
+	Elevation *float64
+}
+
+type ForecastResponse struct {
+	Latitude         float64         `json:"latitude"`
+	Longitude        float64         `json:"longitude"`
+	Timezone         string          `json:"timezone"`
+	Elevation        *float64        `json:"elevation"`
+	UTCOffsetSeconds int             `json:"utc_offset_seconds"`
+	CurrentWeather   *CurrentWeather `json:"current_weather"`
+	Daily            *DailyData      `json:"daily"`
+	Hourly           *HourlyData     `json:"hourly"`
+	// DailyUnits and HourlyUnits give the unit of each variable, e.g.
+	// "°C" for temperature_2m_max.
+	DailyUnits  map[string]string `json:"daily_units"`
+	HourlyUnits map[string]string `json:"hourly_units"`
+	// Cached is set when the response came from the client's cache
+	// rather than the network.
+	Cached bool `json:"-"`
+}
+
+type CurrentWeather struct {
+	Time          string  `json:"time"`
+	Temperature   float64 `json:"temperature"`
+	WindSpeed     float64 `json:"windspeed"`
+	WindDirection float64 `json:"winddirection"`
+	WeatherCode   int     `json:"weathercode"`
+	IsDay         int     `json:"is_day"`
+}
+
+// DailyData holds one array per variable, indexed like Time. Values are
+// null where the model has none, e.g. for the last days of its range, and
+// arrays may be shorter than Time, so the nulls are kept.
+type DailyData struct {
+	Time             []string   `json:"time"`
+	TemperatureMax   []*float64 `json:"temperature_2m_max"`
+	TemperatureMin   []*float64 `json:"temperature_2m_min"`
+	ApparentMax      []*float64 `json:"apparent_temperature_max"`
+	ApparentMin      []*float64 `json:"apparent_temperature_min"`
+	UVIndexMax       []*float64 `json:"uv_index_max"`
+	Sunrise          []*string  `json:"sunrise"`
+	Sunset           []*string  `json:"sunset"`
+	DaylightDuration []*float64 `json:"daylight_duration"`
+	SunshineDuration []*float64 `json:"sunshine_duration"`
+	PrecipitationSum []*float64 `json:"precipitation_sum"`
+	PrecipProbMax    []*float64 `json:"precipitation_probability_max"`
+	PrecipHours      []*float64 `json:"precipitation_hours"`
+	SnowfallSum      []*float64 `json:"snowfall_sum"`
+	ET0              []*float64 `json:"et0_fao_evapotranspiration"`
+	WeatherCode      []*int     `json:"weathercode"`
+
+	WindSpeedMax          []*float64 `json:"windspeed_10m_max"`
+	WindGustsMax          []*float64 `json:"windgusts_10m_max"`
+	WindDirectionDominant []*float64 `json:"winddirection_10m_dominant"`
+
+	WaveHeightMax              []*float64 `json:"wave_height_max"`
+	WavePeriodMax              []*float64 `json:"wave_period_max"`
+	WaveDirectionDominant      []*float64 `json:"wave_direction_dominant"`
+	SwellWaveHeightMax         []*float64 `json:"swell_wave_height_max"`
+	SwellWavePeriodMax         []*float64 `json:"swell_wave_period_max"`
+	SwellWaveDirectionDominant []*float64 `json:"swell_wave_direction_dominant"`
+}
+
+// HourlyData holds one array per variable, indexed like Time. Values are
+// null past the end of a model's range, so the nulls are kept; validate
+// rejects them anywhere else.
+type HourlyData struct {
+	Time                     []string   `json:"time"`
+	Temperature              []*float64 `json:"temperature_2m"`
+	Precipitation            []*float64 `json:"precipitation"`
+	PrecipitationProbability []*float64 `json:"precipitation_probability"`
+	WindSpeed                []*float64 `json:"wind_speed_10m"`
+	WindDirection            []*float64 `json:"wind_direction_10m"`
+	SnowDepth                []*float64 `json:"snow_depth"`
+	RelativeHumidity         []*float64 `json:"relative_humidity_2m"`
+	DewPoint                 []*float64 `json:"dew_point_2m"`
+	SurfacePressure          []*float64 `json:"surface_pressure"`
+	CloudCover               []*float64 `json:"cloud_cover"`
+	Visibility               []*float64 `json:"visibility"`
+	SoilTemperature6cm       []*float64 `json:"soil_temperature_6cm"`
+	SoilMoisture3To9cm       []*float64 `json:"soil_moisture_3_to_9cm"`
+
+	// Pollen in grains/m³ from the air quality endpoint, null outside
+	// Europe and out of season.
+	AlderPollen   []*float64 `json:"alder_pollen"`
+	BirchPollen   []*float64 `json:"birch_pollen"`
+	GrassPollen   []*float64 `json:"grass_pollen"`
+	MugwortPollen []*float64 `json:"mugwort_pollen"`
+	OlivePollen   []*float64 `json:"olive_pollen"`
+	RagweedPollen []*float64 `json:"ragweed_pollen"`
+}
+
+func (req ForecastRequest) query() url.Values {
+	query := url.Values{}
+	query.Set("latitude", strconv.FormatFloat(req.Latitude, 'f', -1, 64))
+	query.Set("longitude", strconv.FormatFloat(req.Longitude, 'f', -1, 64))
+	if req.Timezone == "" {
+		query.Set("timezone", "auto")
+	} else {
+		query.Set("timezone", req.Timezone)
+	}
+	if len(req.Daily) > 0 {
+		query.Set("daily", strings.Join(req.Daily, ","))
+	}
+	if len(req.Hourly) > 0 {
+		query.Set("hourly", strings.Join(req.Hourly, ","))
+	}
+	if req.ForecastHours > 0 {
+		query.Set("forecast_hours", strconv.Itoa(req.ForecastHours))
+	}
+	if req.ForecastDays > 0 {
+		query.Set("forecast_days", strconv.Itoa(req.ForecastDays))
+	}
+	if req.PastDays > 0 {
+		query.Set("past_days", strconv.Itoa(req.PastDays))
+	}
+	if req.CurrentWeather {
+		query.Set("current_weather", "true")
+	}
+	if req.StartDate != "" {
+		query.Set("start_date", req.StartDate)
+	}
+	if req.EndDate != "" {
+		query.Set("end_date", req.EndDate)
+	}
+	if req.TemperatureUnit != "" {
+		query.Set("temperature_unit", req.TemperatureUnit)
+	}
+	if req.WindSpeedUnit != "" {
+		query.Set("wind_speed_unit", req.WindSpeedUnit)
+	}
+	if len(req.Models) > 0 {
+		query.Set("models", strings.Join(req.Models, ","))
+	}
+	if req.Elevation != nil {
+		query.Set("elevation", strconv.FormatFloat(*req.Elevation, 'f', -1, 64))
+	}
+	return query
+}
+
+// Forecast fetches the requested daily and hourly variables.
+func (c *Client) Forecast(ctx context.Context, req ForecastRequest) (*ForecastResponse, error) {
+	return c.fetchForecast(ctx, c.forecastURL, req)
+}
+
+// Archive fetches historical weather for the date range in req.
+func (c *Client) Archive(ctx context.Context, req ForecastRequest) (*ForecastResponse, error) {
+	return c.fetchForecast(ctx, c.archiveURL, req)
+}
+
+// Marine fetches wave and swell variables from the marine endpoint.
+func (c *Client) Marine(ctx context.Context, req ForecastRequest) (*ForecastResponse, error) {
+	return c.fetchForecast(ctx, c.marineURL, req)
+}
+
+// AirQuality fetches air quality and pollen variables.
+func (c *Client) AirQuality(ctx context.Context, req ForecastRequest) (*ForecastResponse, error) {
+	return c.fetchForecast(ctx, c.airQualityURL, req)
+}
+
+// fetchForecast fetches req from endpoint and checks the response has the
+// shape req asks for before it is cached.
+func (c *Client) fetchForecast(ctx context.Context, endpoint string, req ForecastRequest) (*ForecastResponse, error) {
+	var resp ForecastResponse
+	valid := func() error { return resp.validate(req) }
+	cached, err := c.getCachedJSON(ctx, c.cache, endpoint, req.query(), &resp, valid)
+	if err != nil {
+		return nil, err
+	}
+	resp.Cached = cached
+	return &resp, nil
+}
+
+// TimeLocation returns the time zone the response timestamps are expressed in.
+func (r *ForecastResponse) TimeLocation() *time.Location {
+	if loc, err := time.LoadLocation(r.Timezone); err == nil {
+		return loc
+	}
+	return time.FixedZone(r.Timezone, r.UTCOffsetSeconds)
+}
+
+// ParseTime parses a date or local date-time value from the response.
+func (r *ForecastResponse) ParseTime(s string) (time.Time, error) {
+	layout := dateTimeLayout
+	if len(s) == len(dateLayout) {
+		layout = dateLayout
+	}
+	return time.ParseInLocation(layout, s, r.TimeLocation())
+}
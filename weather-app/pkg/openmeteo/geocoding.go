@@ -0,0 +1,211 @@
+package openmeteo
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"weather-app/pkg/iso3166"
+)
+
+type GeocodingRequest struct {
+	Name     string
+	Count    int
+	Language string
+	// CountryCode limits the results to an ISO 3166-1 alpha-2 country.
+	CountryCode string
+}
+
+type GeocodingResult struct {
+	Name        string   `json:"name"`
+	Latitude    float64  `json:"latitude"`
+	Longitude   float64  `json:"longitude"`
+	Country     string   `json:"country"`
+	CountryCode string   `json:"country_code"`
+	Admin1      string   `json:"admin1"`
+	Population  int      `json:"population"`
+	Timezone    string   `json:"timezone"`
+	Postcodes   []string `json:"postcodes"`
+}
+
+// Gazetteer answers lookups locally. An empty result makes the client fall
+// back to the geocoding API.
+type Gazetteer interface {
+	Lookup(name, country string) []GeocodingResult
+}
+
+// WithGazetteer consults g before searching the geocoding API.
+func WithGazetteer(g Gazetteer) Option {
+	return func(c *Client) {
+		c.gazetteer = g
+	}
+}
+
+// WithLanguage sets the language of place and country names returned by
+// geocoding searches that don't ask for one, e.g. "de". The default is
+// English.
+func WithLanguage(lang string) Option {
+	return func(c *Client) {
+		c.language = lang
+	}
+}
+
+type geocodingResponse struct {
+	Results []GeocodingResult `json:"results"`
+}
+
+// Search returns all places matching the requested name.
+func (c *Client) Search(ctx context.Context, req GeocodingRequest) ([]GeocodingResult, error) {
+	if req.Count == 0 {
+		req.Count = 10
+	}
+	if req.Language == "" {
+		req.Language = c.language
+	}
+	if req.Language == "" {
+		req.Language = "en"
+	}
+
+	query := url.Values{}
+	query.Set("name", req.Name)
+	query.Set("count", strconv.Itoa(req.Count))
+	query.Set("language", req.Language)
+	query.Set("format", "json")
+	if req.CountryCode != "" {
+		query.Set("countryCode", req.CountryCode)
+	}
+
+	var resp geocodingResponse
+	if _, err := c.getCachedJSON(ctx, c.geoCache, c.geocodingURL, query, &resp, nil); err != nil {
+		return nil, err
+	}
+	return resp.Results, nil
+}
+
+// FindCities returns every search result for name located in country, in
+// the order ranked by the API. Gazetteer matches, which are in English, are
+// returned without a search unless another language was chosen.
+func (c *Client) FindCities(ctx context.Context, name, country string) ([]GeocodingResult, error) {
+	english := c.language == "" || c.language == "en"
+	if c.gazetteer != nil && english {
+		if matches := c.gazetteer.Lookup(name, country); len(matches) > 0 {
+			c.logger.Debug("gazetteer hit", "city", name, "country", country)
+			return matches, nil
+		}
+	}
+
+	results, err := c.Search(ctx, GeocodingRequest{Name: name})
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []GeocodingResult
+	for _, result := range results {
+		if result.InCountry(country) {
+			matches = append(matches, result)
+		}
+	}
+	if len(matches) == 0 && !english {
+		matches, err = c.matchEnglishCountry(ctx, name, country, results)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(matches) == 0 {
+		return nil, &NotFoundError{
+			Name:        name,
+			Country:     country,
+			Suggestions: c.suggest(ctx, name, country, results),
+			Elsewhere:   results,
+		}
+	}
+	return matches, nil
+}
+
+// matchEnglishCountry picks the localized results in country when country
+// is its English name, as it usually is in flags and config files. The
+// English search tells which country codes it stands for.
+func (c *Client) matchEnglishCountry(ctx context.Context, name, country string, localized []GeocodingResult) ([]GeocodingResult, error) {
+	results, err := c.Search(ctx, GeocodingRequest{Name: name, Language: "en"})
+	if err != nil {
+		return nil, err
+	}
+	codes := map[string]bool{}
+	for _, result := range results {
+		if result.InCountry(country) {
+			codes[result.CountryCode] = true
+		}
+	}
+	var matches []GeocodingResult
+	for _, result := range localized {
+		if codes[result.CountryCode] {
+			matches = append(matches, result)
+		}
+	}
+	return matches, nil
+}
+
+// FindCity returns the first search result for name located in country.
+func (c *Client) FindCity(ctx context.Context, name, country string) (GeocodingResult, error) {
+	matches, err := c.FindCities(ctx, name, country)
+	if err != nil {
+		return GeocodingResult{}, err
+	}
+	return matches[0], nil
+}
+
+// InCountry reports whether r lies in country, given as a name, an ISO
+// 3166-1 alpha-2 or alpha-3 code, or a common other name such as
+// "Holland". Names missing from the ISO table are compared as they are.
+func (r GeocodingResult) InCountry(country string) bool {
+	if c, ok := iso3166.Lookup(country); ok && r.CountryCode != "" {
+		return strings.EqualFold(r.CountryCode, c.Alpha2)
+	}
+	return strings.EqualFold(r.Country, country) || strings.EqualFold(r.CountryCode, country)
+}
+
+// outwardCode shortens a full postcode to the area part the geocoding API
+// knows: "2511CV" to "2511" and "SW1A 1AA" to "SW1A". It returns "" when
+// code has no shorter form.
+func outwardCode(code string) string {
+	if before, _, ok := strings.Cut(code, " "); ok {
+		return before
+	}
+	digits := strings.TrimRightFunc(code, unicode.IsLetter)
+	if digits != code && digits != "" && strings.IndexFunc(digits, unicode.IsLetter) < 0 {
+		return digits
+	}
+	return ""
+}
+
+// FindPostcode returns the places with postal code code in country, given
+// as a name or an ISO 3166-1 code. Full postcodes that the API does
+// not know, such as Dutch ones with letters, are looked up by their area.
+func (c *Client) FindPostcode(ctx context.Context, code, country string) ([]GeocodingResult, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	req := GeocodingRequest{Name: code, CountryCode: iso3166.Code(country)}
+
+	for {
+		results, err := c.Search(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		var matches []GeocodingResult
+		for _, result := range results {
+			if result.InCountry(country) {
+				matches = append(matches, result)
+			}
+		}
+		if len(matches) > 0 {
+			return matches, nil
+		}
+		if req.Name = outwardCode(req.Name); req.Name == "" {
+			break
+		}
+	}
+	return nil, fmt.Errorf("Could not find postal code %s in %s: %w", code, country, ErrCityNotFound)
+}
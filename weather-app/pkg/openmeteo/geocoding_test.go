@@ -0,0 +1,46 @@
+package openmeteo_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"weather-app/pkg/openmeteo"
+	"weather-app/pkg/openmeteo/openmeteotest"
+)
+
+func TestFindCitiesFiltersByCountry(t *testing.T) {
+	tr := openmeteotest.NewTransport()
+	if err := tr.HandleFile(openmeteo.DefaultGeocodingURL, "testdata/geocoding.json"); err != nil {
+		t.Fatal(err)
+	}
+	c := openmeteo.NewClient(openmeteo.WithHTTPClient(tr.Client()))
+
+	matches, err := c.FindCities(context.Background(), "The Hague", "United States")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(matches) != 1 || matches[0].Admin1 != "Pennsylvania" {
+		t.Errorf("got %+v, want the Pennsylvania match only", matches)
+	}
+	for _, country := range []string{"NL", "nld", "Holland"} {
+		matches, err := c.FindCities(context.Background(), "The Hague", country)
+		if err != nil || len(matches) != 1 || matches[0].CountryCode != "NL" {
+			t.Errorf("%s: got %+v, %v, want the Dutch match only", country, matches, err)
+		}
+	}
+
+	_, err = c.FindCities(context.Background(), "The Hague", "France")
+	if !errors.Is(err, openmeteo.ErrCityNotFound) {
+		t.Errorf("got %v, want ErrCityNotFound for a country without matches", err)
+	}
+	var nf *openmeteo.NotFoundError
+	want := []string{"The Hague, Netherlands", "The Hague, United States"}
+	if !errors.As(err, &nf) || !slices.Equal(nf.Suggestions, want) {
+		t.Fatalf("got %v, want suggestions %q", err, want)
+	}
+	if len(nf.Elsewhere) != 2 || nf.Elsewhere[0].Country != "Netherlands" {
+		t.Errorf("got %+v, want both matches outside France, best first", nf.Elsewhere)
+	}
+}
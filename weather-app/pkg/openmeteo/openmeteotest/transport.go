@@ -0,0 +1,80 @@
+// Package openmeteotest provides a fake HTTP transport serving canned
+// Open-Meteo responses, so code using the client can be tested offline.
+package openmeteotest
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+)
+
+type response struct {
+	status int
+	body   []byte
+}
+
+// Transport is an http.RoundTripper that answers requests by endpoint URL
+// (scheme, host and path, ignoring the query) and records every request.
+type Transport struct {
+	mu        sync.Mutex
+	responses map[string]response
+	requests  []*http.Request
+}
+
+func NewTransport() *Transport {
+	return &Transport{responses: map[string]response{}}
+}
+
+// Handle registers the response for endpoint, e.g. openmeteo.DefaultForecastURL.
+func (t *Transport) Handle(endpoint string, status int, body []byte) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.responses[endpoint] = response{status: status, body: body}
+}
+
+// HandleFile registers the contents of path as a 200 response for endpoint.
+func (t *Transport) HandleFile(endpoint, path string) error {
+	body, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	t.Handle(endpoint, http.StatusOK, body)
+	return nil
+}
+
+// Requests returns the requests made so far.
+func (t *Transport) Requests() []*http.Request {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]*http.Request(nil), t.requests...)
+}
+
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	endpoint := req.URL.Scheme + "://" + req.URL.Host + req.URL.Path
+
+	t.mu.Lock()
+	t.requests = append(t.requests, req)
+	resp, ok := t.responses[endpoint]
+	t.mu.Unlock()
+
+	if !ok {
+		resp = response{
+			status: http.StatusNotFound,
+			body:   []byte(`{"error":true,"reason":"no fake response for ` + endpoint + `"}`),
+		}
+	}
+	return &http.Response{
+		StatusCode: resp.status,
+		Status:     http.StatusText(resp.status),
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(bytes.NewReader(resp.body)),
+		Request:    req,
+	}, nil
+}
+
+// Client returns an *http.Client using t, for openmeteo.WithHTTPClient.
+func (t *Transport) Client() *http.Client {
+	return &http.Client{Transport: t}
+}
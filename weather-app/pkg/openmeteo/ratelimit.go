@@ -0,0 +1,86 @@
+package openmeteo
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// DefaultMaxRPS keeps well within the limits of the free Open-Meteo API,
+// 600 requests a minute.
+const DefaultMaxRPS = 5
+
+// WithRateLimit allows at most rps requests a second across all endpoints,
+// with bursts of up to rps requests. Zero or less disables the limit.
+func WithRateLimit(rps float64) Option {
+	return func(c *Client) {
+		c.limiter = newLimiter(rps)
+	}
+}
+
+// limiter is a token bucket: tokens refill at rate a second up to burst,
+// and every request takes one, waiting for it when the bucket is empty.
+type limiter struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newLimiter(rps float64) *limiter {
+	if rps <= 0 {
+		return nil
+	}
+	burst := math.Max(1, math.Floor(rps))
+	return &limiter{rate: rps, burst: burst, tokens: burst}
+}
+
+// reserve takes a token and returns how long to wait until it is available.
+func (l *limiter) reserve(now time.Time) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if !l.last.IsZero() {
+		l.tokens = math.Min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	}
+	l.last = now
+	l.tokens--
+	if l.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-l.tokens / l.rate * float64(time.Second))
+}
+
+// wait blocks until a request may be made. A nil limiter never waits.
+func (l *limiter) wait(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+	if d := l.reserve(time.Now()); d > 0 {
+		return sleep(ctx, d)
+	}
+	return nil
+}
+
+// maxRetryAfter is the longest Retry-After waited for. Longer waits, such
+// as for an exhausted daily quota, fail the request instead.
+const maxRetryAfter = time.Minute
+
+// retryAfter parses a Retry-After header, given in seconds or as an HTTP
+// date. It returns 0 when the header is missing or invalid.
+func retryAfter(h http.Header, now time.Time) time.Duration {
+	v := h.Get("Retry-After")
+	if v == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		return max(time.Duration(secs)*time.Second, 0)
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		return max(t.Sub(now), 0)
+	}
+	return 0
+}
@@ -0,0 +1,135 @@
+package openmeteo
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"weather-app/pkg/openmeteo/openmeteotest"
+)
+
+func TestLimiterRefill(t *testing.T) {
+	start := time.Date(2024, 6, 3, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		rps  float64
+		// after are the offsets of the requests from start.
+		after []time.Duration
+		want  []time.Duration
+	}{
+		{
+			name:  "burst then wait",
+			rps:   2,
+			after: []time.Duration{0, 0, 0},
+			want:  []time.Duration{0, 0, 500 * time.Millisecond},
+		},
+		{
+			name:  "waits queue up",
+			rps:   2,
+			after: []time.Duration{0, 0, 0, 0},
+			want:  []time.Duration{0, 0, 500 * time.Millisecond, time.Second},
+		},
+		{
+			name:  "refills over time",
+			rps:   2,
+			after: []time.Duration{0, 0, time.Second, time.Second},
+			want:  []time.Duration{0, 0, 0, 0},
+		},
+		{
+			name:  "refill is capped at the burst",
+			rps:   2,
+			after: []time.Duration{0, time.Minute, time.Minute, time.Minute},
+			want:  []time.Duration{0, 0, 0, 500 * time.Millisecond},
+		},
+		{
+			name:  "below one a second",
+			rps:   0.5,
+			after: []time.Duration{0, 0, 3 * time.Second},
+			want:  []time.Duration{0, 2 * time.Second, time.Second},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := newLimiter(tt.rps)
+			for i, after := range tt.after {
+				if got := l.reserve(start.Add(after)); got != tt.want[i] {
+					t.Errorf("request %d: wait %v, want %v", i+1, got, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestNoLimit(t *testing.T) {
+	for _, rps := range []float64{0, -1} {
+		if l := newLimiter(rps); l != nil {
+			t.Errorf("newLimiter(%g) = %+v, want no limit", rps, l)
+		}
+	}
+}
+
+func TestLimiterWaitHonoursContext(t *testing.T) {
+	tr := openmeteotest.NewTransport()
+	tr.Handle(DefaultForecastURL, http.StatusOK, []byte(`{}`))
+	// One request every ten minutes: the second would wait far longer
+	// than the test.
+	c := NewClient(WithHTTPClient(tr.Client()), WithRateLimit(1.0/600))
+
+	tests := []struct {
+		name    string
+		timeout time.Duration
+		want    error
+	}{
+		{"first request", time.Minute, nil},
+		{"waiting request", 10 * time.Millisecond, context.DeadlineExceeded},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), tt.timeout)
+			defer cancel()
+			start := time.Now()
+			_, err := c.Forecast(ctx, ForecastRequest{})
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("got %v, want %v", err, tt.want)
+			}
+			if elapsed := time.Since(start); elapsed > 5*time.Second {
+				t.Errorf("returned after %v, want at the deadline", elapsed)
+			}
+		})
+	}
+	if n := len(tr.Requests()); n != 1 {
+		t.Errorf("made %d requests, want 1", n)
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2024, 6, 3, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name   string
+		header string
+		want   time.Duration
+	}{
+		{"missing", "", 0},
+		{"seconds", "30", 30 * time.Second},
+		{"zero seconds", "0", 0},
+		{"negative seconds", "-5", 0},
+		{"HTTP date", "Mon, 03 Jun 2024 12:01:30 GMT", 90 * time.Second},
+		{"HTTP date in the past", "Mon, 03 Jun 2024 11:59:00 GMT", 0},
+		{"RFC 850 date", "Monday, 03-Jun-24 12:00:10 GMT", 10 * time.Second},
+		{"invalid", "soon", 0},
+		{"fractional seconds", "1.5", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := http.Header{}
+			if tt.header != "" {
+				h.Set("Retry-After", tt.header)
+			}
+			if got := retryAfter(h, now); got != tt.want {
+				t.Errorf("retryAfter(%q) = %v, want %v", tt.header, got, tt.want)
+			}
+		})
+	}
+}
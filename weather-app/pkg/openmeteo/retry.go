@@ -0,0 +1,48 @@
+package openmeteo
+
+import (
+	"context"
+	"math/rand/v2"
+	"net/http"
+	"time"
+)
+
+const (
+	DefaultRetries   = 2
+	DefaultRetryWait = 500 * time.Millisecond
+)
+
+// WithRetries sets how many times a failed request is retried and the base
+// wait before the first retry. The wait doubles on every further attempt.
+func WithRetries(retries int, wait time.Duration) Option {
+	return func(c *Client) {
+		c.retries = retries
+		c.retryWait = wait
+	}
+}
+
+// retryable reports whether a response status is worth retrying.
+func retryable(status int) bool {
+	return status == http.StatusTooManyRequests || status >= 500
+}
+
+// backoff returns the wait before retry number attempt (starting at 0):
+// exponential growth with up to 50% jitter.
+func (c *Client) backoff(attempt int) time.Duration {
+	d := c.retryWait << attempt
+	if d <= 0 {
+		return 0
+	}
+	return d/2 + rand.N(d/2+1)
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,73 @@
+package openmeteo
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// validate checks that resp has what req asked for: a time array for
+// daily and hourly variables, no nulls in it, and every requested variable
+// the client decodes present, with a unit when the response lists units.
+// Arrays may be shorter than time or hold nulls, but not be longer: nulls
+// are values a model does not have, past its range or at all, and are left
+// to the caller.
+func (resp *ForecastResponse) validate(req ForecastRequest) error {
+	if len(req.Daily) > 0 {
+		if err := validateBlock("daily", resp.Daily, resp.DailyUnits, req.Daily); err != nil {
+			return err
+		}
+	}
+	if len(req.Hourly) > 0 {
+		if err := validateBlock("hourly", resp.Hourly, resp.HourlyUnits, req.Hourly); err != nil {
+			return err
+		}
+	}
+	if req.CurrentWeather && resp.CurrentWeather == nil {
+		return &SchemaError{Field: "current_weather", Problem: "is missing"}
+	}
+	return nil
+}
+
+// validateBlock validates data, a *DailyData or *HourlyData, against the
+// variables requested in it.
+func validateBlock(name string, data any, units map[string]string, variables []string) error {
+	v := reflect.ValueOf(data)
+	if v.IsNil() {
+		return &SchemaError{Field: name, Problem: "is missing"}
+	}
+	v = v.Elem()
+	arrays := map[string]reflect.Value{}
+	for i := 0; i < v.NumField(); i++ {
+		tag, _, _ := strings.Cut(v.Type().Field(i).Tag.Get("json"), ",")
+		arrays[tag] = v.Field(i)
+	}
+
+	times := arrays["time"].Interface().([]string)
+	if times == nil {
+		return &SchemaError{Field: name + ".time", Problem: "is missing"}
+	}
+	for i, t := range times {
+		if t == "" {
+			return &SchemaError{Field: name + ".time", Problem: fmt.Sprintf("has a null at index %d", i)}
+		}
+	}
+	for _, variable := range variables {
+		array, ok := arrays[variable]
+		if !ok {
+			// Not decoded, so nothing can go wrong with it.
+			continue
+		}
+		field := name + "." + variable
+		if array.IsNil() {
+			return &SchemaError{Field: field, Problem: "is missing"}
+		}
+		if array.Len() > len(times) {
+			return &SchemaError{Field: field, Problem: fmt.Sprintf("has %d values for %d times", array.Len(), len(times))}
+		}
+		if _, ok := units[variable]; units != nil && !ok {
+			return &SchemaError{Field: name + "_units." + variable, Problem: "is missing"}
+		}
+	}
+	return nil
+}
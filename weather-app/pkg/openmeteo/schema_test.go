@@ -0,0 +1,39 @@
+package openmeteo
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestValidateHourly(t *testing.T) {
+	req := ForecastRequest{Hourly: []string{"temperature_2m", "birch_pollen"}}
+	tests := []struct {
+		name   string
+		hourly string
+		ok     bool
+	}{
+		{"complete", `{"time":["a","b","c"],"temperature_2m":[1,2,3],"birch_pollen":[null,null,null]}`, true},
+		{"past the model's range", `{"time":["a","b","c"],"temperature_2m":[1,2,null],"birch_pollen":[]}`, true},
+		{"null before a value", `{"time":["a","b","c"],"temperature_2m":[1,null,3],"birch_pollen":[]}`, true},
+		{"only nulls", `{"time":["a","b","c"],"temperature_2m":[null,null,null],"birch_pollen":[]}`, true},
+		{"missing", `{"time":["a","b","c"],"birch_pollen":[]}`, false},
+		{"longer than time", `{"time":["a","b"],"temperature_2m":[1,2,3],"birch_pollen":[]}`, false},
+		{"null time", `{"time":["a",null],"temperature_2m":[1,2],"birch_pollen":[]}`, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var resp ForecastResponse
+			if err := json.Unmarshal([]byte(`{"hourly":`+tt.hourly+`}`), &resp); err != nil {
+				t.Fatal(err)
+			}
+			err := resp.validate(req)
+			if tt.ok && err != nil {
+				t.Errorf("got %v, want no error", err)
+			}
+			if !tt.ok && !errors.Is(err, ErrSchemaChanged) {
+				t.Errorf("got %v, want ErrSchemaChanged", err)
+			}
+		})
+	}
+}
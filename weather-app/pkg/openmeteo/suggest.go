@@ -0,0 +1,111 @@
+package openmeteo
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxSuggestions is the number of places a NotFoundError suggests.
+const maxSuggestions = 3
+
+// suggest returns the places, as "city, country", that name was most
+// likely meant to be: results of the same name in other countries, and
+// places in country whose names are a typo or two away. When the search
+// found nothing close, the start of name is searched too, which finds
+// e.g. Amsterdam for "Amsterdma". A failing second search only means
+// fewer suggestions.
+func (c *Client) suggest(ctx context.Context, name, country string, results []GeocodingResult) []string {
+	candidates := closeResults(name, results)
+	if len(candidates) == 0 {
+		if prefix := namePrefix(name); prefix != "" {
+			more, err := c.Search(ctx, GeocodingRequest{Name: prefix, Count: 20})
+			if err != nil {
+				c.logger.Debug("searching suggestions", "city", name, "err", err)
+			}
+			candidates = closeResults(name, more)
+		}
+	}
+
+	// Places in the requested country first, then the closest names, then
+	// the largest places.
+	sort.SliceStable(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if inI, inJ := a.InCountry(country), b.InCountry(country); inI != inJ {
+			return inI
+		}
+		if a.distance != b.distance {
+			return a.distance < b.distance
+		}
+		return a.Population > b.Population
+	})
+
+	var suggestions []string
+	seen := map[string]bool{}
+	for _, r := range candidates {
+		place := r.Name + ", " + r.Country
+		if seen[place] || r.Country == "" {
+			continue
+		}
+		seen[place] = true
+		suggestions = append(suggestions, place)
+		if len(suggestions) == maxSuggestions {
+			break
+		}
+	}
+	return suggestions
+}
+
+type candidate struct {
+	GeocodingResult
+	distance int
+}
+
+// closeResults returns the results whose names are within a typo or two of
+// name: one edit for every three letters, and at least one.
+func closeResults(name string, results []GeocodingResult) []candidate {
+	limit := max(1, utf8.RuneCountInString(name)/3)
+	var close []candidate
+	for _, r := range results {
+		if d := editDistance(strings.ToLower(name), strings.ToLower(r.Name)); d <= limit {
+			close = append(close, candidate{r, d})
+		}
+	}
+	return close
+}
+
+// namePrefix returns the first half of name, at least the three letters
+// the geocoding API needs for a fuzzy search, or "" when name is too short
+// to shorten.
+func namePrefix(name string) string {
+	runes := []rune(strings.TrimSpace(name))
+	n := max(3, len(runes)/2)
+	if n >= len(runes) {
+		return ""
+	}
+	return string(runes[:n])
+}
+
+// editDistance is the Levenshtein distance between a and b: the number of
+// letters to insert, delete or replace to turn one into the other.
+func editDistance(a, b string) int {
+	s, t := []rune(a), []rune(b)
+	prev := make([]int, len(t)+1)
+	cur := make([]int, len(t)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := range s {
+		cur[0] = i + 1
+		for j := range t {
+			cost := 1
+			if s[i] == t[j] {
+				cost = 0
+			}
+			cur[j+1] = min(prev[j+1]+1, cur[j]+1, prev[j]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(t)]
+}
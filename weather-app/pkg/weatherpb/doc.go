@@ -0,0 +1,6 @@
+// Package weatherpb holds the gRPC client and server code generated from
+// weather.proto, for services consuming forecasts from `weather-app serve
+// -grpc`.
+package weatherpb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative weather.proto
@@ -0,0 +1,1683 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.29.3
+// source: weather.proto
+
+// The weather service serves the forecasts of weather-app over gRPC. It is
+// served by `weather-app serve -grpc :9090`.
+
+package weatherpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Place is where to forecast for: either city and country, or coordinates.
+// Ambiguous city names resolve to the best match.
+type Place struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	City          string                 `protobuf:"bytes,1,opt,name=city,proto3" json:"city,omitempty"`
+	Country       string                 `protobuf:"bytes,2,opt,name=country,proto3" json:"country,omitempty"`
+	Coordinates   *Coordinates           `protobuf:"bytes,3,opt,name=coordinates,proto3" json:"coordinates,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Place) Reset() {
+	*x = Place{}
+	mi := &file_weather_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Place) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Place) ProtoMessage() {}
+
+func (x *Place) ProtoReflect() protoreflect.Message {
+	mi := &file_weather_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Place.ProtoReflect.Descriptor instead.
+func (*Place) Descriptor() ([]byte, []int) {
+	return file_weather_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Place) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+func (x *Place) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+func (x *Place) GetCoordinates() *Coordinates {
+	if x != nil {
+		return x.Coordinates
+	}
+	return nil
+}
+
+type Coordinates struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Latitude      float64                `protobuf:"fixed64,1,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude     float64                `protobuf:"fixed64,2,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Coordinates) Reset() {
+	*x = Coordinates{}
+	mi := &file_weather_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Coordinates) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Coordinates) ProtoMessage() {}
+
+func (x *Coordinates) ProtoReflect() protoreflect.Message {
+	mi := &file_weather_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Coordinates.ProtoReflect.Descriptor instead.
+func (*Coordinates) Descriptor() ([]byte, []int) {
+	return file_weather_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Coordinates) GetLatitude() float64 {
+	if x != nil {
+		return x.Latitude
+	}
+	return 0
+}
+
+func (x *Coordinates) GetLongitude() float64 {
+	if x != nil {
+		return x.Longitude
+	}
+	return 0
+}
+
+type GetForecastRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Place *Place                 `protobuf:"bytes,1,opt,name=place,proto3" json:"place,omitempty"`
+	// Days to forecast, 1-16; 0 is a week.
+	Days int32 `protobuf:"varint,2,opt,name=days,proto3" json:"days,omitempty"`
+	// Hours to forecast hourly, 1-384; 0 forecasts daily.
+	Hours int32 `protobuf:"varint,3,opt,name=hours,proto3" json:"hours,omitempty"`
+	// The optional daily variables, matching the command-line flags.
+	Precipitation bool   `protobuf:"varint,4,opt,name=precipitation,proto3" json:"precipitation,omitempty"`
+	UvIndex       bool   `protobuf:"varint,5,opt,name=uv_index,json=uvIndex,proto3" json:"uv_index,omitempty"`
+	Sunrise       bool   `protobuf:"varint,6,opt,name=sunrise,proto3" json:"sunrise,omitempty"`
+	Sunset        bool   `protobuf:"varint,7,opt,name=sunset,proto3" json:"sunset,omitempty"`
+	Daylight      bool   `protobuf:"varint,8,opt,name=daylight,proto3" json:"daylight,omitempty"`
+	Wind          bool   `protobuf:"varint,9,opt,name=wind,proto3" json:"wind,omitempty"`
+	Feels         bool   `protobuf:"varint,10,opt,name=feels,proto3" json:"feels,omitempty"`
+	Snow          bool   `protobuf:"varint,11,opt,name=snow,proto3" json:"snow,omitempty"`
+	Humidity      bool   `protobuf:"varint,12,opt,name=humidity,proto3" json:"humidity,omitempty"`
+	Pressure      bool   `protobuf:"varint,13,opt,name=pressure,proto3" json:"pressure,omitempty"`
+	Ensemble      bool   `protobuf:"varint,14,opt,name=ensemble,proto3" json:"ensemble,omitempty"`
+	Units         *Units `protobuf:"bytes,15,opt,name=units,proto3" json:"units,omitempty"`
+	// Model is a weather model such as gfs, icon or ecmwf.
+	Model string `protobuf:"bytes,16,opt,name=model,proto3" json:"model,omitempty"`
+	// Lang translates the weather descriptions, e.g. de or fr.
+	Lang          string `protobuf:"bytes,17,opt,name=lang,proto3" json:"lang,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetForecastRequest) Reset() {
+	*x = GetForecastRequest{}
+	mi := &file_weather_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetForecastRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetForecastRequest) ProtoMessage() {}
+
+func (x *GetForecastRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_weather_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetForecastRequest.ProtoReflect.Descriptor instead.
+func (*GetForecastRequest) Descriptor() ([]byte, []int) {
+	return file_weather_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetForecastRequest) GetPlace() *Place {
+	if x != nil {
+		return x.Place
+	}
+	return nil
+}
+
+func (x *GetForecastRequest) GetDays() int32 {
+	if x != nil {
+		return x.Days
+	}
+	return 0
+}
+
+func (x *GetForecastRequest) GetHours() int32 {
+	if x != nil {
+		return x.Hours
+	}
+	return 0
+}
+
+func (x *GetForecastRequest) GetPrecipitation() bool {
+	if x != nil {
+		return x.Precipitation
+	}
+	return false
+}
+
+func (x *GetForecastRequest) GetUvIndex() bool {
+	if x != nil {
+		return x.UvIndex
+	}
+	return false
+}
+
+func (x *GetForecastRequest) GetSunrise() bool {
+	if x != nil {
+		return x.Sunrise
+	}
+	return false
+}
+
+func (x *GetForecastRequest) GetSunset() bool {
+	if x != nil {
+		return x.Sunset
+	}
+	return false
+}
+
+func (x *GetForecastRequest) GetDaylight() bool {
+	if x != nil {
+		return x.Daylight
+	}
+	return false
+}
+
+func (x *GetForecastRequest) GetWind() bool {
+	if x != nil {
+		return x.Wind
+	}
+	return false
+}
+
+func (x *GetForecastRequest) GetFeels() bool {
+	if x != nil {
+		return x.Feels
+	}
+	return false
+}
+
+func (x *GetForecastRequest) GetSnow() bool {
+	if x != nil {
+		return x.Snow
+	}
+	return false
+}
+
+func (x *GetForecastRequest) GetHumidity() bool {
+	if x != nil {
+		return x.Humidity
+	}
+	return false
+}
+
+func (x *GetForecastRequest) GetPressure() bool {
+	if x != nil {
+		return x.Pressure
+	}
+	return false
+}
+
+func (x *GetForecastRequest) GetEnsemble() bool {
+	if x != nil {
+		return x.Ensemble
+	}
+	return false
+}
+
+func (x *GetForecastRequest) GetUnits() *Units {
+	if x != nil {
+		return x.Units
+	}
+	return nil
+}
+
+func (x *GetForecastRequest) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *GetForecastRequest) GetLang() string {
+	if x != nil {
+		return x.Lang
+	}
+	return ""
+}
+
+type GetCurrentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Place         *Place                 `protobuf:"bytes,1,opt,name=place,proto3" json:"place,omitempty"`
+	Units         *Units                 `protobuf:"bytes,2,opt,name=units,proto3" json:"units,omitempty"`
+	Lang          string                 `protobuf:"bytes,3,opt,name=lang,proto3" json:"lang,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCurrentRequest) Reset() {
+	*x = GetCurrentRequest{}
+	mi := &file_weather_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCurrentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCurrentRequest) ProtoMessage() {}
+
+func (x *GetCurrentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_weather_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCurrentRequest.ProtoReflect.Descriptor instead.
+func (*GetCurrentRequest) Descriptor() ([]byte, []int) {
+	return file_weather_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetCurrentRequest) GetPlace() *Place {
+	if x != nil {
+		return x.Place
+	}
+	return nil
+}
+
+func (x *GetCurrentRequest) GetUnits() *Units {
+	if x != nil {
+		return x.Units
+	}
+	return nil
+}
+
+func (x *GetCurrentRequest) GetLang() string {
+	if x != nil {
+		return x.Lang
+	}
+	return ""
+}
+
+// Units choose the unit system (metric, imperial or scientific) and
+// optionally override its temperature or wind speed unit.
+type Units struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	System     string                 `protobuf:"bytes,1,opt,name=system,proto3" json:"system,omitempty"`
+	Fahrenheit bool                   `protobuf:"varint,2,opt,name=fahrenheit,proto3" json:"fahrenheit,omitempty"`
+	// Wind is kmh, ms, mph or kn.
+	Wind          string `protobuf:"bytes,3,opt,name=wind,proto3" json:"wind,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Units) Reset() {
+	*x = Units{}
+	mi := &file_weather_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Units) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Units) ProtoMessage() {}
+
+func (x *Units) ProtoReflect() protoreflect.Message {
+	mi := &file_weather_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Units.ProtoReflect.Descriptor instead.
+func (*Units) Descriptor() ([]byte, []int) {
+	return file_weather_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Units) GetSystem() string {
+	if x != nil {
+		return x.System
+	}
+	return ""
+}
+
+func (x *Units) GetFahrenheit() bool {
+	if x != nil {
+		return x.Fahrenheit
+	}
+	return false
+}
+
+func (x *Units) GetWind() string {
+	if x != nil {
+		return x.Wind
+	}
+	return ""
+}
+
+type ResolveCityRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	City          string                 `protobuf:"bytes,1,opt,name=city,proto3" json:"city,omitempty"`
+	Country       string                 `protobuf:"bytes,2,opt,name=country,proto3" json:"country,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResolveCityRequest) Reset() {
+	*x = ResolveCityRequest{}
+	mi := &file_weather_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResolveCityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolveCityRequest) ProtoMessage() {}
+
+func (x *ResolveCityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_weather_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResolveCityRequest.ProtoReflect.Descriptor instead.
+func (*ResolveCityRequest) Descriptor() ([]byte, []int) {
+	return file_weather_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ResolveCityRequest) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+func (x *ResolveCityRequest) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+type ResolveCityResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Places        []*ResolvedPlace       `protobuf:"bytes,1,rep,name=places,proto3" json:"places,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResolveCityResponse) Reset() {
+	*x = ResolveCityResponse{}
+	mi := &file_weather_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResolveCityResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolveCityResponse) ProtoMessage() {}
+
+func (x *ResolveCityResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_weather_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResolveCityResponse.ProtoReflect.Descriptor instead.
+func (*ResolveCityResponse) Descriptor() ([]byte, []int) {
+	return file_weather_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ResolveCityResponse) GetPlaces() []*ResolvedPlace {
+	if x != nil {
+		return x.Places
+	}
+	return nil
+}
+
+type ResolvedPlace struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Location      *Location              `protobuf:"bytes,1,opt,name=location,proto3" json:"location,omitempty"`
+	Region        string                 `protobuf:"bytes,2,opt,name=region,proto3" json:"region,omitempty"`
+	Population    int64                  `protobuf:"varint,3,opt,name=population,proto3" json:"population,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResolvedPlace) Reset() {
+	*x = ResolvedPlace{}
+	mi := &file_weather_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResolvedPlace) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolvedPlace) ProtoMessage() {}
+
+func (x *ResolvedPlace) ProtoReflect() protoreflect.Message {
+	mi := &file_weather_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResolvedPlace.ProtoReflect.Descriptor instead.
+func (*ResolvedPlace) Descriptor() ([]byte, []int) {
+	return file_weather_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ResolvedPlace) GetLocation() *Location {
+	if x != nil {
+		return x.Location
+	}
+	return nil
+}
+
+func (x *ResolvedPlace) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *ResolvedPlace) GetPopulation() int64 {
+	if x != nil {
+		return x.Population
+	}
+	return 0
+}
+
+type Location struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Country       string                 `protobuf:"bytes,2,opt,name=country,proto3" json:"country,omitempty"`
+	Latitude      float64                `protobuf:"fixed64,3,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude     float64                `protobuf:"fixed64,4,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	Timezone      string                 `protobuf:"bytes,5,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Location) Reset() {
+	*x = Location{}
+	mi := &file_weather_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Location) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Location) ProtoMessage() {}
+
+func (x *Location) ProtoReflect() protoreflect.Message {
+	mi := &file_weather_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Location.ProtoReflect.Descriptor instead.
+func (*Location) Descriptor() ([]byte, []int) {
+	return file_weather_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Location) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Location) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+func (x *Location) GetLatitude() float64 {
+	if x != nil {
+		return x.Latitude
+	}
+	return 0
+}
+
+func (x *Location) GetLongitude() float64 {
+	if x != nil {
+		return x.Longitude
+	}
+	return 0
+}
+
+func (x *Location) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+// Forecast mirrors the JSON of `weather-app -o json`. Optional values are
+// unset when they were not requested or the provider did not return them.
+type Forecast struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Location      *Location              `protobuf:"bytes,1,opt,name=location,proto3" json:"location,omitempty"`
+	Units         *ForecastUnits         `protobuf:"bytes,2,opt,name=units,proto3" json:"units,omitempty"`
+	Model         string                 `protobuf:"bytes,3,opt,name=model,proto3" json:"model,omitempty"`
+	Current       *Current               `protobuf:"bytes,4,opt,name=current,proto3" json:"current,omitempty"`
+	Days          []*Day                 `protobuf:"bytes,5,rep,name=days,proto3" json:"days,omitempty"`
+	Hours         []*Hour                `protobuf:"bytes,6,rep,name=hours,proto3" json:"hours,omitempty"`
+	Alerts        []*Alert               `protobuf:"bytes,7,rep,name=alerts,proto3" json:"alerts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Forecast) Reset() {
+	*x = Forecast{}
+	mi := &file_weather_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Forecast) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Forecast) ProtoMessage() {}
+
+func (x *Forecast) ProtoReflect() protoreflect.Message {
+	mi := &file_weather_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Forecast.ProtoReflect.Descriptor instead.
+func (*Forecast) Descriptor() ([]byte, []int) {
+	return file_weather_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *Forecast) GetLocation() *Location {
+	if x != nil {
+		return x.Location
+	}
+	return nil
+}
+
+func (x *Forecast) GetUnits() *ForecastUnits {
+	if x != nil {
+		return x.Units
+	}
+	return nil
+}
+
+func (x *Forecast) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *Forecast) GetCurrent() *Current {
+	if x != nil {
+		return x.Current
+	}
+	return nil
+}
+
+func (x *Forecast) GetDays() []*Day {
+	if x != nil {
+		return x.Days
+	}
+	return nil
+}
+
+func (x *Forecast) GetHours() []*Hour {
+	if x != nil {
+		return x.Hours
+	}
+	return nil
+}
+
+func (x *Forecast) GetAlerts() []*Alert {
+	if x != nil {
+		return x.Alerts
+	}
+	return nil
+}
+
+// ForecastUnits are the units the values of a forecast are in.
+type ForecastUnits struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Temperature   string                 `protobuf:"bytes,1,opt,name=temperature,proto3" json:"temperature,omitempty"`
+	Precipitation string                 `protobuf:"bytes,2,opt,name=precipitation,proto3" json:"precipitation,omitempty"`
+	WindSpeed     string                 `protobuf:"bytes,3,opt,name=wind_speed,json=windSpeed,proto3" json:"wind_speed,omitempty"`
+	Snow          string                 `protobuf:"bytes,4,opt,name=snow,proto3" json:"snow,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ForecastUnits) Reset() {
+	*x = ForecastUnits{}
+	mi := &file_weather_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ForecastUnits) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ForecastUnits) ProtoMessage() {}
+
+func (x *ForecastUnits) ProtoReflect() protoreflect.Message {
+	mi := &file_weather_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ForecastUnits.ProtoReflect.Descriptor instead.
+func (*ForecastUnits) Descriptor() ([]byte, []int) {
+	return file_weather_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ForecastUnits) GetTemperature() string {
+	if x != nil {
+		return x.Temperature
+	}
+	return ""
+}
+
+func (x *ForecastUnits) GetPrecipitation() string {
+	if x != nil {
+		return x.Precipitation
+	}
+	return ""
+}
+
+func (x *ForecastUnits) GetWindSpeed() string {
+	if x != nil {
+		return x.WindSpeed
+	}
+	return ""
+}
+
+func (x *ForecastUnits) GetSnow() string {
+	if x != nil {
+		return x.Snow
+	}
+	return ""
+}
+
+type Current struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Time          *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=time,proto3" json:"time,omitempty"`
+	Temperature   float64                `protobuf:"fixed64,2,opt,name=temperature,proto3" json:"temperature,omitempty"`
+	WindSpeed     float64                `protobuf:"fixed64,3,opt,name=wind_speed,json=windSpeed,proto3" json:"wind_speed,omitempty"`
+	WindDirection float64                `protobuf:"fixed64,4,opt,name=wind_direction,json=windDirection,proto3" json:"wind_direction,omitempty"`
+	WeatherCode   int32                  `protobuf:"varint,5,opt,name=weather_code,json=weatherCode,proto3" json:"weather_code,omitempty"`
+	Description   string                 `protobuf:"bytes,6,opt,name=description,proto3" json:"description,omitempty"`
+	IsDay         bool                   `protobuf:"varint,7,opt,name=is_day,json=isDay,proto3" json:"is_day,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Current) Reset() {
+	*x = Current{}
+	mi := &file_weather_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Current) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Current) ProtoMessage() {}
+
+func (x *Current) ProtoReflect() protoreflect.Message {
+	mi := &file_weather_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Current.ProtoReflect.Descriptor instead.
+func (*Current) Descriptor() ([]byte, []int) {
+	return file_weather_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *Current) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *Current) GetTemperature() float64 {
+	if x != nil {
+		return x.Temperature
+	}
+	return 0
+}
+
+func (x *Current) GetWindSpeed() float64 {
+	if x != nil {
+		return x.WindSpeed
+	}
+	return 0
+}
+
+func (x *Current) GetWindDirection() float64 {
+	if x != nil {
+		return x.WindDirection
+	}
+	return 0
+}
+
+func (x *Current) GetWeatherCode() int32 {
+	if x != nil {
+		return x.WeatherCode
+	}
+	return 0
+}
+
+func (x *Current) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Current) GetIsDay() bool {
+	if x != nil {
+		return x.IsDay
+	}
+	return false
+}
+
+type Day struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Date is the local date, e.g. 2024-05-01.
+	Date                        string   `protobuf:"bytes,1,opt,name=date,proto3" json:"date,omitempty"`
+	TempMax                     float64  `protobuf:"fixed64,2,opt,name=temp_max,json=tempMax,proto3" json:"temp_max,omitempty"`
+	TempMin                     float64  `protobuf:"fixed64,3,opt,name=temp_min,json=tempMin,proto3" json:"temp_min,omitempty"`
+	TempMaxP10                  *float64 `protobuf:"fixed64,4,opt,name=temp_max_p10,json=tempMaxP10,proto3,oneof" json:"temp_max_p10,omitempty"`
+	TempMaxP90                  *float64 `protobuf:"fixed64,5,opt,name=temp_max_p90,json=tempMaxP90,proto3,oneof" json:"temp_max_p90,omitempty"`
+	TempMinP10                  *float64 `protobuf:"fixed64,6,opt,name=temp_min_p10,json=tempMinP10,proto3,oneof" json:"temp_min_p10,omitempty"`
+	TempMinP90                  *float64 `protobuf:"fixed64,7,opt,name=temp_min_p90,json=tempMinP90,proto3,oneof" json:"temp_min_p90,omitempty"`
+	ApparentTemperatureMax      *float64 `protobuf:"fixed64,8,opt,name=apparent_temperature_max,json=apparentTemperatureMax,proto3,oneof" json:"apparent_temperature_max,omitempty"`
+	ApparentTemperatureMin      *float64 `protobuf:"fixed64,9,opt,name=apparent_temperature_min,json=apparentTemperatureMin,proto3,oneof" json:"apparent_temperature_min,omitempty"`
+	Precipitation               *float64 `protobuf:"fixed64,10,opt,name=precipitation,proto3,oneof" json:"precipitation,omitempty"`
+	PrecipitationProbabilityMax *float64 `protobuf:"fixed64,11,opt,name=precipitation_probability_max,json=precipitationProbabilityMax,proto3,oneof" json:"precipitation_probability_max,omitempty"`
+	PrecipitationHours          *float64 `protobuf:"fixed64,12,opt,name=precipitation_hours,json=precipitationHours,proto3,oneof" json:"precipitation_hours,omitempty"`
+	SnowfallSum                 *float64 `protobuf:"fixed64,13,opt,name=snowfall_sum,json=snowfallSum,proto3,oneof" json:"snowfall_sum,omitempty"`
+	RelativeHumidityMean        *float64 `protobuf:"fixed64,14,opt,name=relative_humidity_mean,json=relativeHumidityMean,proto3,oneof" json:"relative_humidity_mean,omitempty"`
+	RelativeHumidityMin         *float64 `protobuf:"fixed64,15,opt,name=relative_humidity_min,json=relativeHumidityMin,proto3,oneof" json:"relative_humidity_min,omitempty"`
+	RelativeHumidityMax         *float64 `protobuf:"fixed64,16,opt,name=relative_humidity_max,json=relativeHumidityMax,proto3,oneof" json:"relative_humidity_max,omitempty"`
+	DewPointMean                *float64 `protobuf:"fixed64,17,opt,name=dew_point_mean,json=dewPointMean,proto3,oneof" json:"dew_point_mean,omitempty"`
+	DewPointMin                 *float64 `protobuf:"fixed64,18,opt,name=dew_point_min,json=dewPointMin,proto3,oneof" json:"dew_point_min,omitempty"`
+	DewPointMax                 *float64 `protobuf:"fixed64,19,opt,name=dew_point_max,json=dewPointMax,proto3,oneof" json:"dew_point_max,omitempty"`
+	// Surface pressure in hPa.
+	SurfacePressureMean *float64               `protobuf:"fixed64,20,opt,name=surface_pressure_mean,json=surfacePressureMean,proto3,oneof" json:"surface_pressure_mean,omitempty"`
+	PressureTrend       string                 `protobuf:"bytes,21,opt,name=pressure_trend,json=pressureTrend,proto3" json:"pressure_trend,omitempty"`
+	UvIndex             *float64               `protobuf:"fixed64,22,opt,name=uv_index,json=uvIndex,proto3,oneof" json:"uv_index,omitempty"`
+	Sunrise             *timestamppb.Timestamp `protobuf:"bytes,23,opt,name=sunrise,proto3" json:"sunrise,omitempty"`
+	Sunset              *timestamppb.Timestamp `protobuf:"bytes,24,opt,name=sunset,proto3" json:"sunset,omitempty"`
+	// Durations in seconds.
+	DaylightDuration      *float64 `protobuf:"fixed64,25,opt,name=daylight_duration,json=daylightDuration,proto3,oneof" json:"daylight_duration,omitempty"`
+	SunshineDuration      *float64 `protobuf:"fixed64,26,opt,name=sunshine_duration,json=sunshineDuration,proto3,oneof" json:"sunshine_duration,omitempty"`
+	WindSpeedMax          *float64 `protobuf:"fixed64,27,opt,name=wind_speed_max,json=windSpeedMax,proto3,oneof" json:"wind_speed_max,omitempty"`
+	WindGustsMax          *float64 `protobuf:"fixed64,28,opt,name=wind_gusts_max,json=windGustsMax,proto3,oneof" json:"wind_gusts_max,omitempty"`
+	WindDirectionDominant *float64 `protobuf:"fixed64,29,opt,name=wind_direction_dominant,json=windDirectionDominant,proto3,oneof" json:"wind_direction_dominant,omitempty"`
+	WeatherCode           *int32   `protobuf:"varint,30,opt,name=weather_code,json=weatherCode,proto3,oneof" json:"weather_code,omitempty"`
+	Description           string   `protobuf:"bytes,31,opt,name=description,proto3" json:"description,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *Day) Reset() {
+	*x = Day{}
+	mi := &file_weather_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Day) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Day) ProtoMessage() {}
+
+func (x *Day) ProtoReflect() protoreflect.Message {
+	mi := &file_weather_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Day.ProtoReflect.Descriptor instead.
+func (*Day) Descriptor() ([]byte, []int) {
+	return file_weather_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *Day) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+func (x *Day) GetTempMax() float64 {
+	if x != nil {
+		return x.TempMax
+	}
+	return 0
+}
+
+func (x *Day) GetTempMin() float64 {
+	if x != nil {
+		return x.TempMin
+	}
+	return 0
+}
+
+func (x *Day) GetTempMaxP10() float64 {
+	if x != nil && x.TempMaxP10 != nil {
+		return *x.TempMaxP10
+	}
+	return 0
+}
+
+func (x *Day) GetTempMaxP90() float64 {
+	if x != nil && x.TempMaxP90 != nil {
+		return *x.TempMaxP90
+	}
+	return 0
+}
+
+func (x *Day) GetTempMinP10() float64 {
+	if x != nil && x.TempMinP10 != nil {
+		return *x.TempMinP10
+	}
+	return 0
+}
+
+func (x *Day) GetTempMinP90() float64 {
+	if x != nil && x.TempMinP90 != nil {
+		return *x.TempMinP90
+	}
+	return 0
+}
+
+func (x *Day) GetApparentTemperatureMax() float64 {
+	if x != nil && x.ApparentTemperatureMax != nil {
+		return *x.ApparentTemperatureMax
+	}
+	return 0
+}
+
+func (x *Day) GetApparentTemperatureMin() float64 {
+	if x != nil && x.ApparentTemperatureMin != nil {
+		return *x.ApparentTemperatureMin
+	}
+	return 0
+}
+
+func (x *Day) GetPrecipitation() float64 {
+	if x != nil && x.Precipitation != nil {
+		return *x.Precipitation
+	}
+	return 0
+}
+
+func (x *Day) GetPrecipitationProbabilityMax() float64 {
+	if x != nil && x.PrecipitationProbabilityMax != nil {
+		return *x.PrecipitationProbabilityMax
+	}
+	return 0
+}
+
+func (x *Day) GetPrecipitationHours() float64 {
+	if x != nil && x.PrecipitationHours != nil {
+		return *x.PrecipitationHours
+	}
+	return 0
+}
+
+func (x *Day) GetSnowfallSum() float64 {
+	if x != nil && x.SnowfallSum != nil {
+		return *x.SnowfallSum
+	}
+	return 0
+}
+
+func (x *Day) GetRelativeHumidityMean() float64 {
+	if x != nil && x.RelativeHumidityMean != nil {
+		return *x.RelativeHumidityMean
+	}
+	return 0
+}
+
+func (x *Day) GetRelativeHumidityMin() float64 {
+	if x != nil && x.RelativeHumidityMin != nil {
+		return *x.RelativeHumidityMin
+	}
+	return 0
+}
+
+func (x *Day) GetRelativeHumidityMax() float64 {
+	if x != nil && x.RelativeHumidityMax != nil {
+		return *x.RelativeHumidityMax
+	}
+	return 0
+}
+
+func (x *Day) GetDewPointMean() float64 {
+	if x != nil && x.DewPointMean != nil {
+		return *x.DewPointMean
+	}
+	return 0
+}
+
+func (x *Day) GetDewPointMin() float64 {
+	if x != nil && x.DewPointMin != nil {
+		return *x.DewPointMin
+	}
+	return 0
+}
+
+func (x *Day) GetDewPointMax() float64 {
+	if x != nil && x.DewPointMax != nil {
+		return *x.DewPointMax
+	}
+	return 0
+}
+
+func (x *Day) GetSurfacePressureMean() float64 {
+	if x != nil && x.SurfacePressureMean != nil {
+		return *x.SurfacePressureMean
+	}
+	return 0
+}
+
+func (x *Day) GetPressureTrend() string {
+	if x != nil {
+		return x.PressureTrend
+	}
+	return ""
+}
+
+func (x *Day) GetUvIndex() float64 {
+	if x != nil && x.UvIndex != nil {
+		return *x.UvIndex
+	}
+	return 0
+}
+
+func (x *Day) GetSunrise() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Sunrise
+	}
+	return nil
+}
+
+func (x *Day) GetSunset() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Sunset
+	}
+	return nil
+}
+
+func (x *Day) GetDaylightDuration() float64 {
+	if x != nil && x.DaylightDuration != nil {
+		return *x.DaylightDuration
+	}
+	return 0
+}
+
+func (x *Day) GetSunshineDuration() float64 {
+	if x != nil && x.SunshineDuration != nil {
+		return *x.SunshineDuration
+	}
+	return 0
+}
+
+func (x *Day) GetWindSpeedMax() float64 {
+	if x != nil && x.WindSpeedMax != nil {
+		return *x.WindSpeedMax
+	}
+	return 0
+}
+
+func (x *Day) GetWindGustsMax() float64 {
+	if x != nil && x.WindGustsMax != nil {
+		return *x.WindGustsMax
+	}
+	return 0
+}
+
+func (x *Day) GetWindDirectionDominant() float64 {
+	if x != nil && x.WindDirectionDominant != nil {
+		return *x.WindDirectionDominant
+	}
+	return 0
+}
+
+func (x *Day) GetWeatherCode() int32 {
+	if x != nil && x.WeatherCode != nil {
+		return *x.WeatherCode
+	}
+	return 0
+}
+
+func (x *Day) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+type Hour struct {
+	state                    protoimpl.MessageState `protogen:"open.v1"`
+	Time                     *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=time,proto3" json:"time,omitempty"`
+	Temperature              float64                `protobuf:"fixed64,2,opt,name=temperature,proto3" json:"temperature,omitempty"`
+	PrecipitationProbability *float64               `protobuf:"fixed64,3,opt,name=precipitation_probability,json=precipitationProbability,proto3,oneof" json:"precipitation_probability,omitempty"`
+	WindSpeed                *float64               `protobuf:"fixed64,4,opt,name=wind_speed,json=windSpeed,proto3,oneof" json:"wind_speed,omitempty"`
+	WindDirection            *float64               `protobuf:"fixed64,5,opt,name=wind_direction,json=windDirection,proto3,oneof" json:"wind_direction,omitempty"`
+	SnowDepth                *float64               `protobuf:"fixed64,6,opt,name=snow_depth,json=snowDepth,proto3,oneof" json:"snow_depth,omitempty"`
+	RelativeHumidity         *float64               `protobuf:"fixed64,7,opt,name=relative_humidity,json=relativeHumidity,proto3,oneof" json:"relative_humidity,omitempty"`
+	DewPoint                 *float64               `protobuf:"fixed64,8,opt,name=dew_point,json=dewPoint,proto3,oneof" json:"dew_point,omitempty"`
+	// Surface pressure in hPa.
+	SurfacePressure *float64 `protobuf:"fixed64,9,opt,name=surface_pressure,json=surfacePressure,proto3,oneof" json:"surface_pressure,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Hour) Reset() {
+	*x = Hour{}
+	mi := &file_weather_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Hour) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Hour) ProtoMessage() {}
+
+func (x *Hour) ProtoReflect() protoreflect.Message {
+	mi := &file_weather_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Hour.ProtoReflect.Descriptor instead.
+func (*Hour) Descriptor() ([]byte, []int) {
+	return file_weather_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *Hour) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *Hour) GetTemperature() float64 {
+	if x != nil {
+		return x.Temperature
+	}
+	return 0
+}
+
+func (x *Hour) GetPrecipitationProbability() float64 {
+	if x != nil && x.PrecipitationProbability != nil {
+		return *x.PrecipitationProbability
+	}
+	return 0
+}
+
+func (x *Hour) GetWindSpeed() float64 {
+	if x != nil && x.WindSpeed != nil {
+		return *x.WindSpeed
+	}
+	return 0
+}
+
+func (x *Hour) GetWindDirection() float64 {
+	if x != nil && x.WindDirection != nil {
+		return *x.WindDirection
+	}
+	return 0
+}
+
+func (x *Hour) GetSnowDepth() float64 {
+	if x != nil && x.SnowDepth != nil {
+		return *x.SnowDepth
+	}
+	return 0
+}
+
+func (x *Hour) GetRelativeHumidity() float64 {
+	if x != nil && x.RelativeHumidity != nil {
+		return *x.RelativeHumidity
+	}
+	return 0
+}
+
+func (x *Hour) GetDewPoint() float64 {
+	if x != nil && x.DewPoint != nil {
+		return *x.DewPoint
+	}
+	return 0
+}
+
+func (x *Hour) GetSurfacePressure() float64 {
+	if x != nil && x.SurfacePressure != nil {
+		return *x.SurfacePressure
+	}
+	return 0
+}
+
+type Alert struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Event         string                 `protobuf:"bytes,1,opt,name=event,proto3" json:"event,omitempty"`
+	Severity      string                 `protobuf:"bytes,2,opt,name=severity,proto3" json:"severity,omitempty"`
+	Headline      string                 `protobuf:"bytes,3,opt,name=headline,proto3" json:"headline,omitempty"`
+	Description   string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	Instruction   string                 `protobuf:"bytes,5,opt,name=instruction,proto3" json:"instruction,omitempty"`
+	Start         *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=start,proto3" json:"start,omitempty"`
+	End           *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=end,proto3" json:"end,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Alert) Reset() {
+	*x = Alert{}
+	mi := &file_weather_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Alert) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Alert) ProtoMessage() {}
+
+func (x *Alert) ProtoReflect() protoreflect.Message {
+	mi := &file_weather_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Alert.ProtoReflect.Descriptor instead.
+func (*Alert) Descriptor() ([]byte, []int) {
+	return file_weather_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *Alert) GetEvent() string {
+	if x != nil {
+		return x.Event
+	}
+	return ""
+}
+
+func (x *Alert) GetSeverity() string {
+	if x != nil {
+		return x.Severity
+	}
+	return ""
+}
+
+func (x *Alert) GetHeadline() string {
+	if x != nil {
+		return x.Headline
+	}
+	return ""
+}
+
+func (x *Alert) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Alert) GetInstruction() string {
+	if x != nil {
+		return x.Instruction
+	}
+	return ""
+}
+
+func (x *Alert) GetStart() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Start
+	}
+	return nil
+}
+
+func (x *Alert) GetEnd() *timestamppb.Timestamp {
+	if x != nil {
+		return x.End
+	}
+	return nil
+}
+
+var File_weather_proto protoreflect.FileDescriptor
+
+const file_weather_proto_rawDesc = "" +
+	"\n" +
+	"\rweather.proto\x12\n" +
+	"weather.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"p\n" +
+	"\x05Place\x12\x12\n" +
+	"\x04city\x18\x01 \x01(\tR\x04city\x12\x18\n" +
+	"\acountry\x18\x02 \x01(\tR\acountry\x129\n" +
+	"\vcoordinates\x18\x03 \x01(\v2\x17.weather.v1.CoordinatesR\vcoordinates\"G\n" +
+	"\vCoordinates\x12\x1a\n" +
+	"\blatitude\x18\x01 \x01(\x01R\blatitude\x12\x1c\n" +
+	"\tlongitude\x18\x02 \x01(\x01R\tlongitude\"\xdb\x03\n" +
+	"\x12GetForecastRequest\x12'\n" +
+	"\x05place\x18\x01 \x01(\v2\x11.weather.v1.PlaceR\x05place\x12\x12\n" +
+	"\x04days\x18\x02 \x01(\x05R\x04days\x12\x14\n" +
+	"\x05hours\x18\x03 \x01(\x05R\x05hours\x12$\n" +
+	"\rprecipitation\x18\x04 \x01(\bR\rprecipitation\x12\x19\n" +
+	"\buv_index\x18\x05 \x01(\bR\auvIndex\x12\x18\n" +
+	"\asunrise\x18\x06 \x01(\bR\asunrise\x12\x16\n" +
+	"\x06sunset\x18\a \x01(\bR\x06sunset\x12\x1a\n" +
+	"\bdaylight\x18\b \x01(\bR\bdaylight\x12\x12\n" +
+	"\x04wind\x18\t \x01(\bR\x04wind\x12\x14\n" +
+	"\x05feels\x18\n" +
+	" \x01(\bR\x05feels\x12\x12\n" +
+	"\x04snow\x18\v \x01(\bR\x04snow\x12\x1a\n" +
+	"\bhumidity\x18\f \x01(\bR\bhumidity\x12\x1a\n" +
+	"\bpressure\x18\r \x01(\bR\bpressure\x12\x1a\n" +
+	"\bensemble\x18\x0e \x01(\bR\bensemble\x12'\n" +
+	"\x05units\x18\x0f \x01(\v2\x11.weather.v1.UnitsR\x05units\x12\x14\n" +
+	"\x05model\x18\x10 \x01(\tR\x05model\x12\x12\n" +
+	"\x04lang\x18\x11 \x01(\tR\x04lang\"y\n" +
+	"\x11GetCurrentRequest\x12'\n" +
+	"\x05place\x18\x01 \x01(\v2\x11.weather.v1.PlaceR\x05place\x12'\n" +
+	"\x05units\x18\x02 \x01(\v2\x11.weather.v1.UnitsR\x05units\x12\x12\n" +
+	"\x04lang\x18\x03 \x01(\tR\x04lang\"S\n" +
+	"\x05Units\x12\x16\n" +
+	"\x06system\x18\x01 \x01(\tR\x06system\x12\x1e\n" +
+	"\n" +
+	"fahrenheit\x18\x02 \x01(\bR\n" +
+	"fahrenheit\x12\x12\n" +
+	"\x04wind\x18\x03 \x01(\tR\x04wind\"B\n" +
+	"\x12ResolveCityRequest\x12\x12\n" +
+	"\x04city\x18\x01 \x01(\tR\x04city\x12\x18\n" +
+	"\acountry\x18\x02 \x01(\tR\acountry\"H\n" +
+	"\x13ResolveCityResponse\x121\n" +
+	"\x06places\x18\x01 \x03(\v2\x19.weather.v1.ResolvedPlaceR\x06places\"y\n" +
+	"\rResolvedPlace\x120\n" +
+	"\blocation\x18\x01 \x01(\v2\x14.weather.v1.LocationR\blocation\x12\x16\n" +
+	"\x06region\x18\x02 \x01(\tR\x06region\x12\x1e\n" +
+	"\n" +
+	"population\x18\x03 \x01(\x03R\n" +
+	"population\"\x8e\x01\n" +
+	"\bLocation\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\acountry\x18\x02 \x01(\tR\acountry\x12\x1a\n" +
+	"\blatitude\x18\x03 \x01(\x01R\blatitude\x12\x1c\n" +
+	"\tlongitude\x18\x04 \x01(\x01R\tlongitude\x12\x1a\n" +
+	"\btimezone\x18\x05 \x01(\tR\btimezone\"\xaa\x02\n" +
+	"\bForecast\x120\n" +
+	"\blocation\x18\x01 \x01(\v2\x14.weather.v1.LocationR\blocation\x12/\n" +
+	"\x05units\x18\x02 \x01(\v2\x19.weather.v1.ForecastUnitsR\x05units\x12\x14\n" +
+	"\x05model\x18\x03 \x01(\tR\x05model\x12-\n" +
+	"\acurrent\x18\x04 \x01(\v2\x13.weather.v1.CurrentR\acurrent\x12#\n" +
+	"\x04days\x18\x05 \x03(\v2\x0f.weather.v1.DayR\x04days\x12&\n" +
+	"\x05hours\x18\x06 \x03(\v2\x10.weather.v1.HourR\x05hours\x12)\n" +
+	"\x06alerts\x18\a \x03(\v2\x11.weather.v1.AlertR\x06alerts\"\x8a\x01\n" +
+	"\rForecastUnits\x12 \n" +
+	"\vtemperature\x18\x01 \x01(\tR\vtemperature\x12$\n" +
+	"\rprecipitation\x18\x02 \x01(\tR\rprecipitation\x12\x1d\n" +
+	"\n" +
+	"wind_speed\x18\x03 \x01(\tR\twindSpeed\x12\x12\n" +
+	"\x04snow\x18\x04 \x01(\tR\x04snow\"\xfd\x01\n" +
+	"\aCurrent\x12.\n" +
+	"\x04time\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04time\x12 \n" +
+	"\vtemperature\x18\x02 \x01(\x01R\vtemperature\x12\x1d\n" +
+	"\n" +
+	"wind_speed\x18\x03 \x01(\x01R\twindSpeed\x12%\n" +
+	"\x0ewind_direction\x18\x04 \x01(\x01R\rwindDirection\x12!\n" +
+	"\fweather_code\x18\x05 \x01(\x05R\vweatherCode\x12 \n" +
+	"\vdescription\x18\x06 \x01(\tR\vdescription\x12\x15\n" +
+	"\x06is_day\x18\a \x01(\bR\x05isDay\"\x97\x0f\n" +
+	"\x03Day\x12\x12\n" +
+	"\x04date\x18\x01 \x01(\tR\x04date\x12\x19\n" +
+	"\btemp_max\x18\x02 \x01(\x01R\atempMax\x12\x19\n" +
+	"\btemp_min\x18\x03 \x01(\x01R\atempMin\x12%\n" +
+	"\ftemp_max_p10\x18\x04 \x01(\x01H\x00R\n" +
+	"tempMaxP10\x88\x01\x01\x12%\n" +
+	"\ftemp_max_p90\x18\x05 \x01(\x01H\x01R\n" +
+	"tempMaxP90\x88\x01\x01\x12%\n" +
+	"\ftemp_min_p10\x18\x06 \x01(\x01H\x02R\n" +
+	"tempMinP10\x88\x01\x01\x12%\n" +
+	"\ftemp_min_p90\x18\a \x01(\x01H\x03R\n" +
+	"tempMinP90\x88\x01\x01\x12=\n" +
+	"\x18apparent_temperature_max\x18\b \x01(\x01H\x04R\x16apparentTemperatureMax\x88\x01\x01\x12=\n" +
+	"\x18apparent_temperature_min\x18\t \x01(\x01H\x05R\x16apparentTemperatureMin\x88\x01\x01\x12)\n" +
+	"\rprecipitation\x18\n" +
+	" \x01(\x01H\x06R\rprecipitation\x88\x01\x01\x12G\n" +
+	"\x1dprecipitation_probability_max\x18\v \x01(\x01H\aR\x1bprecipitationProbabilityMax\x88\x01\x01\x124\n" +
+	"\x13precipitation_hours\x18\f \x01(\x01H\bR\x12precipitationHours\x88\x01\x01\x12&\n" +
+	"\fsnowfall_sum\x18\r \x01(\x01H\tR\vsnowfallSum\x88\x01\x01\x129\n" +
+	"\x16relative_humidity_mean\x18\x0e \x01(\x01H\n" +
+	"R\x14relativeHumidityMean\x88\x01\x01\x127\n" +
+	"\x15relative_humidity_min\x18\x0f \x01(\x01H\vR\x13relativeHumidityMin\x88\x01\x01\x127\n" +
+	"\x15relative_humidity_max\x18\x10 \x01(\x01H\fR\x13relativeHumidityMax\x88\x01\x01\x12)\n" +
+	"\x0edew_point_mean\x18\x11 \x01(\x01H\rR\fdewPointMean\x88\x01\x01\x12'\n" +
+	"\rdew_point_min\x18\x12 \x01(\x01H\x0eR\vdewPointMin\x88\x01\x01\x12'\n" +
+	"\rdew_point_max\x18\x13 \x01(\x01H\x0fR\vdewPointMax\x88\x01\x01\x127\n" +
+	"\x15surface_pressure_mean\x18\x14 \x01(\x01H\x10R\x13surfacePressureMean\x88\x01\x01\x12%\n" +
+	"\x0epressure_trend\x18\x15 \x01(\tR\rpressureTrend\x12\x1e\n" +
+	"\buv_index\x18\x16 \x01(\x01H\x11R\auvIndex\x88\x01\x01\x124\n" +
+	"\asunrise\x18\x17 \x01(\v2\x1a.google.protobuf.TimestampR\asunrise\x122\n" +
+	"\x06sunset\x18\x18 \x01(\v2\x1a.google.protobuf.TimestampR\x06sunset\x120\n" +
+	"\x11daylight_duration\x18\x19 \x01(\x01H\x12R\x10daylightDuration\x88\x01\x01\x120\n" +
+	"\x11sunshine_duration\x18\x1a \x01(\x01H\x13R\x10sunshineDuration\x88\x01\x01\x12)\n" +
+	"\x0ewind_speed_max\x18\x1b \x01(\x01H\x14R\fwindSpeedMax\x88\x01\x01\x12)\n" +
+	"\x0ewind_gusts_max\x18\x1c \x01(\x01H\x15R\fwindGustsMax\x88\x01\x01\x12;\n" +
+	"\x17wind_direction_dominant\x18\x1d \x01(\x01H\x16R\x15windDirectionDominant\x88\x01\x01\x12&\n" +
+	"\fweather_code\x18\x1e \x01(\x05H\x17R\vweatherCode\x88\x01\x01\x12 \n" +
+	"\vdescription\x18\x1f \x01(\tR\vdescriptionB\x0f\n" +
+	"\r_temp_max_p10B\x0f\n" +
+	"\r_temp_max_p90B\x0f\n" +
+	"\r_temp_min_p10B\x0f\n" +
+	"\r_temp_min_p90B\x1b\n" +
+	"\x19_apparent_temperature_maxB\x1b\n" +
+	"\x19_apparent_temperature_minB\x10\n" +
+	"\x0e_precipitationB \n" +
+	"\x1e_precipitation_probability_maxB\x16\n" +
+	"\x14_precipitation_hoursB\x0f\n" +
+	"\r_snowfall_sumB\x19\n" +
+	"\x17_relative_humidity_meanB\x18\n" +
+	"\x16_relative_humidity_minB\x18\n" +
+	"\x16_relative_humidity_maxB\x11\n" +
+	"\x0f_dew_point_meanB\x10\n" +
+	"\x0e_dew_point_minB\x10\n" +
+	"\x0e_dew_point_maxB\x18\n" +
+	"\x16_surface_pressure_meanB\v\n" +
+	"\t_uv_indexB\x14\n" +
+	"\x12_daylight_durationB\x14\n" +
+	"\x12_sunshine_durationB\x11\n" +
+	"\x0f_wind_speed_maxB\x11\n" +
+	"\x0f_wind_gusts_maxB\x1a\n" +
+	"\x18_wind_direction_dominantB\x0f\n" +
+	"\r_weather_code\"\x9a\x04\n" +
+	"\x04Hour\x12.\n" +
+	"\x04time\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04time\x12 \n" +
+	"\vtemperature\x18\x02 \x01(\x01R\vtemperature\x12@\n" +
+	"\x19precipitation_probability\x18\x03 \x01(\x01H\x00R\x18precipitationProbability\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"wind_speed\x18\x04 \x01(\x01H\x01R\twindSpeed\x88\x01\x01\x12*\n" +
+	"\x0ewind_direction\x18\x05 \x01(\x01H\x02R\rwindDirection\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"snow_depth\x18\x06 \x01(\x01H\x03R\tsnowDepth\x88\x01\x01\x120\n" +
+	"\x11relative_humidity\x18\a \x01(\x01H\x04R\x10relativeHumidity\x88\x01\x01\x12 \n" +
+	"\tdew_point\x18\b \x01(\x01H\x05R\bdewPoint\x88\x01\x01\x12.\n" +
+	"\x10surface_pressure\x18\t \x01(\x01H\x06R\x0fsurfacePressure\x88\x01\x01B\x1c\n" +
+	"\x1a_precipitation_probabilityB\r\n" +
+	"\v_wind_speedB\x11\n" +
+	"\x0f_wind_directionB\r\n" +
+	"\v_snow_depthB\x14\n" +
+	"\x12_relative_humidityB\f\n" +
+	"\n" +
+	"_dew_pointB\x13\n" +
+	"\x11_surface_pressure\"\xf9\x01\n" +
+	"\x05Alert\x12\x14\n" +
+	"\x05event\x18\x01 \x01(\tR\x05event\x12\x1a\n" +
+	"\bseverity\x18\x02 \x01(\tR\bseverity\x12\x1a\n" +
+	"\bheadline\x18\x03 \x01(\tR\bheadline\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\x12 \n" +
+	"\vinstruction\x18\x05 \x01(\tR\vinstruction\x120\n" +
+	"\x05start\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\x05start\x12,\n" +
+	"\x03end\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\x03end2\xe8\x01\n" +
+	"\x0eWeatherService\x12C\n" +
+	"\vGetForecast\x12\x1e.weather.v1.GetForecastRequest\x1a\x14.weather.v1.Forecast\x12A\n" +
+	"\n" +
+	"GetCurrent\x12\x1d.weather.v1.GetCurrentRequest\x1a\x14.weather.v1.Forecast\x12N\n" +
+	"\vResolveCity\x12\x1e.weather.v1.ResolveCityRequest\x1a\x1f.weather.v1.ResolveCityResponseB\x1bZ\x19weather-app/pkg/weatherpbb\x06proto3"
+
+var (
+	file_weather_proto_rawDescOnce sync.Once
+	file_weather_proto_rawDescData []byte
+)
+
+func file_weather_proto_rawDescGZIP() []byte {
+	file_weather_proto_rawDescOnce.Do(func() {
+		file_weather_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_weather_proto_rawDesc), len(file_weather_proto_rawDesc)))
+	})
+	return file_weather_proto_rawDescData
+}
+
+var file_weather_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
+var file_weather_proto_goTypes = []any{
+	(*Place)(nil),                 // 0: weather.v1.Place
+	(*Coordinates)(nil),           // 1: weather.v1.Coordinates
+	(*GetForecastRequest)(nil),    // 2: weather.v1.GetForecastRequest
+	(*GetCurrentRequest)(nil),     // 3: weather.v1.GetCurrentRequest
+	(*Units)(nil),                 // 4: weather.v1.Units
+	(*ResolveCityRequest)(nil),    // 5: weather.v1.ResolveCityRequest
+	(*ResolveCityResponse)(nil),   // 6: weather.v1.ResolveCityResponse
+	(*ResolvedPlace)(nil),         // 7: weather.v1.ResolvedPlace
+	(*Location)(nil),              // 8: weather.v1.Location
+	(*Forecast)(nil),              // 9: weather.v1.Forecast
+	(*ForecastUnits)(nil),         // 10: weather.v1.ForecastUnits
+	(*Current)(nil),               // 11: weather.v1.Current
+	(*Day)(nil),                   // 12: weather.v1.Day
+	(*Hour)(nil),                  // 13: weather.v1.Hour
+	(*Alert)(nil),                 // 14: weather.v1.Alert
+	(*timestamppb.Timestamp)(nil), // 15: google.protobuf.Timestamp
+}
+var file_weather_proto_depIdxs = []int32{
+	1,  // 0: weather.v1.Place.coordinates:type_name -> weather.v1.Coordinates
+	0,  // 1: weather.v1.GetForecastRequest.place:type_name -> weather.v1.Place
+	4,  // 2: weather.v1.GetForecastRequest.units:type_name -> weather.v1.Units
+	0,  // 3: weather.v1.GetCurrentRequest.place:type_name -> weather.v1.Place
+	4,  // 4: weather.v1.GetCurrentRequest.units:type_name -> weather.v1.Units
+	7,  // 5: weather.v1.ResolveCityResponse.places:type_name -> weather.v1.ResolvedPlace
+	8,  // 6: weather.v1.ResolvedPlace.location:type_name -> weather.v1.Location
+	8,  // 7: weather.v1.Forecast.location:type_name -> weather.v1.Location
+	10, // 8: weather.v1.Forecast.units:type_name -> weather.v1.ForecastUnits
+	11, // 9: weather.v1.Forecast.current:type_name -> weather.v1.Current
+	12, // 10: weather.v1.Forecast.days:type_name -> weather.v1.Day
+	13, // 11: weather.v1.Forecast.hours:type_name -> weather.v1.Hour
+	14, // 12: weather.v1.Forecast.alerts:type_name -> weather.v1.Alert
+	15, // 13: weather.v1.Current.time:type_name -> google.protobuf.Timestamp
+	15, // 14: weather.v1.Day.sunrise:type_name -> google.protobuf.Timestamp
+	15, // 15: weather.v1.Day.sunset:type_name -> google.protobuf.Timestamp
+	15, // 16: weather.v1.Hour.time:type_name -> google.protobuf.Timestamp
+	15, // 17: weather.v1.Alert.start:type_name -> google.protobuf.Timestamp
+	15, // 18: weather.v1.Alert.end:type_name -> google.protobuf.Timestamp
+	2,  // 19: weather.v1.WeatherService.GetForecast:input_type -> weather.v1.GetForecastRequest
+	3,  // 20: weather.v1.WeatherService.GetCurrent:input_type -> weather.v1.GetCurrentRequest
+	5,  // 21: weather.v1.WeatherService.ResolveCity:input_type -> weather.v1.ResolveCityRequest
+	9,  // 22: weather.v1.WeatherService.GetForecast:output_type -> weather.v1.Forecast
+	9,  // 23: weather.v1.WeatherService.GetCurrent:output_type -> weather.v1.Forecast
+	6,  // 24: weather.v1.WeatherService.ResolveCity:output_type -> weather.v1.ResolveCityResponse
+	22, // [22:25] is the sub-list for method output_type
+	19, // [19:22] is the sub-list for method input_type
+	19, // [19:19] is the sub-list for extension type_name
+	19, // [19:19] is the sub-list for extension extendee
+	0,  // [0:19] is the sub-list for field type_name
+}
+
+func init() { file_weather_proto_init() }
+func file_weather_proto_init() {
+	if File_weather_proto != nil {
+		return
+	}
+	file_weather_proto_msgTypes[12].OneofWrappers = []any{}
+	file_weather_proto_msgTypes[13].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_weather_proto_rawDesc), len(file_weather_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   15,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_weather_proto_goTypes,
+		DependencyIndexes: file_weather_proto_depIdxs,
+		MessageInfos:      file_weather_proto_msgTypes,
+	}.Build()
+	File_weather_proto = out.File
+	file_weather_proto_goTypes = nil
+	file_weather_proto_depIdxs = nil
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+
+	"weather-app/internal/forecast"
+	"weather-app/internal/provider"
+)
+
+// maxPollenDays is how far ahead the Open-Meteo air quality model
+// forecasts pollen.
+const maxPollenDays = 4
+
+func fetchPollen(ctx context.Context, p provider.Provider, place forecast.Location, days int) (forecast.Forecast, error) {
+	pollen, ok := p.(provider.PollenProvider)
+	if !ok {
+		return forecast.Forecast{}, fmt.Errorf("%s does not forecast pollen, try -provider open-meteo", p.Name())
+	}
+	f, err := pollen.PollenForecast(ctx, place, days)
+	if err != nil {
+		return f, err
+	}
+	if len(f.Pollen) == 0 {
+		return f, fmt.Errorf("No pollen forecast for %s, pollen is only forecast in Europe", place.Name)
+	}
+	return f, nil
+}
+
+func runPollen(ctx context.Context, args []string) {
+	fs := flag.NewFlagSet("pollen", flag.ExitOnError)
+	var loc locationFlags
+	var client clientFlags
+	loc.register(fs)
+	client.register(fs)
+	days := fs.Int("days", maxPollenDays, fmt.Sprintf("Number of forecast days (1-%d) - Optional", maxPollenDays))
+	var out outputFlags
+	out.register(fs)
+
+	fs.Usage = func() {
+		fmt.Println("Pollen forecast for allergy sufferers: the day's highest count of alder,")
+		fmt.Println("birch, olive, grass, mugwort and ragweed pollen in grains/m³, rated from")
+		fmt.Println("low to very high. Open-Meteo forecasts pollen in Europe only.")
+		fmt.Println("Usage:")
+		fmt.Println("  weather-app pollen [flags]")
+		fmt.Println()
+		printLocationUsage()
+		fmt.Println()
+		fmt.Println("Optional Flags:")
+		fmt.Printf("  -days           Number of forecast days (default %d, max %d)\n", maxPollenDays, maxPollenDays)
+		printOutputUsage()
+		printClientUsage()
+	}
+
+	parseLocation(fs, &loc, args)
+
+	if err := out.validate(); err != nil {
+		fatalUsage(err)
+	}
+	client.language = out.lang
+	switch out.format {
+	case "table", "json", "yaml", "csv":
+	default:
+		fatalUsage("pollen forecasts can only be shown as table, json, yaml or csv")
+	}
+	if *days < 1 || *days > maxPollenDays {
+		fatalUsage(fmt.Sprintf("-days must be between 1 and %d", maxPollenDays))
+	}
+
+	if err := client.setup(slog.LevelWarn); err != nil {
+		fatal(err)
+	}
+
+	p, err := client.newProvider()
+	if err != nil {
+		fatal(err)
+	}
+
+	place, err := loc.resolve(ctx, p)
+	if err != nil {
+		fatal(err)
+	}
+
+	f, err := fetchPollen(ctx, p, place, *days)
+	if err != nil {
+		fatal(err)
+	}
+
+	if err := out.render(f); err != nil {
+		fatal(err)
+	}
+}
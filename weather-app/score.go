@@ -0,0 +1,47 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"weather-app/internal/activity"
+	"weather-app/internal/config"
+)
+
+// scoreProfile returns the profile of the -score activity name: a built-in
+// one with the changes of its [score.NAME] table in the config file, or an
+// activity that table adds, starting from activity.Default.
+func scoreProfile(name string, cfg config.Config) (activity.Profile, error) {
+	p, builtin := activity.Profiles[name]
+	custom, configured := cfg.Score[name]
+	if !builtin && !configured {
+		return activity.Profile{}, fmt.Errorf("Unknown -score activity %q: use %s, or add a [score.%s] table to the config file",
+			name, strings.Join(activity.Names(), ", "), name)
+	}
+	if !builtin {
+		p = activity.Default
+	}
+	for _, v := range []struct {
+		from *float64
+		to   *float64
+	}{
+		{custom.IdealLow, &p.IdealLow},
+		{custom.IdealHigh, &p.IdealHigh},
+		{custom.Tolerance, &p.Tolerance},
+		{custom.MaxWind, &p.MaxWind},
+		{custom.MaxPrecip, &p.MaxPrecip},
+		{custom.MaxUV, &p.MaxUV},
+		{custom.TemperatureWeight, &p.TemperatureWeight},
+		{custom.PrecipitationWeight, &p.PrecipitationWeight},
+		{custom.WindWeight, &p.WindWeight},
+		{custom.UVWeight, &p.UVWeight},
+	} {
+		if v.from != nil {
+			*v.to = *v.from
+		}
+	}
+	if p.IdealLow > p.IdealHigh {
+		return activity.Profile{}, fmt.Errorf("[score.%s]: ideal_low must not be above ideal_high", name)
+	}
+	return p, nil
+}
@@ -0,0 +1,300 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"weather-app/internal/forecast"
+	"weather-app/internal/i18n"
+	"weather-app/internal/provider"
+	"weather-app/pkg/nws"
+	"weather-app/pkg/openmeteo"
+	"weather-app/pkg/weatherpb"
+)
+
+type server struct {
+	provider provider.Provider
+}
+
+type httpError struct {
+	status int
+	err    error
+}
+
+func (e *httpError) Error() string {
+	return e.err.Error()
+}
+
+func badRequest(format string, args ...any) error {
+	return &httpError{status: http.StatusBadRequest, err: fmt.Errorf(format, args...)}
+}
+
+func (s *server) routes() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /forecast", s.handleForecast)
+	mux.HandleFunc("GET /forecast/hourly", s.handleHourly)
+	mux.HandleFunc("GET /current", s.handleCurrent)
+	mux.HandleFunc("GET /healthz", func(w http.ResponseWriter, r *http.Request) {
+		writeJSONResponse(w, http.StatusOK, map[string]string{"status": "ok"})
+	})
+	return mux
+}
+
+func writeJSONResponse(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	status := http.StatusBadGateway
+	var he *httpError
+	switch {
+	case errors.As(err, &he):
+		status = he.status
+	case errors.Is(err, openmeteo.ErrCityNotFound), errors.Is(err, nws.ErrUnsupportedLocation):
+		status = http.StatusNotFound
+	case errors.Is(err, openmeteo.ErrAPIUnavailable):
+		status = http.StatusServiceUnavailable
+	}
+	writeJSONResponse(w, status, map[string]string{"error": err.Error()})
+}
+
+func queryBool(q url.Values, name string) (bool, error) {
+	v := q.Get(name)
+	if v == "" {
+		return false, nil
+	}
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		return false, badRequest("invalid value for %s: %q", name, v)
+	}
+	return b, nil
+}
+
+func queryOptions(q url.Values) (forecastOptions, error) {
+	var opts forecastOptions
+	fields := map[string]*bool{
+		"p":        &opts.Precipitation,
+		"uv":       &opts.UVIndex,
+		"sunrise":  &opts.Sunrise,
+		"sunset":   &opts.Sunset,
+		"daylight": &opts.Daylight,
+		"f":        &opts.Fahrenheit,
+		"wind":     &opts.Wind,
+		"feels":    &opts.Feels,
+		"snow":     &opts.Snow,
+		"humidity": &opts.Humidity,
+		"pressure": &opts.Pressure,
+		"clouds":   &opts.Clouds,
+		"ensemble": &opts.Ensemble,
+	}
+	for name, field := range fields {
+		b, err := queryBool(q, name)
+		if err != nil {
+			return forecastOptions{}, err
+		}
+		*field = b
+	}
+	opts.Units = q.Get("units")
+	opts.WindUnit = q.Get("wind_unit")
+	opts.Model = q.Get("model")
+	if q.Has("days") {
+		days, err := strconv.Atoi(q.Get("days"))
+		if err != nil {
+			return forecastOptions{}, badRequest("invalid days %q", q.Get("days"))
+		}
+		opts.Days = days
+	}
+	if q.Has("past_days") {
+		pastDays, err := strconv.Atoi(q.Get("past_days"))
+		if err != nil {
+			return forecastOptions{}, badRequest("invalid past_days %q", q.Get("past_days"))
+		}
+		opts.PastDays = pastDays
+	}
+	if q.Has("elevation") {
+		elevation, err := strconv.ParseFloat(q.Get("elevation"), 64)
+		if err != nil {
+			return forecastOptions{}, badRequest("invalid elevation %q", q.Get("elevation"))
+		}
+		opts.Elevation = &elevation
+	}
+	if err := opts.validate(); err != nil {
+		return forecastOptions{}, &httpError{status: http.StatusBadRequest, err: err}
+	}
+	if len(opts.Models) > 1 {
+		return forecastOptions{}, badRequest("only one model can be requested")
+	}
+	return opts, nil
+}
+
+// queryLocation resolves either lat/lon or city/country query parameters.
+// Ambiguous city names resolve to the first match.
+func (s *server) queryLocation(ctx context.Context, q url.Values) (forecast.Location, error) {
+	if q.Has("lat") || q.Has("lon") {
+		lat, err := strconv.ParseFloat(q.Get("lat"), 64)
+		if err != nil {
+			return forecast.Location{}, badRequest("invalid lat %q", q.Get("lat"))
+		}
+		lon, err := strconv.ParseFloat(q.Get("lon"), 64)
+		if err != nil {
+			return forecast.Location{}, badRequest("invalid lon %q", q.Get("lon"))
+		}
+		if err := validateCoordinates(lat, lon); err != nil {
+			return forecast.Location{}, &httpError{status: http.StatusBadRequest, err: err}
+		}
+		return forecast.Location{Latitude: lat, Longitude: lon}, nil
+	}
+
+	city, country := q.Get("city"), q.Get("country")
+	if city == "" || country == "" {
+		return forecast.Location{}, badRequest("either city and country or lat and lon are required")
+	}
+	matches, err := s.provider.Geocode(ctx, city, country)
+	if err != nil {
+		return forecast.Location{}, err
+	}
+	return matches[0].Location, nil
+}
+
+func (s *server) handle(w http.ResponseWriter, r *http.Request, fetch func(context.Context, forecast.Location, forecastOptions) (forecast.Forecast, error)) {
+	q := r.URL.Query()
+	opts, err := queryOptions(q)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	lang, err := i18n.Parse(q.Get("lang"))
+	if err != nil {
+		writeError(w, &httpError{status: http.StatusBadRequest, err: err})
+		return
+	}
+	place, err := s.queryLocation(r.Context(), q)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	f, err := fetch(r.Context(), place, opts)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSONResponse(w, http.StatusOK, i18n.Translate(f, lang))
+}
+
+func (s *server) handleForecast(w http.ResponseWriter, r *http.Request) {
+	s.handle(w, r, func(ctx context.Context, place forecast.Location, opts forecastOptions) (forecast.Forecast, error) {
+		return fetchDaily(ctx, s.provider, place, opts)
+	})
+}
+
+func (s *server) handleHourly(w http.ResponseWriter, r *http.Request) {
+	hours := 24
+	if v := r.URL.Query().Get("hours"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > 384 {
+			writeError(w, badRequest("hours must be between 1 and 384"))
+			return
+		}
+		hours = n
+	}
+	s.handle(w, r, func(ctx context.Context, place forecast.Location, opts forecastOptions) (forecast.Forecast, error) {
+		return fetchHourly(ctx, s.provider, place, opts, hours)
+	})
+}
+
+func (s *server) handleCurrent(w http.ResponseWriter, r *http.Request) {
+	s.handle(w, r, func(ctx context.Context, place forecast.Location, opts forecastOptions) (forecast.Forecast, error) {
+		return fetchCurrent(ctx, s.provider, place, opts)
+	})
+}
+
+func runServe(ctx context.Context, args []string) {
+	fs := flag.NewFlagSet("serve", flag.ExitOnError)
+	var client clientFlags
+	client.register(fs)
+	addr := fs.String("addr", ":8080", "Address to listen on - Optional")
+	grpcAddr := fs.String("grpc", "", "Also serve the gRPC WeatherService on this address, e.g. :9090 - Optional")
+
+	fs.Usage = func() {
+		fmt.Println("Serve forecasts as JSON over HTTP.")
+		fmt.Println("Usage:")
+		fmt.Println("  weather-app serve [flags]")
+		fmt.Println()
+		fmt.Println("Endpoints:")
+		fmt.Println("  GET /forecast?city=...&country=...   Daily forecast (or lat=...&lon=...)")
+		fmt.Println("  GET /forecast/hourly?...&hours=24    Hourly forecast")
+		fmt.Println("  GET /current?...                     Current conditions")
+		fmt.Println("  GET /healthz                         Health check")
+		fmt.Println()
+		fmt.Println("  Forecast endpoints accept p, uv, sunrise, sunset, daylight, feels, snow, humidity, pressure,")
+		fmt.Println("  clouds, ensemble, f and wind as boolean parameters, matching the command-line flags, units, wind_unit,")
+		fmt.Println("  days, model and lang, which translates the weather descriptions.")
+		fmt.Println()
+		fmt.Println("Optional Flags:")
+		fmt.Println("  -addr           Address to listen on (default :8080)")
+		fmt.Println("  -grpc           Also serve the gRPC WeatherService of pkg/weatherpb/weather.proto on this")
+		fmt.Println("                  address, e.g. :9090")
+		printClientUsage()
+	}
+
+	fs.Parse(args)
+
+	if err := client.setup(slog.LevelInfo); err != nil {
+		fatal(err)
+	}
+
+	p, err := client.newProvider()
+	if err != nil {
+		fatal(err)
+	}
+	s := &server{provider: p}
+	srv := &http.Server{
+		Addr:              *addr,
+		Handler:           s.routes(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	var grpcSrv *grpc.Server
+	if *grpcAddr != "" {
+		lis, err := net.Listen("tcp", *grpcAddr)
+		if err != nil {
+			fatal(err)
+		}
+		grpcSrv = grpc.NewServer()
+		weatherpb.RegisterWeatherServiceServer(grpcSrv, &grpcServer{provider: p})
+		slog.Info("listening", "grpc", *grpcAddr)
+		go func() {
+			if err := grpcSrv.Serve(lis); err != nil {
+				fatal(err)
+			}
+		}()
+	}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if grpcSrv != nil {
+			grpcSrv.GracefulStop()
+		}
+		srv.Shutdown(shutdownCtx)
+	}()
+
+	slog.Info("listening", "addr", *addr)
+	if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		fatal(err)
+	}
+}
@@ -0,0 +1,15 @@
+package main
+
+import (
+	"weather-app/internal/forecast"
+	"weather-app/internal/units"
+)
+
+// wetDay is the precipitation in mm from which -summary counts a day as
+// rainy, the usual threshold in climate statistics.
+const wetDay = 1.0
+
+// summarize sets the -summary of f's days.
+func summarize(f *forecast.Forecast) {
+	f.Summary = forecast.Summarize(f.Days, units.Length(wetDay, units.Millimetres, f.Units.Precipitation))
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"weather-app/internal/timer"
+	"weather-app/internal/units"
+)
+
+// notifyCommand returns the command line the timer runs: this executable's
+// notify with the flags given to install-timer, except its own.
+func notifyCommand(fs *flag.FlagSet, own ...string) ([]string, error) {
+	exe, err := os.Executable()
+	if err != nil {
+		return nil, err
+	}
+	if exe, err = filepath.EvalSymlinks(exe); err != nil {
+		return nil, err
+	}
+	// go run builds into a temporary directory that is removed on exit.
+	if strings.Contains(exe, string(filepath.Separator)+"go-build") {
+		return nil, errors.New("install-timer needs an installed weather-app, not go run: install it with go install first")
+	}
+	cmd := []string{exe, "notify"}
+	fs.Visit(func(f *flag.Flag) {
+		for _, name := range own {
+			if f.Name == name {
+				return
+			}
+		}
+		cmd = append(cmd, "-"+f.Name+"="+f.Value.String())
+	})
+	return cmd, nil
+}
+
+func runInstallTimer(ctx context.Context, args []string) {
+	fs := flag.NewFlagSet("install-timer", flag.ExitOnError)
+	var loc locationFlags
+	var client clientFlags
+	loc.register(fs)
+	client.register(fs)
+	var opts forecastOptions
+	fs.BoolVar(&opts.Fahrenheit, "f", false, "Use fahrenheit - Optional")
+	fs.StringVar(&opts.Units, "units", string(units.Metric), "Unit system: "+strings.Join(units.Systems, ", ")+" - Optional")
+	at := fs.String("at", "07:00", "Time of day to notify, HH:MM - Optional")
+	printOnly := fs.Bool("print", false, "Print the files and commands instead of installing them - Optional")
+
+	fs.Usage = func() {
+		fmt.Println("Run notify every day at a chosen time, with a systemd user timer on Linux,")
+		fmt.Println("a launchd agent on macOS or a scheduled task on Windows.")
+		fmt.Println("Usage:")
+		fmt.Println("  weather-app install-timer [flags]")
+		fmt.Println()
+		printLocationUsage()
+		fmt.Println()
+		fmt.Println("Optional Flags:")
+		fmt.Println("  -at             Time of day to notify, HH:MM (default 07:00)")
+		fmt.Println("  -units          Unit system: metric, imperial or si (default metric)")
+		fmt.Println("  -f              Use fahrenheit whatever the unit system")
+		fmt.Println("  -print          Print the files and commands instead of installing them")
+		printClientUsage()
+		fmt.Println()
+		fmt.Println("  The location and unit flags are passed on to notify. Run it again to change")
+		fmt.Println("  the time or the location.")
+	}
+
+	configPath := fs.String("config", "", "Path to the config file - Optional")
+	fs.Parse(args)
+	command, err := notifyCommand(fs, "at", "print")
+	if err != nil {
+		fatal(err)
+	}
+	configureLocation(fs, &loc, *configPath)
+
+	if err := opts.validate(); err != nil {
+		fatalUsage(err)
+	}
+	t, err := time.Parse("15:04", *at)
+	if err != nil {
+		fatalUsage(fmt.Sprintf("Invalid -at %q, expected HH:MM, e.g. 07:30", *at))
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		fatal(err)
+	}
+	plan, err := timer.NewPlan(runtime.GOOS, home, timer.Job{Command: command, Hour: t.Hour(), Minute: t.Minute()})
+	if err != nil {
+		fatal(err)
+	}
+
+	if *printOnly {
+		for _, f := range plan.Files {
+			fmt.Printf("# %s\n%s\n", f.Path, f.Data)
+		}
+		for _, args := range plan.Commands {
+			fmt.Println(strings.Join(args, " "))
+		}
+		return
+	}
+	if err := plan.Run(ctx); err != nil {
+		fatal(err)
+	}
+	fmt.Printf("Installed %s: the forecast is shown every day at %s\n", timer.Name, t.Format("15:04"))
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"weather-app/internal/httpclient"
+	"weather-app/internal/selfupdate"
+)
+
+func runUpdate(ctx context.Context, args []string) {
+	fs := flag.NewFlagSet("update", flag.ExitOnError)
+	check := fs.Bool("check", false, "Only report whether a newer release exists - Optional")
+	force := fs.Bool("force", false, "Install the latest release even if it is not newer - Optional")
+	fs.Usage = func() {
+		fmt.Println("Replace this executable with the latest release from GitHub, after")
+		fmt.Println("verifying the signature of the release's SHA-256 checksums and checking")
+		fmt.Println("the download against them. Only release builds update: a build from")
+		fmt.Println("source has no version to compare and no key to verify with.")
+		fmt.Println("Usage:")
+		fmt.Println("  weather-app update [flags]")
+		fmt.Println()
+		fmt.Println("Optional Flags:")
+		fmt.Println("  -check          Only report whether a newer release exists")
+		fmt.Println("  -force          Install the latest release even if it is not newer")
+	}
+	fs.Parse(args)
+
+	current := currentBuild().Version
+	if !selfupdate.Released(current) {
+		fatal(fmt.Errorf("weather-app %s is not a release build, update it from source or install a release", current))
+	}
+	u := &selfupdate.Updater{Client: httpclient.New(selfupdate.DefaultTimeout)}
+	release, err := u.Latest(ctx)
+	if err != nil {
+		fatal(err)
+	}
+	if !*force && !selfupdate.Newer(release.Tag, current) {
+		fmt.Printf("weather-app %s is up to date\n", current)
+		return
+	}
+	if *check {
+		fmt.Printf("weather-app %s is available, this is %s: run weather-app update to install it\n", release.Tag, current)
+		return
+	}
+
+	exe, err := os.Executable()
+	if err != nil {
+		fatal(err)
+	}
+	if exe, err = filepath.EvalSymlinks(exe); err != nil {
+		fatal(err)
+	}
+	if strings.Contains(exe, string(filepath.Separator)+"go-build") {
+		fatal(errors.New("update needs an installed weather-app, not go run"))
+	}
+	data, err := u.Download(ctx, release, runtime.GOOS, runtime.GOARCH)
+	if err != nil {
+		fatal(err)
+	}
+	if err := selfupdate.Replace(exe, data, runtime.GOOS); err != nil {
+		fatal(err)
+	}
+	fmt.Printf("Updated %s from %s to %s\n", exe, current, release.Tag)
+}
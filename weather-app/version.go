@@ -0,0 +1,101 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"runtime"
+	"runtime/debug"
+)
+
+// Set at build time, e.g.
+//
+//	go build -ldflags "-X main.version=v1.2.3 -X main.commit=$(git rev-parse HEAD) -X main.date=$(date -u +%FT%TZ)"
+//
+// Builds without them fall back to what the Go toolchain embedded.
+var (
+	version = "dev"
+	commit  = ""
+	date    = ""
+)
+
+type buildInfo struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit,omitempty"`
+	Date      string `json:"date,omitempty"`
+	Modified  bool   `json:"modified,omitempty"`
+	GoVersion string `json:"go_version"`
+	Platform  string `json:"platform"`
+}
+
+// currentBuild describes this binary. go install of a tagged release
+// records its version, and builds from a git checkout the commit.
+func currentBuild() buildInfo {
+	b := buildInfo{
+		Version:   version,
+		Commit:    commit,
+		Date:      date,
+		GoVersion: runtime.Version(),
+		Platform:  runtime.GOOS + "/" + runtime.GOARCH,
+	}
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return b
+	}
+	if b.Version == "dev" && info.Main.Version != "" && info.Main.Version != "(devel)" {
+		b.Version = info.Main.Version
+	}
+	for _, s := range info.Settings {
+		switch s.Key {
+		case "vcs.revision":
+			if b.Commit == "" {
+				b.Commit = s.Value
+			}
+		case "vcs.time":
+			if b.Date == "" {
+				b.Date = s.Value
+			}
+		case "vcs.modified":
+			b.Modified = s.Value == "true"
+		}
+	}
+	return b
+}
+
+func runVersion(args []string) {
+	fs := flag.NewFlagSet("version", flag.ExitOnError)
+	asJSON := fs.Bool("json", false, "Print the build info as JSON - Optional")
+	fs.Usage = func() {
+		fmt.Println("Print the version, commit and build date of this binary.")
+		fmt.Println("Usage:")
+		fmt.Println("  weather-app version [flags]")
+		fmt.Println()
+		fmt.Println("Optional Flags:")
+		fmt.Println("  -json           Print the build info as JSON")
+	}
+	fs.Parse(args)
+
+	b := currentBuild()
+	if *asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(b); err != nil {
+			fatal(err)
+		}
+		return
+	}
+	fmt.Printf("weather-app %s\n", b.Version)
+	if b.Commit != "" {
+		modified := ""
+		if b.Modified {
+			modified = " (modified)"
+		}
+		fmt.Printf("  commit:   %s%s\n", b.Commit, modified)
+	}
+	if b.Date != "" {
+		fmt.Printf("  built:    %s\n", b.Date)
+	}
+	fmt.Printf("  go:       %s\n", b.GoVersion)
+	fmt.Printf("  platform: %s\n", b.Platform)
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+)
+
+// clearScreen moves the cursor home and clears the terminal.
+const clearScreen = "\033[H\033[2J"
+
+// watch calls show right away and then every interval until ctx is done.
+// Only a failing first draw is fatal; later errors are printed below the
+// previous output, which is kept until the next attempt. show clears the
+// screen itself as it prints, see outputFlags.clear.
+func watch(ctx context.Context, interval time.Duration, show func() error) error {
+	draw := func() error {
+		err := show()
+		if err == nil {
+			fmt.Fprintf(os.Stderr, "\nUpdated %s, refreshing every %s (Ctrl+C to stop)\n", time.Now().Format("15:04:05"), interval)
+		}
+		return err
+	}
+
+	if err := draw(); err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := draw(); err != nil && ctx.Err() == nil {
+				fmt.Fprintf(os.Stderr, "Refresh failed at %s: %v\n", time.Now().Format("15:04:05"), err)
+			}
+		}
+	}
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"fmt"
+
+	"weather-app/internal/clothing"
+	"weather-app/internal/config"
+)
+
+// wearRules returns the rules of -what-to-wear: the [[wear]] tables of the
+// config file when it has any, else the built-in ones.
+func wearRules(cfg config.Config) ([]clothing.Rule, error) {
+	if len(cfg.Wear) == 0 {
+		return clothing.Rules, nil
+	}
+	rules := make([]clothing.Rule, len(cfg.Wear))
+	for i, r := range cfg.Wear {
+		if r.Item == "" {
+			return nil, fmt.Errorf("[[wear]] table %d has no item", i+1)
+		}
+		rules[i] = clothing.Rule{
+			Item:              r.Item,
+			FeelsBelow:        r.FeelsBelow,
+			FeelsAbove:        r.FeelsAbove,
+			PrecipChanceAbove: r.PrecipChanceAbove,
+			WindAbove:         r.WindAbove,
+			UVAbove:           r.UVAbove,
+		}
+	}
+	return rules, nil
+}
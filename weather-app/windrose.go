@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+
+	"weather-app/internal/forecast"
+	"weather-app/internal/provider"
+	"weather-app/internal/render"
+	"weather-app/internal/units"
+)
+
+// calmWind is the speed in km/h below which the wind counts as calm in a
+// wind rose, as on the Beaufort scale.
+const calmWind = 1.0
+
+// fetchWindRose returns the wind rose of the hourly forecast for the next
+// opts.Days days, in the units of opts.
+func fetchWindRose(ctx context.Context, p provider.Provider, place forecast.Location, opts forecastOptions) (forecast.WindRose, error) {
+	f, err := fetchHourly(ctx, p, place, opts, opts.Days*24)
+	if err != nil {
+		return forecast.WindRose{}, err
+	}
+	calm := units.Speed(calmWind, units.KilometresPerHour, f.Units.WindSpeed)
+	return forecast.NewWindRose(f, calm), nil
+}
+
+func runWindRose(ctx context.Context, args []string) {
+	fs := flag.NewFlagSet("wind-rose", flag.ExitOnError)
+	var loc locationFlags
+	var client clientFlags
+	loc.register(fs)
+	client.register(fs)
+	var opts forecastOptions
+	fs.StringVar(&opts.Units, "units", string(units.Metric), "Unit system: "+strings.Join(units.Systems, ", ")+" - Optional")
+	fs.StringVar(&opts.WindUnit, "wind-unit", "", "Wind speed unit: kmh, ms, mph or kn, overriding -units - Optional")
+	fs.IntVar(&opts.Days, "days", defaultDays, "Number of forecast days (1-16) - Optional")
+	var out outputFlags
+	out.register(fs)
+
+	fs.Usage = func() {
+		fmt.Println("How often the wind will blow from each direction over the next days, drawn")
+		fmt.Println("as a wind rose from the hourly forecast.")
+		fmt.Println("Usage:")
+		fmt.Println("  weather-app wind-rose [flags]")
+		fmt.Println()
+		printLocationUsage()
+		fmt.Println()
+		fmt.Println("Optional Flags:")
+		fmt.Println("  -days           Number of forecast days (default 7, max 16)")
+		fmt.Println("  -units          Unit system: metric, imperial or si (default metric)")
+		fmt.Println("  -wind-unit      Wind speed unit: kmh, ms, mph or kn, overriding -units")
+		printOutputUsage()
+		printClientUsage()
+		fmt.Println()
+		fmt.Println("  Hours with less than 1 km/h of wind are counted as calm.")
+	}
+
+	parseLocation(fs, &loc, args)
+
+	if err := out.validate(); err != nil {
+		fatalUsage(err)
+	}
+	client.language = out.lang
+	switch out.format {
+	case "table", "json", "yaml":
+	default:
+		fatalUsage("A wind rose can only be shown as table, json or yaml")
+	}
+	if err := opts.validate(); err != nil {
+		fatalUsage(err)
+	}
+
+	if err := client.setup(slog.LevelWarn); err != nil {
+		fatal(err)
+	}
+
+	p, err := client.newProvider()
+	if err != nil {
+		fatal(err)
+	}
+
+	place, err := loc.resolve(ctx, p)
+	if err != nil {
+		fatal(err)
+	}
+
+	rose, err := fetchWindRose(ctx, p, place, opts)
+	if err != nil {
+		fatal(err)
+	}
+
+	err = out.write(func(w io.Writer) error {
+		return render.WindRose(w, out.format, rose, out.renderOptions(w))
+	})
+	if err != nil {
+		fatal(err)
+	}
+}